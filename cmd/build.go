@@ -16,7 +16,19 @@ var buildCmd = &cobra.Command{
   adoctl build search --branch main --status completed
 
   # Search builds and output as JSON
-  adoctl build search --status failed --json`,
+  adoctl build search --status failed --json
+
+  # Queue a pipeline run and wait for the result
+  adoctl build run --pipeline my-pipeline --branch main --wait
+
+  # Wait for the latest build of a branch and fail if it failed
+  adoctl build watch --branch main --latest
+
+  # Show the timeline and failed task logs of a build
+  adoctl build logs 1234
+
+  # Rerun the failed jobs of a build
+  adoctl build retry 1234 --failed-only`,
 }
 
 func init() {
@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"adoctl/pkg/config"
+	"adoctl/pkg/devops"
+	"adoctl/pkg/errors"
+	"adoctl/pkg/progress"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	artifactNames   []string
+	artifactOutDir  string
+	artifactExtract bool
+	// artifactTimeout bounds the whole download, separately from the API --timeout
+	artifactTimeout time.Duration
+)
+
+var buildArtifactsCmd = &cobra.Command{
+	Use:   "artifacts <build-id>",
+	Short: "List or download the artifacts of a build",
+	Long:  `List the artifacts published by a build with their type and size.`,
+	Example: `  # List the artifacts of a build
+  adoctl build artifacts 1234
+
+  # Download the drop artifact and extract it
+  adoctl build artifacts download 1234 --name drop -o ./drop-1234 --extract`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		buildID, err := parseBuildIDArg(args[0])
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		artifacts, err := svc.ListBuildArtifacts(ctx, buildID)
+		if err != nil {
+			return err
+		}
+
+		writer := NewOutputWriter(cmd.Flag("format").Value.String())
+		if writer.IsStructured() {
+			return writer.Write(artifacts)
+		}
+
+		if len(artifacts) == 0 {
+			fmt.Printf("Build %d has no artifacts\n", buildID)
+			return nil
+		}
+
+		fmt.Printf("%-40s %-18s %10s\n", "NAME", "TYPE", "SIZE")
+		for _, a := range artifacts {
+			fmt.Printf("%-40s %-18s %10s\n", a.Name, a.Type, formatArtifactSize(a.Size))
+		}
+		return nil
+	},
+}
+
+var buildArtifactsDownloadCmd = &cobra.Command{
+	Use:   "download <build-id>",
+	Short: "Download build artifacts as zips",
+	Long: `Download the artifacts of a build as zip files, in parallel. Each zip is verified
+after download; --extract also unpacks it into a directory named after the artifact.
+Without --name every artifact is downloaded.
+
+Downloads are not bound by --timeout; they give up after --download-timeout with
+exit code 9, so a stalled transfer cannot hang a CI job. Ctrl+C stops them with
+exit code 8. Incomplete zips are removed either way.`,
+	Example: `  # Download the drop artifact into the current directory
+  adoctl build artifacts download 1234 --name drop
+
+  # Download and extract every artifact
+  adoctl build artifacts download 1234 -o ./artifacts-1234 --extract`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		buildID, err := parseBuildIDArg(args[0])
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		artifacts, err := svc.ListBuildArtifacts(ctx, buildID)
+		if err != nil {
+			return err
+		}
+
+		selected, err := selectArtifacts(artifacts, artifactNames)
+		if err != nil {
+			return err
+		}
+
+		return downloadArtifacts(svc, selected)
+	},
+}
+
+// selectArtifacts picks the artifacts named in names, or all of them when names is empty
+func selectArtifacts(artifacts []devops.ArtifactInfo, names []string) ([]devops.ArtifactInfo, error) {
+	if len(names) == 0 {
+		if len(artifacts) == 0 {
+			return nil, errors.NotFoundError("build artifacts")
+		}
+		return artifacts, nil
+	}
+
+	selected := []devops.ArtifactInfo{}
+	for _, name := range names {
+		found := false
+		for _, a := range artifacts {
+			if strings.EqualFold(a.Name, name) {
+				selected = append(selected, a)
+				found = true
+				break
+			}
+		}
+		if !found {
+			available := make([]string, 0, len(artifacts))
+			for _, a := range artifacts {
+				available = append(available, a.Name)
+			}
+			return nil, errors.NotFoundErrorWithSuggestions(fmt.Sprintf("artifact '%s'", name), available)
+		}
+	}
+	return selected, nil
+}
+
+// downloadArtifacts downloads artifacts in parallel, reporting progress per artifact
+func downloadArtifacts(svc *devops.DevOpsService, artifacts []devops.ArtifactInfo) error {
+	// Downloads can outlast the API timeout, so they stop on interrupt or after
+	// their own, longer, timeout
+	interruptCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+	if artifactTimeout > 0 {
+		ctx, cancel = context.WithTimeout(interruptCtx, artifactTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(interruptCtx)
+	}
+	defer cancel()
+
+	bar := progress.NewProgressBar(len(artifacts), "Downloading artifacts")
+	bar.SetWriter(os.Stderr)
+	bar.Update(0)
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results = make([]string, len(artifacts))
+		failed  = 0
+	)
+	semaphore := make(chan struct{}, config.GetParallelProcesses())
+
+	for i, artifact := range artifacts {
+		wg.Add(1)
+		go func(i int, artifact devops.ArtifactInfo) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			if ctx.Err() != nil {
+				return
+			}
+
+			path, err := svc.DownloadArtifact(ctx, artifact, artifactOutDir, artifactExtract)
+
+			mu.Lock()
+			if err != nil {
+				failed++
+				results[i] = fmt.Sprintf("✗ %s: %v", artifact.Name, err)
+			} else {
+				results[i] = fmt.Sprintf("✓ %s → %s", artifact.Name, path)
+			}
+			mu.Unlock()
+			bar.Increment()
+		}(i, artifact)
+	}
+	wg.Wait()
+	bar.Finish()
+
+	for _, line := range results {
+		if line != "" {
+			fmt.Println(line)
+		}
+	}
+
+	if interruptCtx.Err() != nil {
+		return errors.New(errors.ExitCodeCancellation, "artifact download interrupted; incomplete zips were removed")
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return errors.New(errors.ExitCodeTimeout, fmt.Sprintf("artifact download did not finish within %s; incomplete zips were removed", artifactTimeout))
+	}
+	if failed > 0 {
+		return errors.New(errors.ExitCodeFileOperation, fmt.Sprintf("%d of %d artifacts failed to download", failed, len(artifacts)))
+	}
+	return nil
+}
+
+func formatArtifactSize(size int64) string {
+	if size <= 0 {
+		return "-"
+	}
+	units := []string{"B", "KB", "MB", "GB", "TB"}
+	value := float64(size)
+	unit := 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%d B", size)
+	}
+	return fmt.Sprintf("%.1f %s", value, units[unit])
+}
+
+func parseBuildIDArg(arg string) (int, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+	if err != nil || id <= 0 {
+		return 0, errors.ValidationError(fmt.Sprintf("invalid build ID %q", arg))
+	}
+	return id, nil
+}
+
+func init() {
+	buildArtifactsDownloadCmd.Flags().StringArrayVar(&artifactNames, "name", nil, "Artifact name to download (repeatable; default: all)")
+	buildArtifactsDownloadCmd.Flags().StringVarP(&artifactOutDir, "output", "o", ".", "Directory to download into")
+	buildArtifactsDownloadCmd.Flags().BoolVar(&artifactExtract, "extract", false, "Extract each zip into a directory named after the artifact")
+	buildArtifactsDownloadCmd.Flags().DurationVar(&artifactTimeout, "download-timeout", time.Hour, "Maximum time for all downloads (0 for no limit)")
+
+	buildArtifactsCmd.AddCommand(buildArtifactsDownloadCmd)
+}
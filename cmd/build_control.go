@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"adoctl/pkg/devops"
+	"adoctl/pkg/errors"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	buildRetryFailedOnly bool
+	buildRetainDays      int
+	buildTagNames        []string
+)
+
+const buildIDsHelp = `Build IDs are given as arguments, or "-" reads them from stdin: one ID per
+line, the text output of "build search", or its --json output.`
+
+var buildCancelCmd = &cobra.Command{
+	Use:   "cancel <build-id>... | -",
+	Short: "Cancel queued or running builds",
+	Long:  "Cancel queued or running builds.\n\n" + buildIDsHelp,
+	Example: `  # Cancel a build
+  adoctl build cancel 1234
+
+  # Cancel every running build of a branch
+  adoctl build search --branch feature/x --status inProgress --json | adoctl build cancel - --yes`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ids, err := resolveBuildIDArgs(args, os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		return runBuildAction("cancel builds", ids, nil, func(ctx context.Context, svc *devops.DevOpsService, id int) (string, error) {
+			if _, err := svc.CancelBuild(ctx, id); err != nil {
+				return "", err
+			}
+			return "cancellation requested", nil
+		})
+	},
+}
+
+var buildRetryCmd = &cobra.Command{
+	Use:   "retry <build-id>... | -",
+	Short: "Rerun builds, or only their failed stages and jobs",
+	Long: `Rerun builds. By default a new run of the same pipeline is queued on the same
+branch and commit with the same parameters. With --failed-only the failed and
+canceled stages and jobs are rerun in place, within the original build.
+
+` + buildIDsHelp,
+	Example: `  # Queue a new run of a build
+  adoctl build retry 1234
+
+  # Rerun only the failed jobs of a build
+  adoctl build retry 1234 --failed-only
+
+  # Rerun the failed jobs of all failed builds of a PR
+  adoctl build search --pr 42 --status completed --json | adoctl build retry - --failed-only --yes`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ids, err := resolveBuildIDArgs(args, os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		if buildRetryFailedOnly {
+			details := map[string]string{"Mode": "rerun failed stages and jobs in place"}
+			return runBuildAction("retry failed jobs", ids, details, func(ctx context.Context, svc *devops.DevOpsService, id int) (string, error) {
+				if _, err := svc.RetryFailedJobs(ctx, id); err != nil {
+					return "", err
+				}
+				return "failed jobs requeued", nil
+			})
+		}
+
+		details := map[string]string{"Mode": "queue a new run on the same branch and commit"}
+		return runBuildAction("rerun builds", ids, details, func(ctx context.Context, svc *devops.DevOpsService, id int) (string, error) {
+			b, err := svc.GetBuild(ctx, id)
+			if err != nil {
+				return "", err
+			}
+			queued, err := svc.RequeueBuild(ctx, b)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("queued build %d: %s", *queued.Id, svc.BuildWebURL(*queued.Id)), nil
+		})
+	},
+}
+
+var buildRetainCmd = &cobra.Command{
+	Use:   "retain <build-id>... | -",
+	Short: "Retain builds for a number of days",
+	Long: `Retain builds for a number of days by adding a retention lease owned by you.
+
+` + buildIDsHelp,
+	Example: `  # Keep a build for 90 days
+  adoctl build retain 1234 --days 90
+
+  # Keep every build of a release branch for a year
+  adoctl build search --branch release/1.0 --json | adoctl build retain - --days 365 --yes`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if buildRetainDays <= 0 {
+			return errors.ValidationError("--days must be greater than 0")
+		}
+
+		ids, err := resolveBuildIDArgs(args, os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		details := map[string]string{"Days": strconv.Itoa(buildRetainDays)}
+		return runBuildAction("retain builds", ids, details, func(ctx context.Context, svc *devops.DevOpsService, id int) (string, error) {
+			b, err := svc.GetBuild(ctx, id)
+			if err != nil {
+				return "", err
+			}
+			if err := svc.RetainBuild(ctx, b, buildRetainDays); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("retained for %d days", buildRetainDays), nil
+		})
+	},
+}
+
+var buildTagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Add or remove build tags",
+}
+
+var buildTagAddCmd = &cobra.Command{
+	Use:   "add <build-id>... | -",
+	Short: "Add tags to builds",
+	Long:  "Add tags to builds.\n\n" + buildIDsHelp,
+	Example: `  # Tag a build
+  adoctl build tag add 1234 --tag release-candidate
+
+  # Tag several builds with two tags
+  adoctl build tag add 1234 1235 --tag verified --tag qa`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ids, err := resolveBuildIDArgs(args, os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		details := map[string]string{"Tags": strings.Join(buildTagNames, ", ")}
+		return runBuildAction("add build tags", ids, details, func(ctx context.Context, svc *devops.DevOpsService, id int) (string, error) {
+			tags, err := svc.AddBuildTags(ctx, id, buildTagNames)
+			if err != nil {
+				return "", err
+			}
+			return "tags: " + formatListOrNone(tags), nil
+		})
+	},
+}
+
+var buildTagRemoveCmd = &cobra.Command{
+	Use:   "remove <build-id>... | -",
+	Short: "Remove tags from builds",
+	Long:  "Remove tags from builds.\n\n" + buildIDsHelp,
+	Example: `  # Remove a tag from a build
+  adoctl build tag remove 1234 --tag release-candidate`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ids, err := resolveBuildIDArgs(args, os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		details := map[string]string{"Tags": strings.Join(buildTagNames, ", ")}
+		return runBuildAction("remove build tags", ids, details, func(ctx context.Context, svc *devops.DevOpsService, id int) (string, error) {
+			tags, err := svc.RemoveBuildTags(ctx, id, buildTagNames)
+			if err != nil {
+				return "", err
+			}
+			return "tags: " + formatListOrNone(tags), nil
+		})
+	},
+}
+
+// runBuildAction confirms an action on a set of builds, then applies fn to each
+// build in turn, reporting each outcome. It fails if any build failed.
+func runBuildAction(action string, ids []int, details map[string]string, fn func(context.Context, *devops.DevOpsService, int) (string, error)) error {
+	allDetails := copyDetails(details)
+	allDetails["Builds"] = formatBuildIDs(ids)
+
+	if IsDryRun() {
+		PrintDryRunAction(action, allDetails)
+		return nil
+	}
+
+	if err := RequireConfirmation(action, allDetails); err != nil {
+		return err
+	}
+
+	svc, err := devops.NewServiceFromEnv()
+	if err != nil {
+		return fmt.Errorf("failed to create devops service: %w", err)
+	}
+	defer svc.Close()
+
+	failed := 0
+	for _, id := range ids {
+		ctx, cancel := GetContext()
+		message, err := fn(ctx, svc, id)
+		cancel()
+
+		if err != nil {
+			failed++
+			fmt.Printf("✗ Build %d: %v\n", id, err)
+			continue
+		}
+		fmt.Printf("✓ Build %d: %s\n", id, message)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to %s: %d of %d builds failed", action, failed, len(ids))
+	}
+	return nil
+}
+
+func formatBuildIDs(ids []int) string {
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, strconv.Itoa(id))
+	}
+	return fmt.Sprintf("%d (%s)", len(ids), strings.Join(parts, ", "))
+}
+
+// resolveBuildIDArgs parses build ID arguments; a single "-" reads them from stdin
+func resolveBuildIDArgs(args []string, stdin io.Reader) ([]int, error) {
+	if len(args) == 1 && args[0] == "-" {
+		// The confirmation prompt reads stdin too, so it cannot be answered here
+		if !IsAssumeYes() && !IsDryRun() {
+			return nil, errors.ValidationError("reading build IDs from stdin requires --yes or --dry-run")
+		}
+		ids, err := parseBuildIDs(stdin)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			return nil, errors.ValidationError("no build IDs found on stdin")
+		}
+		return ids, nil
+	}
+
+	ids := make([]int, 0, len(args))
+	for _, arg := range args {
+		id, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+		if err != nil || id <= 0 {
+			return nil, errors.ValidationError(fmt.Sprintf("invalid build ID %q", arg))
+		}
+		ids = append(ids, id)
+	}
+	return dedupeIDs(ids), nil
+}
+
+var buildIDLinePattern = regexp.MustCompile(`^\s*(?:Build ID:\s*)?#?(\d+)\s*$`)
+
+// parseBuildIDs reads build IDs from the JSON or text output of "build search",
+// or from a list with one ID per line
+func parseBuildIDs(r io.Reader) ([]int, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read build IDs: %w", err)
+	}
+
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "[") {
+		var builds []struct {
+			BuildID int `json:"build_id"`
+			ID      int `json:"id"`
+		}
+		if err := json.Unmarshal([]byte(trimmed), &builds); err != nil {
+			return nil, errors.ValidationError(fmt.Sprintf("failed to parse build IDs from JSON: %v", err))
+		}
+
+		ids := []int{}
+		for _, b := range builds {
+			switch {
+			case b.BuildID > 0:
+				ids = append(ids, b.BuildID)
+			case b.ID > 0:
+				ids = append(ids, b.ID)
+			}
+		}
+		return dedupeIDs(ids), nil
+	}
+
+	ids := []int{}
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		matches := buildIDLinePattern.FindStringSubmatch(scanner.Text())
+		if matches == nil {
+			continue
+		}
+		if id, err := strconv.Atoi(matches[1]); err == nil && id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read build IDs: %w", err)
+	}
+
+	return dedupeIDs(ids), nil
+}
+
+func init() {
+	buildRetryCmd.Flags().BoolVar(&buildRetryFailedOnly, "failed-only", false, "Rerun only failed and canceled stages and jobs, in place")
+
+	buildRetainCmd.Flags().IntVar(&buildRetainDays, "days", 0, "Number of days to retain the builds (required)")
+	if err := buildRetainCmd.MarkFlagRequired("days"); err != nil {
+		panic(err)
+	}
+
+	for _, c := range []*cobra.Command{buildTagAddCmd, buildTagRemoveCmd} {
+		c.Flags().StringArrayVar(&buildTagNames, "tag", nil, "Tag name (repeatable, required)")
+		if err := c.MarkFlagRequired("tag"); err != nil {
+			panic(err)
+		}
+	}
+
+	buildTagCmd.AddCommand(buildTagAddCmd)
+	buildTagCmd.AddCommand(buildTagRemoveCmd)
+}
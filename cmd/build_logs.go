@@ -0,0 +1,362 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"adoctl/pkg/devops"
+	"adoctl/pkg/errors"
+
+	"github.com/fatih/color"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/build"
+	"github.com/spf13/cobra"
+)
+
+var (
+	buildLogsAll      bool
+	buildLogsTask     string
+	buildLogsFollow   bool
+	buildLogsSaveDir  string
+	buildLogsInterval int
+)
+
+var buildLogsCmd = &cobra.Command{
+	Use:   "logs <build-id>",
+	Short: "Show the timeline and logs of a build",
+	Long: `Show the stage → job → task tree of a build with durations and results,
+followed by the logs of the failed tasks. Errors and warnings reported by the
+timeline are highlighted.
+
+Use --all to print every task log, or --task to pick tasks by name. --follow
+streams the logs of an in-progress build until it completes; the exit code then
+follows the build result. --save-dir writes the full log of every task to a directory,
+after the build completes when combined with --follow.`,
+	Example: `  # Timeline and failed task logs
+  adoctl build logs 1234
+
+  # Every task log
+  adoctl build logs 1234 --all
+
+  # Logs of the tasks whose name contains "test"
+  adoctl build logs 1234 --task test
+
+  # Follow an in-progress build
+  adoctl build logs 1234 --follow
+
+  # Save all logs to a directory
+  adoctl build logs 1234 --save-dir ./logs-1234
+
+  # Timeline as JSON
+  adoctl build logs 1234 --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		buildID, err := parseBuildIDArg(args[0])
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		b, err := svc.GetBuild(ctx, buildID)
+		if err != nil {
+			return err
+		}
+
+		writer := NewOutputWriter(cmd.Flag("format").Value.String())
+
+		if buildLogsFollow && !writer.IsStructured() && !isBuildCompleted(b) {
+			printBuildHeader(svc, b)
+			final, tree, err := followBuildLogs(svc, buildID)
+			if err != nil {
+				return err
+			}
+			// Logs are complete only once the build is, so they are saved after following
+			if buildLogsSaveDir != "" {
+				if err := saveBuildLogs(svc, buildID, devops.TimelineTasks(tree)); err != nil {
+					return err
+				}
+			}
+
+			result := ""
+			if final.Result != nil {
+				result = string(*final.Result)
+			}
+			if resultErr := errors.BuildResultError(buildID, result); resultErr != nil {
+				return resultErr
+			}
+			return nil
+		}
+
+		tree, err := svc.GetBuildTimeline(ctx, buildID)
+		if err != nil {
+			return err
+		}
+		tasks := devops.TimelineTasks(tree)
+
+		if buildLogsSaveDir != "" {
+			if err := saveBuildLogs(svc, buildID, tasks); err != nil {
+				return err
+			}
+		}
+
+		if writer.IsStructured() {
+			return writer.Write(tree)
+		}
+
+		printBuildHeader(svc, b)
+		printTimelineTree(tree, 0)
+
+		for _, task := range selectLogTasks(tasks) {
+			lines, err := svc.GetBuildLogLines(ctx, buildID, task.Node.LogID, 0)
+			if err != nil {
+				return err
+			}
+			printTaskLogHeader(task)
+			printLogLines(lines)
+		}
+
+		return nil
+	},
+}
+
+func isBuildCompleted(b *build.Build) bool {
+	return b.Status != nil && *b.Status == build.BuildStatusValues.Completed
+}
+
+func printBuildHeader(svc *devops.DevOpsService, b *build.Build) {
+	bold := color.New(color.Bold)
+
+	name := ""
+	if b.Definition != nil && b.Definition.Name != nil {
+		name = *b.Definition.Name
+	}
+	number := ""
+	if b.BuildNumber != nil {
+		number = *b.BuildNumber
+	}
+	status, result := "", ""
+	if b.Status != nil {
+		status = string(*b.Status)
+	}
+	if b.Result != nil {
+		result = string(*b.Result)
+	}
+
+	_, _ = bold.Printf("Build %d: %s %s\n", *b.Id, name, number)
+	if b.SourceBranch != nil {
+		fmt.Printf("  Branch:  %s\n", strings.TrimPrefix(*b.SourceBranch, "refs/heads/"))
+	}
+	fmt.Printf("  Status:  %s\n", devops.FormatBuildStatus(status, result))
+	fmt.Printf("  URL:     %s\n\n", svc.BuildWebURL(*b.Id))
+}
+
+// printTimelineTree prints the stage → job → task tree with results, durations and issues
+func printTimelineTree(nodes []*devops.TimelineNode, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, node := range nodes {
+		icon, c := timelineResultStyle(node)
+		line := fmt.Sprintf("%s%s %s", indent, icon, node.Name)
+		if d := node.Duration(); d > 0 {
+			line += fmt.Sprintf(" (%s)", formatLogDuration(d))
+		}
+		if node.Type != devops.RecordTask {
+			line = color.New(color.Bold).Sprint(line)
+		}
+		_, _ = c.Println(line)
+
+		for _, issue := range node.Issues {
+			printTimelineIssue(indent+"    ", issue)
+		}
+		printTimelineTree(node.Children, depth+1)
+	}
+}
+
+func timelineResultStyle(node *devops.TimelineNode) (string, *color.Color) {
+	switch node.Result {
+	case "succeeded":
+		return "✔", color.New(color.FgGreen)
+	case "succeededWithIssues":
+		return "⚠", color.New(color.FgYellow)
+	case "failed":
+		return "✖", color.New(color.FgRed)
+	case "canceled", "abandoned":
+		return "⊘", color.New(color.FgYellow)
+	case "skipped":
+		return "○", color.New(color.Faint)
+	}
+	if node.State == "inProgress" {
+		return "⟳", color.New(color.FgCyan)
+	}
+	return "◌", color.New(color.Faint)
+}
+
+func printTimelineIssue(indent string, issue devops.TimelineIssue) {
+	switch issue.Type {
+	case "error":
+		_, _ = color.New(color.FgRed).Printf("%s✖ %s\n", indent, issue.Message)
+	case "warning":
+		_, _ = color.New(color.FgYellow).Printf("%s⚠ %s\n", indent, issue.Message)
+	default:
+		fmt.Printf("%s• %s\n", indent, issue.Message)
+	}
+}
+
+func formatLogDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
+
+// selectLogTasks picks the task logs to print: --task matches, --all, or the failed tasks
+func selectLogTasks(tasks []devops.TimelineTask) []devops.TimelineTask {
+	selected := []devops.TimelineTask{}
+	for _, task := range tasks {
+		if task.Node.LogID == 0 {
+			continue
+		}
+		switch {
+		case buildLogsTask != "":
+			if matchesLogTask(task, buildLogsTask) {
+				selected = append(selected, task)
+			}
+		case buildLogsAll || task.Node.IsFailed():
+			selected = append(selected, task)
+		}
+	}
+	return selected
+}
+
+func matchesLogTask(task devops.TimelineTask, name string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(strings.ToLower(task.Node.Name), name) || strings.EqualFold(task.Label(), name)
+}
+
+func printTaskLogHeader(task devops.TimelineTask) {
+	_, _ = color.New(color.FgCyan, color.Bold).Printf("\n── %s ──\n", task.Label())
+}
+
+// printLogLines prints log lines, highlighting ##[error] and ##[warning] lines
+func printLogLines(lines []string) {
+	red := color.New(color.FgRed)
+	yellow := color.New(color.FgYellow)
+	for _, line := range lines {
+		switch {
+		case strings.Contains(line, "##[error]"):
+			_, _ = red.Println(line)
+		case strings.Contains(line, "##[warning]"):
+			_, _ = yellow.Println(line)
+		default:
+			fmt.Println(line)
+		}
+	}
+}
+
+// followBuildLogs streams new task log lines until the build completes, then
+// prints the final timeline and returns the completed build with it
+func followBuildLogs(svc *devops.DevOpsService, buildID int) (*build.Build, []*devops.TimelineNode, error) {
+	interval := time.Duration(buildLogsInterval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	printed := map[int]uint64{}
+	current := 0
+
+	for {
+		ctx, cancel := GetContext()
+		b, err := svc.GetBuild(ctx, buildID)
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+		// Read the timeline after the build status, so the pass after completion
+		// still sees every log line
+		tree, err := svc.GetBuildTimeline(ctx, buildID)
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+
+		for _, task := range devops.TimelineTasks(tree) {
+			logID := task.Node.LogID
+			if logID == 0 || (buildLogsTask != "" && !matchesLogTask(task, buildLogsTask)) {
+				continue
+			}
+			lines, err := svc.GetBuildLogLines(ctx, buildID, logID, printed[logID]+1)
+			if err != nil {
+				cancel()
+				return nil, nil, err
+			}
+			if len(lines) == 0 {
+				continue
+			}
+			if current != logID {
+				printTaskLogHeader(task)
+				current = logID
+			}
+			printLogLines(lines)
+			printed[logID] += uint64(len(lines))
+		}
+		cancel()
+
+		if isBuildCompleted(b) {
+			fmt.Println()
+			printTimelineTree(tree, 0)
+			return b, tree, nil
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// saveBuildLogs writes the full log of every task to buildLogsSaveDir
+func saveBuildLogs(svc *devops.DevOpsService, buildID int, tasks []devops.TimelineTask) error {
+	if err := os.MkdirAll(buildLogsSaveDir, 0755); err != nil {
+		return errors.NewWithError(errors.ExitCodeFileOperation, "failed to create log directory", err)
+	}
+
+	saved := 0
+	for i, task := range tasks {
+		if task.Node.LogID == 0 {
+			continue
+		}
+
+		ctx, cancel := GetContext()
+		lines, err := svc.GetBuildLogLines(ctx, buildID, task.Node.LogID, 0)
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		name := fmt.Sprintf("%03d-%s.log", i+1, strings.Trim(unsafeFileChars.ReplaceAllString(task.Label(), "_"), "_"))
+		path := filepath.Join(buildLogsSaveDir, name)
+		if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600); err != nil {
+			return errors.NewWithError(errors.ExitCodeFileOperation, "failed to write log file", err)
+		}
+		saved++
+	}
+
+	fmt.Fprintf(os.Stderr, "✓ Saved %d task logs to %s\n", saved, buildLogsSaveDir)
+	return nil
+}
+
+func init() {
+	buildLogsCmd.Flags().BoolVar(&buildLogsAll, "all", false, "Print the logs of every task, not only failed ones")
+	buildLogsCmd.Flags().StringVar(&buildLogsTask, "task", "", "Print the logs of tasks whose name contains this text")
+	buildLogsCmd.Flags().BoolVar(&buildLogsFollow, "follow", false, "Stream logs of an in-progress build until it completes")
+	buildLogsCmd.Flags().StringVar(&buildLogsSaveDir, "save-dir", "", "Directory to save the full log of every task")
+	buildLogsCmd.Flags().IntVar(&buildLogsInterval, "interval", 5, "Seconds between polls with --follow")
+
+	buildLogsCmd.MarkFlagsMutuallyExclusive("all", "task")
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"adoctl/pkg/devops"
+	"adoctl/pkg/errors"
+	"adoctl/pkg/git"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	buildRunPipeline     string
+	buildRunBranch       string
+	buildRunCommit       string
+	buildRunParams       []string
+	buildRunVars         []string
+	buildRunStagesToSkip []string
+	buildRunWait         bool
+	buildRunInterval     int
+	buildRunWaitTimeout  time.Duration
+)
+
+var buildRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Queue a pipeline run",
+	Long: `Queue a run of a pipeline on a branch, optionally pinned to a commit, with YAML
+template parameters, variables and stages to skip.
+
+The pipeline can be given by ID, name or folder path (e.g. \team\deploy). Names are
+resolved through the cached list of pipeline definitions, which is refreshed when a
+name is not found.
+
+With --wait the command follows the run until it completes and exits with a code
+mapped from the result: 0 succeeded, 11 failed, 12 partially succeeded, 8 canceled.
+The wait gives up after --wait-timeout with exit code 9.`,
+	Example: `  # Run a pipeline on the current branch
+  adoctl build run --pipeline my-pipeline
+
+  # Run on a branch with template parameters and variables
+  adoctl build run --pipeline my-pipeline --branch main --param environment=staging --var DEBUG=true
+
+  # Run a specific commit and skip a stage
+  adoctl build run --pipeline 42 --branch main --commit 1a2b3c4 --stage-to-skip Deploy
+
+  # Wait for the run to finish (exit code follows the result)
+  adoctl build run --pipeline my-pipeline --branch main --wait
+
+  # Show what would be queued
+  adoctl build run --pipeline my-pipeline --branch main --param environment=prod --dry-run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := parseKeyValues("--param", buildRunParams)
+		if err != nil {
+			return err
+		}
+		variables, err := parseKeyValues("--var", buildRunVars)
+		if err != nil {
+			return err
+		}
+
+		branch := buildRunBranch
+		if branch == "" {
+			if !git.IsGitRepository() {
+				return errors.ValidationError("--branch is required outside a git repository")
+			}
+			branch, err = git.GetCurrentBranch()
+			if err != nil {
+				return fmt.Errorf("failed to get current branch: %w", err)
+			}
+		}
+
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		definition, err := svc.ResolveDefinition(ctx, buildRunPipeline)
+		if err != nil {
+			return err
+		}
+
+		details := map[string]string{
+			"Pipeline": fmt.Sprintf("%s (id %d)", definition.Name, definition.ID),
+			"Branch":   branch,
+		}
+		if buildRunCommit != "" {
+			details["Commit"] = buildRunCommit
+		}
+		if len(params) > 0 {
+			details["Parameters"] = formatKeyValues(params)
+		}
+		if len(variables) > 0 {
+			details["Variables"] = formatKeyValues(variables)
+		}
+		if len(buildRunStagesToSkip) > 0 {
+			details["Skip stages"] = strings.Join(buildRunStagesToSkip, ", ")
+		}
+
+		if IsDryRun() {
+			PrintDryRunAction("queue a pipeline run", details)
+			return nil
+		}
+
+		run, err := svc.RunPipeline(ctx, definition.ID, devops.RunOptions{
+			Branch:       branch,
+			Commit:       buildRunCommit,
+			Parameters:   params,
+			Variables:    variables,
+			StagesToSkip: buildRunStagesToSkip,
+		})
+		if err != nil {
+			return err
+		}
+		if run.Id == nil {
+			return fmt.Errorf("pipeline run was queued without an ID")
+		}
+
+		runName := ""
+		if run.Name != nil {
+			runName = *run.Name
+		}
+		fmt.Printf("✓ Queued run %d (%s) of %s on %s\n", *run.Id, runName, definition.Name, branch)
+		fmt.Printf("  %s\n", svc.BuildWebURL(*run.Id))
+
+		if !buildRunWait {
+			return nil
+		}
+
+		return waitForBuildRun(svc, *run.Id)
+	},
+}
+
+// waitForBuildRun follows a build until it completes and maps its result to an error
+func waitForBuildRun(svc *devops.DevOpsService, buildID int) error {
+	interval := time.Duration(buildRunInterval) * time.Second
+	if interval <= 0 {
+		interval = 15 * time.Second
+	}
+
+	return watchBuild(svc, buildID, devops.WatchOptions{
+		MinInterval: interval,
+		MaxInterval: max(interval, time.Minute),
+	}, buildRunWaitTimeout)
+}
+
+// parseKeyValues parses repeated key=value flag values into a map
+func parseKeyValues(flag string, values []string) (map[string]string, error) {
+	result := make(map[string]string, len(values))
+	for _, value := range values {
+		key, val, ok := strings.Cut(value, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, errors.ValidationError(fmt.Sprintf("invalid %s %q, expected key=value", flag, value))
+		}
+		result[strings.TrimSpace(key)] = val
+	}
+	return result, nil
+}
+
+// formatKeyValues renders a map as sorted key=value pairs
+func formatKeyValues(values map[string]string) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+values[key])
+	}
+	return strings.Join(pairs, ", ")
+}
+
+func init() {
+	buildRunCmd.Flags().StringVar(&buildRunPipeline, "pipeline", "", "Pipeline ID, name or folder path (required)")
+	buildRunCmd.Flags().StringVar(&buildRunBranch, "branch", "", "Branch to run (default: current git branch)")
+	buildRunCmd.Flags().StringVar(&buildRunCommit, "commit", "", "Commit SHA to run instead of the branch head")
+	buildRunCmd.Flags().StringArrayVar(&buildRunParams, "param", nil, "YAML template parameter as key=value (repeatable)")
+	buildRunCmd.Flags().StringArrayVar(&buildRunVars, "var", nil, "Pipeline variable as key=value (repeatable)")
+	buildRunCmd.Flags().StringArrayVar(&buildRunStagesToSkip, "stage-to-skip", nil, "Stage to skip (repeatable)")
+	buildRunCmd.Flags().BoolVar(&buildRunWait, "wait", false, "Wait for the run to complete; the exit code follows the result")
+	buildRunCmd.Flags().IntVar(&buildRunInterval, "interval", 15, "Initial seconds between status checks with --wait; backs off up to a minute")
+	buildRunCmd.Flags().DurationVar(&buildRunWaitTimeout, "wait-timeout", 2*time.Hour, "Maximum time to wait with --wait (0 for no limit)")
+
+	if err := buildRunCmd.MarkFlagRequired("pipeline"); err != nil {
+		panic(err)
+	}
+}
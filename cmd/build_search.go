@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,6 +27,10 @@ var (
 	searchBuildsEndTimeTo     string
 	searchBuildsHasEndTime    string
 	searchBuildsLimit         int
+	searchBuildsPRID          int
+	searchBuildsDefinition    string
+	searchBuildsReason        string
+	searchBuildsRequestedFor  string
 	searchBuildsOutput        string
 	searchBuildsJSON          bool
 )
@@ -46,6 +51,12 @@ var searchBuildsCmd = &cobra.Command{
   # Search with time filters
   adoctl build search --start-time-from 2024-01-01T00:00:00Z --start-time-to 2024-01-31T23:59:59Z
 
+  # Builds triggered by PR #123
+  adoctl build search --pr 123
+
+  # My pull request validation builds of a pipeline
+  adoctl build search --definition my-pipeline --reason pullRequest --requested-for me
+
   # Limit results
   adoctl build search --limit 10
 
@@ -64,6 +75,17 @@ var searchBuildsCmd = &cobra.Command{
 
 		filters := buildSearchFilters()
 
+		if searchBuildsRequestedFor == "me" {
+			ctx, cancel := GetContext()
+			defer cancel()
+
+			user, err := svc.GetCurrentUser(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get current user: %w", err)
+			}
+			filters["requested_for"] = user.ID
+		}
+
 		builds, err := svc.SearchBuildsCached(filters)
 		if err != nil {
 			return fmt.Errorf("error searching builds: %w", err)
@@ -88,6 +110,16 @@ func buildSearchFilters() map[string]any {
 	addEndTimeFilter(filters)
 	addIntFilter(filters, "build_id", searchBuildsID)
 	addIntFilter(filters, "limit", searchBuildsLimit)
+	addIntFilter(filters, "pr_id", searchBuildsPRID)
+	addStringFilter(filters, "reason", searchBuildsReason)
+	addStringFilter(filters, "requested_for", searchBuildsRequestedFor)
+
+	// A numeric definition is an ID, anything else the pipeline name
+	if id, err := strconv.Atoi(searchBuildsDefinition); err == nil {
+		filters["definition_id"] = id
+	} else {
+		addStringFilter(filters, "definition_name", searchBuildsDefinition)
+	}
 
 	return filters
 }
@@ -141,6 +173,11 @@ func outputBuildsAsJSON(builds []cache.Build, shouldCopy bool) error {
 			"end_time":       nil,
 			"status":         build.Status,
 			"updated_at":     build.UpdatedAt,
+			"repository_id":  build.RepositoryID,
+			"definition_id":  build.DefinitionID,
+			"reason":         build.Reason,
+			"requested_for":  build.RequestedFor,
+			"pr_id":          build.PullRequestID,
 		}
 		if build.EndTime.Valid {
 			buildMap["end_time"] = build.EndTime.Time
@@ -200,6 +237,15 @@ func outputBuilds(builds []cache.Build, shouldCopy bool) error {
 			fmt.Println("  End Time: (not completed)")
 		}
 		fmt.Printf("  Status: %s\n", build.Status)
+		if build.Reason != "" {
+			fmt.Printf("  Reason: %s\n", build.Reason)
+		}
+		if build.RequestedFor != "" {
+			fmt.Printf("  Requested For: %s\n", build.RequestedFor)
+		}
+		if build.PullRequestID != 0 {
+			fmt.Printf("  Pull Request: #%d\n", build.PullRequestID)
+		}
 		fmt.Printf("  Updated At: %s\n", build.UpdatedAt.Format("2006-01-02 15:04:05"))
 		fmt.Println()
 
@@ -232,6 +278,10 @@ func init() {
 	searchBuildsCmd.Flags().StringVar(&searchBuildsEndTimeTo, "end-time-to", "", "Filter builds ending before this time (RFC3339 format)")
 	searchBuildsCmd.Flags().StringVar(&searchBuildsHasEndTime, "has-end-time", "", "Filter by end time existence (true/false)")
 	searchBuildsCmd.Flags().IntVar(&searchBuildsLimit, "limit", 0, "Limit number of results")
+	searchBuildsCmd.Flags().IntVar(&searchBuildsPRID, "pr", 0, "Filter by the pull request that triggered the build")
+	searchBuildsCmd.Flags().StringVar(&searchBuildsDefinition, "definition", "", "Filter by pipeline definition ID or name")
+	searchBuildsCmd.Flags().StringVar(&searchBuildsReason, "reason", "", "Filter by build reason (e.g. pullRequest, individualCI, manual, schedule)")
+	searchBuildsCmd.Flags().StringVar(&searchBuildsRequestedFor, "requested-for", "", "Filter by requester ID or name ('me' for the current user)")
 	searchBuildsCmd.Flags().StringVar(&searchBuildsOutput, "output", "", "Output file path (default: stdout)")
 	searchBuildsCmd.Flags().BoolVar(&searchBuildsJSON, "json", false, "Output in JSON format")
 }
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"adoctl/pkg/devops"
+	"adoctl/pkg/filter"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	buildStatsPipeline string
+	buildStatsBranch   string
+	buildStatsSince    string
+)
+
+var buildStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Pass rate, durations and flakiness from the builds cache",
+	Long: `Analyse completed builds in the local cache: success rate, p50/p90 queue and
+run durations, failures by day, the longest red streak and time-to-green after
+a failure, per pipeline and overall.
+
+The success rate ignores canceled builds. Streaks follow each pipeline and branch
+in build order; time-to-green runs from the end of the first failed build to the
+end of the next succeeded one. A commit whose builds of one pipeline both failed
+and succeeded is flagged as flaky.
+
+Use --format json or yaml for the full statistics, or csv for the per-pipeline
+summary.`,
+	Example: `  # Stats of every pipeline over the last 30 days
+  adoctl build stats
+
+  # One pipeline on main over the last week
+  adoctl build stats --pipeline my-app-ci --branch main --since 7d
+
+  # Per-pipeline summary as CSV
+  adoctl build stats --since 90d --format csv > build-stats.csv`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		since, err := filter.ParseAge(buildStatsSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		stats, err := svc.GetBuildStats(buildStatsPipeline, buildStatsBranch, time.Now().Add(-since))
+		if err != nil {
+			return err
+		}
+
+		writer := NewOutputWriter(cmd.Flag("format").Value.String())
+		switch {
+		case writer.IsStructured():
+			return writer.Write(stats)
+		case writer.GetFormat() == FormatCSV:
+			return writer.WriteCSV(buildStatsCSV(stats))
+		}
+
+		printBuildStats(stats)
+		return nil
+	},
+}
+
+func printBuildStats(stats *devops.BuildStats) {
+	fmt.Printf("Build stats since %s (%d completed builds)\n\n", stats.Since.Local().Format("2006-01-02 15:04"), stats.Summary.Builds)
+	if stats.Summary.Builds == 0 {
+		fmt.Println("No completed builds in the cache for these filters")
+		return
+	}
+
+	labelWidth := len("PIPELINE")
+	for _, p := range stats.Pipelines {
+		labelWidth = max(labelWidth, len([]rune(p.Pipeline)))
+	}
+	labelWidth = min(labelWidth, 40)
+
+	row := func(label string, s devops.StatsSummary) string {
+		return strings.TrimRight(fmt.Sprintf("%-*s  %6d  %7s  %-17s  %-17s  %10d  %-13s  %5d",
+			labelWidth, truncateString(label, labelWidth), s.Builds, formatSuccessRate(s),
+			formatStatsSeconds(s.QueueP50Seconds)+" / "+formatStatsSeconds(s.QueueP90Seconds),
+			formatStatsSeconds(s.RunP50Seconds)+" / "+formatStatsSeconds(s.RunP90Seconds),
+			s.LongestRedStreak, formatStatsSeconds(s.TimeToGreenP50Seconds), s.FlakyCommits), " ")
+	}
+
+	fmt.Printf("%-*s  %6s  %7s  %-17s  %-17s  %10s  %-13s  %5s\n",
+		labelWidth, "PIPELINE", "BUILDS", "SUCCESS", "QUEUE P50 / P90", "RUN P50 / P90", "RED STREAK", "TO GREEN P50", "FLAKY")
+	for _, p := range stats.Pipelines {
+		fmt.Println(row(p.Pipeline, p))
+	}
+	if len(stats.Pipelines) > 1 {
+		_, _ = color.New(color.Bold).Println(row("ALL", stats.Summary))
+	}
+
+	if red := stats.LongestRed; red != nil {
+		fmt.Printf("\nLongest red streak: %d failed builds of %s on %s (#%d to #%d)", red.Builds, red.Pipeline, red.Branch, red.FirstBuildID, red.LastBuildID)
+		if red.GreenAt != nil {
+			fmt.Printf(", green after %s\n", formatLogDuration(red.GreenAt.Sub(red.Since)))
+		} else {
+			_, _ = color.New(color.FgRed).Printf(", still red since %s\n", red.Since.Local().Format("2006-01-02 15:04"))
+		}
+	}
+
+	fmt.Println("\nFailures by day:")
+	for _, day := range stats.FailuresByDay {
+		failed, total := day.Failed, day.Builds
+		// Scale busy days down to fit the bar in 50 columns
+		if total > 50 {
+			failed, total = (failed*50+total-1)/total, 50
+		}
+		bar := color.RedString(strings.Repeat("█", failed)) + strings.Repeat("░", total-failed)
+		fmt.Printf("  %s  %3d/%-3d  %s\n", day.Date, day.Failed, day.Builds, bar)
+	}
+
+	if len(stats.Flaky) > 0 {
+		fmt.Println("\nFlaky commits (failed and succeeded on the same commit):")
+		for _, f := range stats.Flaky {
+			ids := make([]string, 0, len(f.BuildIDs))
+			for _, id := range f.BuildIDs {
+				ids = append(ids, "#"+strconv.Itoa(id))
+			}
+			_, _ = color.New(color.FgYellow).Printf("  ⚠ %s %s on %s: %d failed, %d succeeded (%s)\n",
+				f.Pipeline, devops.ShortCommit(f.Commit), f.Branch, f.Failed, f.Succeeded, strings.Join(ids, ", "))
+		}
+	}
+}
+
+// buildStatsCSV returns the per-pipeline summary and the overall summary as CSV rows
+func buildStatsCSV(stats *devops.BuildStats) ([]string, [][]string) {
+	header := []string{
+		"pipeline", "builds", "succeeded", "failed", "partially_succeeded", "canceled", "success_rate",
+		"queue_p50_seconds", "queue_p90_seconds", "run_p50_seconds", "run_p90_seconds",
+		"longest_red_streak", "recoveries", "time_to_green_p50_seconds", "time_to_green_max_seconds", "flaky_commits",
+	}
+
+	summaries := append([]devops.StatsSummary{}, stats.Pipelines...)
+	overall := stats.Summary
+	overall.Pipeline = "ALL"
+	summaries = append(summaries, overall)
+
+	rows := make([][]string, 0, len(summaries))
+	for _, s := range summaries {
+		rows = append(rows, []string{
+			s.Pipeline,
+			strconv.Itoa(s.Builds),
+			strconv.Itoa(s.Succeeded),
+			strconv.Itoa(s.Failed),
+			strconv.Itoa(s.PartiallySucceeded),
+			strconv.Itoa(s.Canceled),
+			strconv.FormatFloat(s.SuccessRate, 'f', 4, 64),
+			strconv.Itoa(s.QueueP50Seconds),
+			strconv.Itoa(s.QueueP90Seconds),
+			strconv.Itoa(s.RunP50Seconds),
+			strconv.Itoa(s.RunP90Seconds),
+			strconv.Itoa(s.LongestRedStreak),
+			strconv.Itoa(s.Recoveries),
+			strconv.Itoa(s.TimeToGreenP50Seconds),
+			strconv.Itoa(s.TimeToGreenMaxSeconds),
+			strconv.Itoa(s.FlakyCommits),
+		})
+	}
+	return header, rows
+}
+
+func formatSuccessRate(s devops.StatsSummary) string {
+	if s.Builds == s.Canceled {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", s.SuccessRate*100)
+}
+
+func formatStatsSeconds(seconds int) string {
+	if seconds == 0 {
+		return "-"
+	}
+	return formatLogDuration(time.Duration(seconds) * time.Second)
+}
+
+func init() {
+	buildStatsCmd.Flags().StringVar(&buildStatsPipeline, "pipeline", "", "Pipeline (build definition) name or ID")
+	buildStatsCmd.Flags().StringVar(&buildStatsBranch, "branch", "", "Branch name, e.g. main")
+	buildStatsCmd.Flags().StringVar(&buildStatsSince, "since", "30d", "How far back to look (e.g. 7d, 2w, 12h)")
+}
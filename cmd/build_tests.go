@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"adoctl/pkg/devops"
+	"adoctl/pkg/progress"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	buildTestsNoCompare bool
+	buildTestsFull      bool
+)
+
+// stackTraceLines is how many stack trace lines are shown without --full
+const stackTraceLines = 8
+
+var buildTestsCmd = &cobra.Command{
+	Use:   "tests <build-id>",
+	Short: "Show the test results of a build",
+	Long: `Show the test runs of a build with passed, failed and skipped counts, followed by
+the failed tests with their error messages and stack traces.
+
+Failures are compared with the previous completed build of the same pipeline and
+branch from the builds cache, to tell new failures from pre-existing ones. When
+that build has no test runs there is no baseline and failures are not flagged.`,
+	Example: `  # Test summary and failures of a build
+  adoctl build tests 1234
+
+  # Full stack traces, without the comparison
+  adoctl build tests 1234 --full --no-compare
+
+  # Report as JSON
+  adoctl build tests 1234 --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		buildID, err := parseBuildIDArg(args[0])
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		b, err := svc.GetBuild(ctx, buildID)
+		if err != nil {
+			return err
+		}
+
+		spinner := progress.NewSpinner("Fetching test results...")
+		spinner.Start()
+		report, err := svc.GetBuildTestReport(ctx, b, !buildTestsNoCompare)
+		spinner.Stop()
+		if err != nil {
+			return err
+		}
+
+		writer := NewOutputWriter(cmd.Flag("format").Value.String())
+		if writer.IsStructured() {
+			return writer.Write(report)
+		}
+
+		printBuildTestReport(report)
+		return nil
+	},
+}
+
+func printBuildTestReport(report *devops.BuildTestReport) {
+	bold := color.New(color.Bold)
+
+	if len(report.Runs) == 0 {
+		fmt.Printf("Build %d has no test runs\n", report.BuildID)
+		return
+	}
+
+	summary := fmt.Sprintf("Build %d: %d test runs, %d failed tests", report.BuildID, len(report.Runs), len(report.Failed))
+	switch {
+	case report.NoBaseline:
+		summary += fmt.Sprintf(" (not compared: previous build %d has no test runs)", report.PreviousBuildID)
+	case report.PreviousBuildID != 0:
+		newCount := report.NewFailures()
+		summary += fmt.Sprintf(" (%d new, %d pre-existing vs build %d)", newCount, len(report.Failed)-newCount, report.PreviousBuildID)
+	}
+	_, _ = bold.Println(summary)
+	fmt.Println()
+
+	fmt.Printf("%-44s %7s %7s %7s %8s\n", "RUN", "TOTAL", "PASSED", "FAILED", "SKIPPED")
+	for _, run := range report.Runs {
+		line := fmt.Sprintf("%-44s %7d %7d %7d %8d", truncateString(run.Name, 44), run.Total, run.Passed, run.Failed, run.Skipped)
+		if run.Failed > 0 {
+			_, _ = color.New(color.FgRed).Println(line)
+		} else {
+			fmt.Println(line)
+		}
+	}
+
+	if len(report.Failed) == 0 {
+		return
+	}
+
+	fmt.Println()
+	_, _ = bold.Println("Failed tests:")
+	for _, f := range report.Failed {
+		fmt.Println()
+		_, _ = color.New(color.FgRed).Printf("✖ %s", f.Name)
+		switch f.Comparison {
+		case devops.FailureNew:
+			_, _ = color.New(color.FgRed, color.Bold).Print("  [NEW]")
+		case devops.FailureExisting:
+			_, _ = color.New(color.FgYellow).Print("  [pre-existing]")
+		}
+		_, _ = color.New(color.Faint).Printf("  (%s)\n", f.RunName)
+
+		if f.Error != "" {
+			printIndented(f.Error, 0)
+		}
+		if f.StackTrace != "" {
+			limit := stackTraceLines
+			if buildTestsFull {
+				limit = 0
+			}
+			printIndented(f.StackTrace, limit)
+		}
+	}
+}
+
+// printIndented prints text indented under a failed test, keeping at most limit
+// lines when limit is positive
+func printIndented(text string, limit int) {
+	lines := strings.Split(text, "\n")
+	hidden := 0
+	if limit > 0 && len(lines) > limit {
+		hidden = len(lines) - limit
+		lines = lines[:limit]
+	}
+	for _, line := range lines {
+		fmt.Printf("    %s\n", strings.TrimRight(line, "\r"))
+	}
+	if hidden > 0 {
+		_, _ = color.New(color.Faint).Printf("    … %d more lines (--full to show)\n", hidden)
+	}
+}
+
+func truncateString(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-1]) + "…"
+}
+
+func init() {
+	buildTestsCmd.Flags().BoolVar(&buildTestsNoCompare, "no-compare", false, "Do not compare failures with the previous build")
+	buildTestsCmd.Flags().BoolVar(&buildTestsFull, "full", false, "Show full stack traces")
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"adoctl/pkg/devops"
+	"adoctl/pkg/errors"
+	"adoctl/pkg/git"
+	"adoctl/pkg/progress"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/build"
+	"github.com/spf13/cobra"
+)
+
+var (
+	buildWatchBranch      string
+	buildWatchLatest      bool
+	buildWatchPipeline    string
+	buildWatchInterval    time.Duration
+	buildWatchMaxInterval time.Duration
+)
+
+var buildWatchCmd = &cobra.Command{
+	Use:   "watch [build-id]",
+	Short: "Follow a build until it completes",
+	Long: `Follow a build until it completes, showing the current stage and stage progress,
+then exit with a code mapped from the result: 0 succeeded, 11 failed, 12 partially
+succeeded, 8 canceled.
+
+Instead of a build ID, --branch with --latest picks the most recently queued build
+of a branch, optionally of one --pipeline.
+
+Polling starts every --interval and backs off up to --max-interval while no stage
+starts or finishes. When --timeout is given it bounds the whole watch, and the
+command exits with code 9 if the build has not finished by then.`,
+	Example: `  # Wait for a build and fail if it failed
+  adoctl build watch 1234
+
+  # Watch the latest build of a branch
+  adoctl build watch --branch main --latest
+
+  # Watch the latest run of one pipeline on the current branch
+  adoctl build watch --latest --pipeline my-pipeline
+
+  # Give up after 30 minutes (exit code 9)
+  adoctl build watch 1234 --timeout 30m`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 1 && buildWatchLatest {
+			return errors.ValidationError("use either a build ID or --latest, not both")
+		}
+		if len(args) == 0 && !buildWatchLatest {
+			return errors.ValidationError("a build ID or --latest is required")
+		}
+		if buildWatchInterval <= 0 {
+			return errors.ValidationError("--interval must be positive")
+		}
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		var buildID int
+		if len(args) == 1 {
+			buildID, err = parseBuildIDArg(args[0])
+			if err != nil {
+				return err
+			}
+		} else {
+			buildID, err = findLatestBuildID(svc)
+			if err != nil {
+				return err
+			}
+		}
+
+		// --timeout bounds the whole watch only when given; otherwise the watch runs
+		// until the build finishes and --timeout keeps its default per request
+		limit := time.Duration(0)
+		if cmd.Flags().Changed("timeout") {
+			limit = globalTimeout
+		}
+
+		fmt.Printf("Watching build %d\n", buildID)
+		fmt.Printf("  %s\n", svc.BuildWebURL(buildID))
+
+		return watchBuild(svc, buildID, devops.WatchOptions{
+			MinInterval: buildWatchInterval,
+			MaxInterval: max(buildWatchMaxInterval, buildWatchInterval),
+		}, limit)
+	},
+}
+
+// findLatestBuildID resolves --branch, --latest and --pipeline to a build ID
+func findLatestBuildID(svc *devops.DevOpsService) (int, error) {
+	branch := buildWatchBranch
+	if branch == "" {
+		if !git.IsGitRepository() {
+			return 0, errors.ValidationError("--branch is required outside a git repository")
+		}
+		var err error
+		branch, err = git.GetCurrentBranch()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get current branch: %w", err)
+		}
+	}
+
+	ctx, cancel := GetContext()
+	defer cancel()
+
+	definitionID := 0
+	if buildWatchPipeline != "" {
+		definition, err := svc.ResolveDefinition(ctx, buildWatchPipeline)
+		if err != nil {
+			return 0, err
+		}
+		definitionID = definition.ID
+	}
+
+	b, err := svc.FindLatestBuild(ctx, branch, definitionID)
+	if err != nil {
+		return 0, errors.NewWithError(errors.ExitCodeAPINotFound, "Failed to find the latest build", err)
+	}
+	return *b.Id, nil
+}
+
+// watchBuild follows a build with a live stage progress line and maps its
+// result, or running past limit (0 for no limit), to an error
+func watchBuild(svc *devops.DevOpsService, buildID int, opts devops.WatchOptions, limit time.Duration) error {
+	var (
+		watchCtx context.Context
+		cancel   context.CancelFunc
+	)
+	if limit > 0 {
+		watchCtx, cancel = context.WithTimeout(context.Background(), limit)
+	} else {
+		watchCtx, cancel = context.WithCancel(context.Background())
+	}
+	defer cancel()
+
+	newCtx := func() (context.Context, context.CancelFunc) {
+		return context.WithTimeout(watchCtx, defaultTimeout)
+	}
+
+	spinner := progress.NewSpinner(fmt.Sprintf("Waiting for build %d...", buildID))
+	spinner.SetWriter(os.Stderr)
+	spinner.Start()
+	started := time.Now()
+	b, err := svc.WatchBuild(watchCtx, newCtx, buildID, opts, func(p devops.BuildProgress) {
+		spinner.SetMessage(formatBuildProgress(buildID, p, time.Since(started)))
+	})
+	spinner.Stop()
+	if err != nil {
+		if watchCtx.Err() == context.DeadlineExceeded {
+			return errors.TimeoutError(fmt.Sprintf("build %d did not finish within %s", buildID, limit))
+		}
+		return err
+	}
+
+	result := ""
+	if b.Result != nil {
+		result = string(*b.Result)
+	}
+	fmt.Printf("Build %d completed: %s (%s)\n", buildID, devops.FormatBuildStatus("completed", result), formatAge(time.Since(started)))
+
+	if resultErr := errors.BuildResultError(buildID, result); resultErr != nil {
+		return resultErr
+	}
+	return nil
+}
+
+// formatBuildProgress renders the spinner line for a running build
+func formatBuildProgress(buildID int, p devops.BuildProgress, elapsed time.Duration) string {
+	if p.Build.Status != nil && *p.Build.Status == build.BuildStatusValues.NotStarted {
+		return fmt.Sprintf("Build %d queued (%s)", buildID, formatAge(elapsed))
+	}
+	if len(p.Stages) == 0 {
+		return fmt.Sprintf("Build %d running (%s)", buildID, formatAge(elapsed))
+	}
+
+	current := "between stages"
+	if stage := p.CurrentStage(); stage != nil {
+		current = stage.Name
+	}
+	return fmt.Sprintf("Build %d: %s [%d/%d stages] (%s)", buildID, current, p.CompletedStages(), len(p.Stages), formatAge(elapsed))
+}
+
+func init() {
+	buildWatchCmd.Flags().StringVar(&buildWatchBranch, "branch", "", "Branch of the build to watch with --latest (default: current git branch)")
+	buildWatchCmd.Flags().BoolVar(&buildWatchLatest, "latest", false, "Watch the most recently queued build of the branch")
+	buildWatchCmd.Flags().StringVar(&buildWatchPipeline, "pipeline", "", "Pipeline ID, name or folder path to pick the latest build from")
+	buildWatchCmd.Flags().DurationVar(&buildWatchInterval, "interval", 5*time.Second, "Initial time between polls")
+	buildWatchCmd.Flags().DurationVar(&buildWatchMaxInterval, "max-interval", time.Minute, "Longest time between polls while nothing changes")
+}
@@ -5,6 +5,7 @@ import (
 
 	"adoctl/pkg/config"
 	"adoctl/pkg/errors"
+	"adoctl/pkg/filter"
 
 	"github.com/spf13/cobra"
 )
@@ -14,6 +15,8 @@ var (
 	configOrg         string
 	configProject     string
 	configToken       string
+	configQueryName   string
+	configQueryExpr   string
 )
 
 var configCmd = &cobra.Command{
@@ -212,6 +215,94 @@ var configProfilesUseCmd = &cobra.Command{
 	},
 }
 
+var configQueriesCmd = &cobra.Command{
+	Use:     "queries",
+	Aliases: []string{"query"},
+	Short:   "Manage saved PR queries",
+	Long: `List, save, and remove named PR query expressions.
+
+Saved queries can be passed by name to --query, or referenced inside another
+query with saved:<name>.`,
+}
+
+var configQueriesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved queries",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+
+		names := cfg.ListQueries()
+		if len(names) == 0 {
+			fmt.Println("No saved queries.")
+			fmt.Println("Use 'adoctl config queries set --name <name> --query <expression>' to create one.")
+			return nil
+		}
+
+		fmt.Println("Saved queries:")
+		for _, name := range names {
+			fmt.Printf("  %s: %s\n", name, cfg.Queries[name])
+		}
+
+		return nil
+	},
+}
+
+var configQueriesSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Save a query",
+	Example: `  # Save a query for my stale PRs
+  adoctl config queries set --name stale-mine --query 'author:me status:active age>14d'
+
+  # Use it
+  adoctl pr list --query stale-mine`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+
+		if _, err := filter.ParseQuery(configQueryExpr, cfg.Queries); err != nil {
+			return fmt.Errorf("invalid query: %w", err)
+		}
+
+		if err := cfg.SetQuery(configQueryName, configQueryExpr); err != nil {
+			return err
+		}
+
+		if err := config.Save(cfg); err != nil {
+			return err
+		}
+
+		fmt.Printf("Query '%s' saved.\n", configQueryName)
+		return nil
+	},
+}
+
+var configQueriesRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove a saved query",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+
+		if err := cfg.RemoveQuery(configQueryName); err != nil {
+			return err
+		}
+
+		if err := config.Save(cfg); err != nil {
+			return err
+		}
+
+		fmt.Printf("Query '%s' removed.\n", configQueryName)
+		return nil
+	},
+}
+
 var configPathCmd = &cobra.Command{
 	Use:   "path",
 	Short: "Show configuration file path",
@@ -251,6 +342,21 @@ func init() {
 		panic(err)
 	}
 
+	// Saved query flags
+	configQueriesSetCmd.Flags().StringVar(&configQueryName, "name", "", "Query name (required)")
+	configQueriesSetCmd.Flags().StringVar(&configQueryExpr, "query", "", "Query expression (required)")
+	if err := configQueriesSetCmd.MarkFlagRequired("name"); err != nil {
+		panic(err)
+	}
+	if err := configQueriesSetCmd.MarkFlagRequired("query"); err != nil {
+		panic(err)
+	}
+
+	configQueriesRemoveCmd.Flags().StringVar(&configQueryName, "name", "", "Query name (required)")
+	if err := configQueriesRemoveCmd.MarkFlagRequired("name"); err != nil {
+		panic(err)
+	}
+
 	// Add commands
 	configProfilesCmd.AddCommand(configProfilesListCmd)
 	configProfilesCmd.AddCommand(configProfilesAddCmd)
@@ -259,5 +365,9 @@ func init() {
 
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configProfilesCmd)
+	configQueriesCmd.AddCommand(configQueriesListCmd)
+	configQueriesCmd.AddCommand(configQueriesSetCmd)
+	configQueriesCmd.AddCommand(configQueriesRemoveCmd)
+	configCmd.AddCommand(configQueriesCmd)
 	configCmd.AddCommand(configPathCmd)
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -94,6 +95,16 @@ func RequireConfirmation(action string, details map[string]string) error {
 	return nil
 }
 
+// ConfirmAndGetContext requires confirmation, then returns a new request context:
+// the prompt may outlast the timeout of a context created before it
+func ConfirmAndGetContext(action string, details map[string]string) (context.Context, context.CancelFunc, error) {
+	if err := RequireConfirmation(action, details); err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := GetContext()
+	return ctx, cancel, nil
+}
+
 func init() {
 	// These will be bound to root command flags
 }
@@ -19,7 +19,16 @@ var deploymentCmd = &cobra.Command{
   adoctl deployment search --status succeeded
 
   # Search deployments by repository
-  adoctl deployment search --repository myrepo`,
+  adoctl deployment search --repository myrepo
+
+  # Show what release is deployed in each environment
+  adoctl deployment matrix
+
+  # List release approvals waiting on you
+  adoctl deployment approvals
+
+  # Approve every pending approval of a release
+  adoctl deployment approve --release "Release-42" --comment "LGTM"`,
 }
 
 func init() {
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"adoctl/pkg/devops"
+	"adoctl/pkg/errors"
+	"adoctl/pkg/progress"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	approvalsRelease  string
+	approvalsNoGroups bool
+	approvalComment   string
+	approvalRelease   string
+)
+
+var deploymentApprovalsCmd = &cobra.Command{
+	Use:   "approvals",
+	Short: "List pending release approvals",
+	Long: `List the pending release approvals assigned to you or to one of your groups,
+oldest first, with their release, environment and age.`,
+	Example: `  # List pending approvals
+  adoctl deployment approvals
+
+  # Only approvals assigned to you directly
+  adoctl deployment approvals --no-groups
+
+  # Approvals of one release
+  adoctl deployment approvals --release "Release-42"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		spinner := progress.NewSpinner("Fetching pending approvals...")
+		spinner.Start()
+		approvals, err := svc.ListPendingApprovals(ctx, !approvalsNoGroups)
+		spinner.Stop()
+		if err != nil {
+			return err
+		}
+
+		if approvalsRelease != "" {
+			approvals = devops.FilterApprovalsByRelease(approvals, approvalsRelease)
+		}
+
+		writer := NewOutputWriter(cmd.Flag("format").Value.String())
+		if writer.IsStructured() {
+			return writer.Write(approvals)
+		}
+
+		if len(approvals) == 0 {
+			fmt.Println("No pending approvals")
+			return nil
+		}
+
+		fmt.Printf("%-8s %-28s %-20s %-11s %-28s %5s\n", "ID", "RELEASE", "ENVIRONMENT", "TYPE", "APPROVER", "AGE")
+		for _, a := range approvals {
+			approver := a.Approver
+			if a.ViaGroup {
+				approver += " (group)"
+			}
+			fmt.Printf("%-8d %-28s %-20s %-11s %-28s %5s\n",
+				a.ID,
+				truncateString(a.Release, 28),
+				truncateString(a.Environment, 20),
+				a.Type,
+				truncateString(approver, 28),
+				formatAge(a.Age()))
+		}
+		_, _ = color.New(color.Faint).Printf("\n%d pending approvals\n", len(approvals))
+		return nil
+	},
+}
+
+var deploymentApproveCmd = &cobra.Command{
+	Use:   "approve [<approval-id>...]",
+	Short: "Approve pending release approvals",
+	Long: `Approve release approvals by ID, or every pending approval of a release with
+--release. Approval IDs are listed by "deployment approvals".`,
+	Example: `  # Approve one approval with a comment
+  adoctl deployment approve 5678 --comment "Smoke tests passed"
+
+  # Approve every pending approval of a release
+  adoctl deployment approve --release "Release-42"
+
+  # Preview what would be approved
+  adoctl deployment approve --release "Release-42" --dry-run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runApprovalAction("approve", "approved", args, func(ctx context.Context, svc *devops.DevOpsService, id int) error {
+			return svc.ApproveRelease(ctx, id, approvalComment)
+		})
+	},
+}
+
+var deploymentRejectCmd = &cobra.Command{
+	Use:   "reject [<approval-id>...]",
+	Short: "Reject pending release approvals",
+	Long: `Reject release approvals by ID, or every pending approval of a release with
+--release. Approval IDs are listed by "deployment approvals".`,
+	Example: `  # Reject an approval with a reason
+  adoctl deployment reject 5678 --comment "Blocked by incident INC-123"
+
+  # Reject every pending approval of a release
+  adoctl deployment reject --release "Release-42" --yes`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runApprovalAction("reject", "rejected", args, func(ctx context.Context, svc *devops.DevOpsService, id int) error {
+			return svc.RejectRelease(ctx, id, approvalComment)
+		})
+	},
+}
+
+// runApprovalAction resolves the approvals targeted by the arguments or --release,
+// confirms the action, then applies fn to each approval in turn. done is the past
+// tense of action used to report each approval.
+func runApprovalAction(action, done string, args []string, fn func(context.Context, *devops.DevOpsService, int) error) error {
+	if len(args) > 0 && approvalRelease != "" {
+		return errors.ValidationError("give approval IDs or --release, not both")
+	}
+	if len(args) == 0 && approvalRelease == "" {
+		return errors.ValidationError("give approval IDs or --release")
+	}
+
+	ids := make([]int, 0, len(args))
+	for _, arg := range args {
+		id, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+		if err != nil || id <= 0 {
+			return errors.ValidationError(fmt.Sprintf("invalid approval ID %q", arg))
+		}
+		ids = append(ids, id)
+	}
+	ids = dedupeIDs(ids)
+
+	svc, err := devops.NewServiceFromEnv()
+	if err != nil {
+		return fmt.Errorf("failed to create devops service: %w", err)
+	}
+	defer svc.Close()
+
+	ctx, cancel := GetContext()
+	pending, err := svc.ListPendingApprovals(ctx, true)
+	cancel()
+
+	if approvalRelease != "" {
+		if err != nil {
+			return err
+		}
+		pending = devops.FilterApprovalsByRelease(pending, approvalRelease)
+		if len(pending) == 0 {
+			return errors.NotFoundError(fmt.Sprintf("pending approvals for release '%s'", approvalRelease))
+		}
+		for _, a := range pending {
+			ids = append(ids, a.ID)
+		}
+	} else if err != nil {
+		// The lookup only describes explicit IDs; label them by ID alone without it
+		pending = nil
+	}
+
+	labels := describeApprovals(ids, pending)
+	details := map[string]string{"Approvals": strings.Join(labels, "; ")}
+	if approvalComment != "" {
+		details["Comment"] = approvalComment
+	}
+
+	if IsDryRun() {
+		PrintDryRunAction(action+" release approvals", details)
+		return nil
+	}
+
+	if err := RequireConfirmation(action+" release approvals", details); err != nil {
+		return err
+	}
+
+	failed := 0
+	for i, id := range ids {
+		ctx, cancel := GetContext()
+		err := fn(ctx, svc, id)
+		cancel()
+
+		if err != nil {
+			failed++
+			fmt.Printf("✗ %s: %v\n", labels[i], err)
+			continue
+		}
+		fmt.Printf("✓ %s: %s\n", labels[i], done)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to %s: %d of %d approvals failed", action, failed, len(ids))
+	}
+	return nil
+}
+
+// describeApprovals labels each approval ID with its release and environment when
+// it is among the pending approvals
+func describeApprovals(ids []int, pending []devops.PendingApproval) []string {
+	byID := make(map[int]devops.PendingApproval, len(pending))
+	for _, a := range pending {
+		byID[a.ID] = a
+	}
+
+	labels := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if a, ok := byID[id]; ok {
+			labels = append(labels, fmt.Sprintf("#%d %s → %s (%s)", id, a.Release, a.Environment, a.Type))
+		} else {
+			labels = append(labels, fmt.Sprintf("#%d", id))
+		}
+	}
+	return labels
+}
+
+func init() {
+	deploymentApprovalsCmd.Flags().StringVar(&approvalsRelease, "release", "", "Only show approvals of this release name")
+	deploymentApprovalsCmd.Flags().BoolVar(&approvalsNoGroups, "no-groups", false, "Only show approvals assigned to you directly")
+
+	for _, c := range []*cobra.Command{deploymentApproveCmd, deploymentRejectCmd} {
+		c.Flags().StringVar(&approvalComment, "comment", "", "Comment recorded with the approval")
+		c.Flags().StringVar(&approvalRelease, "release", "", "Act on every pending approval of this release name")
+	}
+}
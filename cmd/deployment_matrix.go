@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"adoctl/pkg/devops"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	matrixDefinition string
+	matrixHTML       bool
+)
+
+// matrixCellWidth is the width of an environment column in the terminal grid
+const matrixCellWidth = 26
+
+var deploymentMatrixCmd = &cobra.Command{
+	Use:   "matrix",
+	Short: "Show what is deployed in each environment",
+	Long: `Show a grid of release definitions by environments, built from the deployments
+cache. Each cell holds the latest successful release with its build, source
+branch and commit, and deploy time. Environments running an older release than the
+environment before them are highlighted as behind.
+
+Environments are ordered as they were added to the release definition.`,
+	Example: `  # Matrix of every release definition
+  adoctl deployment matrix
+
+  # A single definition
+  adoctl deployment matrix --definition my-app
+
+  # HTML table for Teams, or copy it as rich text
+  adoctl deployment matrix --html > matrix.html
+  adoctl deployment matrix --copy
+
+  # As JSON
+  adoctl deployment matrix --format json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		matrix, err := svc.GetDeploymentMatrix(matrixDefinition)
+		if err != nil {
+			return err
+		}
+
+		writer := NewOutputWriter(cmd.Flag("format").Value.String())
+		if writer.IsStructured() {
+			return writer.Write(matrix)
+		}
+
+		if matrixHTML {
+			fmt.Println(devops.WrapHTMLForClipboard(svc.GenerateHTMLDeploymentMatrix(matrix)))
+			return nil
+		}
+
+		if len(matrix.Rows) == 0 {
+			if matrixDefinition != "" {
+				fmt.Printf("No cached deployments for release definition '%s'\n", matrixDefinition)
+			} else {
+				fmt.Println("No cached deployments")
+			}
+			return nil
+		}
+
+		fmt.Print(formatDeploymentMatrix(matrix, true))
+
+		if ShouldCopyOutput(cmd) {
+			htmlContent := devops.WrapHTMLForClipboard(svc.GenerateHTMLDeploymentMatrix(matrix))
+			if err := CopyRichToClipboard(htmlContent, formatDeploymentMatrix(matrix, false)); err != nil {
+				return fmt.Errorf("failed to copy to clipboard: %w", err)
+			}
+			fmt.Println("\n✓ Copied to clipboard!")
+		}
+
+		return nil
+	},
+}
+
+// formatDeploymentMatrix renders the matrix as a text grid with three lines per
+// definition: release, build and source, and deploy time
+func formatDeploymentMatrix(matrix *devops.DeploymentMatrix, colored bool) string {
+	labelWidth := len("DEFINITION")
+	for _, row := range matrix.Rows {
+		labelWidth = max(labelWidth, len([]rune(row.Definition)))
+	}
+	labelWidth = min(labelWidth, 32)
+
+	var b strings.Builder
+	header := fmt.Sprintf("%-*s", labelWidth, "DEFINITION")
+	for _, env := range matrix.Environments {
+		header += fmt.Sprintf("  %-*s", matrixCellWidth, truncateString(strings.ToUpper(env), matrixCellWidth))
+	}
+	b.WriteString(strings.TrimRight(header, " ") + "\n")
+
+	behind := color.New(color.FgYellow)
+	for _, row := range matrix.Rows {
+		for line := 0; line < 3; line++ {
+			label := ""
+			if line == 0 {
+				label = truncateString(row.Definition, labelWidth)
+			}
+			text := fmt.Sprintf("%-*s", labelWidth, label)
+
+			for _, env := range matrix.Environments {
+				cell := row.Cell(env)
+				value := fmt.Sprintf("%-*s", matrixCellWidth, truncateString(matrixCellLine(cell, line), matrixCellWidth))
+				if colored && cell != nil && cell.Behind {
+					value = behind.Sprint(value)
+				}
+				text += "  " + value
+			}
+			b.WriteString(strings.TrimRight(text, " ") + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func matrixCellLine(cell *devops.MatrixCell, line int) string {
+	if cell == nil {
+		return ""
+	}
+	if cell.ReleaseID == 0 {
+		if line == 0 {
+			if cell.Behind {
+				return "- ⚠ behind"
+			}
+			return "-"
+		}
+		return ""
+	}
+
+	switch line {
+	case 0:
+		if cell.Behind {
+			return cell.ReleaseName + " ⚠ behind"
+		}
+		return cell.ReleaseName
+	case 1:
+		source := cell.Branch
+		if cell.Commit != "" {
+			source += "@" + devops.ShortCommit(cell.Commit)
+		}
+		if cell.BuildID != 0 {
+			return fmt.Sprintf("#%d %s", cell.BuildID, source)
+		}
+		return source
+	default:
+		return cell.DeployedAt.Local().Format("2006-01-02 15:04")
+	}
+}
+
+func init() {
+	deploymentMatrixCmd.Flags().StringVar(&matrixDefinition, "definition", "", "Only show this release definition (name or ID)")
+	deploymentMatrixCmd.Flags().BoolVar(&matrixHTML, "html", false, "Print the matrix as an HTML table (the format copied for Teams)")
+}
@@ -112,6 +112,9 @@ func outputDeployments(deployments []cache.Deployment, shouldCopy bool) error {
 		// Build output
 		fmt.Printf("Release ID: %d\n", deployment.ReleaseID)
 		fmt.Printf("  Release Name: %s\n", deployment.ReleaseName)
+		if deployment.Environment != "" {
+			fmt.Printf("  Environment: %s\n", deployment.Environment)
+		}
 		fmt.Printf("  Status: %s\n", deployment.Status)
 		fmt.Printf("  Start Time: %s\n", deployment.StartTime.Format("2006-01-02 15:04:05"))
 		if deployment.EndTime.Valid {
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -26,6 +27,8 @@ const (
 	FormatJSON OutputFormat = "json"
 	// FormatYAML outputs as YAML
 	FormatYAML OutputFormat = "yaml"
+	// FormatCSV outputs as CSV, for commands with tabular data
+	FormatCSV OutputFormat = "csv"
 )
 
 // OutputWriter handles structured output formatting
@@ -37,7 +40,7 @@ type OutputWriter struct {
 // NewOutputWriter creates a new output writer with the specified format
 func NewOutputWriter(format string) *OutputWriter {
 	f := OutputFormat(format)
-	if f != FormatJSON && f != FormatYAML && f != FormatModern {
+	if f != FormatJSON && f != FormatYAML && f != FormatModern && f != FormatCSV {
 		f = FormatTable // default
 	}
 	return &OutputWriter{
@@ -80,6 +83,18 @@ func (w *OutputWriter) Write(data interface{}) error {
 	}
 }
 
+// WriteCSV writes a header and rows as CSV
+func (w *OutputWriter) WriteCSV(header []string, rows [][]string) error {
+	writer := csv.NewWriter(w.writer)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
 // WriteBytes writes raw bytes to output
 func (w *OutputWriter) WriteBytes(data []byte) error {
 	_, err := w.writer.Write(data)
@@ -88,7 +103,7 @@ func (w *OutputWriter) WriteBytes(data []byte) error {
 
 // ValidFormats returns a list of valid output formats
 func ValidFormats() []string {
-	return []string{"table", "modern", "json", "yaml"}
+	return []string{"table", "modern", "json", "yaml", "csv"}
 }
 
 func FormatDuration(startTime time.Time) string {
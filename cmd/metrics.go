@@ -0,0 +1,11 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Delivery metrics commands",
+	Long:  `Commands for reporting delivery metrics from cached builds and deployments`,
+	Example: `  # DORA metrics of production deployments over the last quarter
+  adoctl metrics dora --environment prod --since 90d`,
+}
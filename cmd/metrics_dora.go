@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"adoctl/pkg/config"
+	"adoctl/pkg/devops"
+	"adoctl/pkg/filter"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doraEnvironment string
+	doraSince       string
+	doraGroupBy     string
+)
+
+// unassignedTeam groups the repositories no configured team lists
+const unassignedTeam = "(unassigned)"
+
+var metricsDoraCmd = &cobra.Command{
+	Use:   "dora",
+	Short: "DORA metrics of deployments to an environment",
+	Long: `Compute the four DORA metrics of deployments to an environment, per repository
+or team and overall:
+
+  Deployment frequency   succeeded deployments per week
+  Lead time for changes  PR creation to the first deployment of a build made
+                         after the PR merged (p50 and p90)
+  Change failure rate    failed deployments, and deployments rolled back by
+                         redeploying an older release, over all deployments
+  Time to restore        failed change to the next succeeded deployment of the
+                         same release definition
+
+Deployments and builds come from the cache, whose first sync fetches a month of
+history; the report notes when the cache starts after --since. Pull requests
+completed in the period are fetched from Azure DevOps; those open for more than
+90 days before --since are not counted.
+
+Teams are defined in the config file as lists of repositories:
+
+  teams:
+    payments: [payments-api, checkout-web]`,
+	Example: `  # Production metrics for the last quarter, per repository
+  adoctl metrics dora --environment prod --since 90d
+
+  # Per team
+  adoctl metrics dora --environment prod --group-by team
+
+  # Export
+  adoctl metrics dora --environment prod --format csv > dora.csv
+  adoctl metrics dora --environment prod --format json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		since, err := filter.ParseAge(doraSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+
+		groupOf := func(repository string) string { return repository }
+		switch doraGroupBy {
+		case "repo":
+		case "team":
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			if len(cfg.Teams) == 0 {
+				return fmt.Errorf("no teams in the config file, add a 'teams' map of team names to repositories")
+			}
+			groupOf = func(repository string) string {
+				if team := cfg.TeamOfRepository(repository); team != "" {
+					return team
+				}
+				return unassignedTeam
+			}
+		default:
+			return fmt.Errorf("invalid --group-by '%s' (use repo or team)", doraGroupBy)
+		}
+
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		report, err := svc.GetDoraReport(ctx, doraEnvironment, time.Now().Add(-since), groupOf)
+		if err != nil {
+			return err
+		}
+
+		writer := NewOutputWriter(cmd.Flag("format").Value.String())
+		switch {
+		case writer.IsStructured():
+			return writer.Write(report)
+		case writer.GetFormat() == FormatCSV:
+			return writer.WriteCSV(doraCSV(report))
+		}
+
+		printDoraReport(report)
+		return nil
+	},
+}
+
+func printDoraReport(report *devops.DoraReport) {
+	fmt.Printf("DORA metrics for %s since %s\n", report.Environment, report.Since.Local().Format("2006-01-02"))
+	if report.DataFrom != nil && report.DataFrom.After(report.Since.Add(24*time.Hour)) {
+		_, _ = color.New(color.FgYellow).Printf("⚠ Cached deployments start on %s, earlier deployments are not counted\n",
+			report.DataFrom.Local().Format("2006-01-02"))
+	}
+	fmt.Println()
+
+	if report.Summary.Attempts == 0 {
+		fmt.Printf("No cached deployments to '%s' in this period\n", report.Environment)
+		return
+	}
+
+	labelWidth := len(strings.ToUpper(doraGroupBy))
+	for _, g := range report.Groups {
+		labelWidth = max(labelWidth, len([]rune(g.Group)))
+	}
+	labelWidth = min(labelWidth, 40)
+
+	row := func(label string, m devops.DoraMetrics) string {
+		return strings.TrimRight(fmt.Sprintf("%-*s  %7d  %8.1f  %-19s  %-17s  %s",
+			labelWidth, truncateString(label, labelWidth), m.Deployments, m.DeploymentsPerWeek,
+			formatStatsSeconds(m.LeadTimeP50Seconds)+" / "+formatStatsSeconds(m.LeadTimeP90Seconds),
+			fmt.Sprintf("%.1f%% (%d/%d)", m.ChangeFailureRate*100, m.Failed+m.RolledBack, m.Attempts),
+			formatStatsSeconds(m.TimeToRestoreP50Seconds)), " ")
+	}
+
+	fmt.Printf("%-*s  %7s  %8s  %-19s  %-17s  %s\n",
+		labelWidth, strings.ToUpper(doraGroupBy), "DEPLOYS", "PER WEEK", "LEAD TIME P50 / P90", "CHANGE FAILURE", "RESTORE P50")
+	for _, g := range report.Groups {
+		fmt.Println(row(g.Group, g))
+	}
+	if len(report.Groups) > 1 {
+		_, _ = color.New(color.Bold).Println(row("ALL", report.Summary))
+	}
+
+	s := report.Summary
+	fmt.Printf("\n%d changes measured for lead time, %d failed and %d rolled back deployments, %d restores\n",
+		s.LeadTimeChanges, s.Failed, s.RolledBack, s.Restores)
+}
+
+// doraCSV returns the metrics of each group and overall as CSV rows
+func doraCSV(report *devops.DoraReport) ([]string, [][]string) {
+	header := []string{
+		"group", "environment", "since", "until", "deployments", "deployments_per_week",
+		"lead_time_changes", "lead_time_p50_seconds", "lead_time_p90_seconds",
+		"attempts", "failed", "rolled_back", "change_failure_rate",
+		"restores", "time_to_restore_p50_seconds", "time_to_restore_max_seconds",
+	}
+
+	metrics := append([]devops.DoraMetrics{}, report.Groups...)
+	overall := report.Summary
+	overall.Group = "ALL"
+	metrics = append(metrics, overall)
+
+	rows := make([][]string, 0, len(metrics))
+	for _, m := range metrics {
+		rows = append(rows, []string{
+			m.Group,
+			report.Environment,
+			report.Since.Format(time.RFC3339),
+			report.Until.Format(time.RFC3339),
+			strconv.Itoa(m.Deployments),
+			strconv.FormatFloat(m.DeploymentsPerWeek, 'f', 2, 64),
+			strconv.Itoa(m.LeadTimeChanges),
+			strconv.Itoa(m.LeadTimeP50Seconds),
+			strconv.Itoa(m.LeadTimeP90Seconds),
+			strconv.Itoa(m.Attempts),
+			strconv.Itoa(m.Failed),
+			strconv.Itoa(m.RolledBack),
+			strconv.FormatFloat(m.ChangeFailureRate, 'f', 4, 64),
+			strconv.Itoa(m.Restores),
+			strconv.Itoa(m.TimeToRestoreP50Seconds),
+			strconv.Itoa(m.TimeToRestoreMaxSeconds),
+		})
+	}
+	return header, rows
+}
+
+func init() {
+	metricsDoraCmd.Flags().StringVar(&doraEnvironment, "environment", "", "Release environment to measure, e.g. prod (required)")
+	metricsDoraCmd.Flags().StringVar(&doraSince, "since", "90d", "Period to measure (e.g. 30d, 12w)")
+	metricsDoraCmd.Flags().StringVar(&doraGroupBy, "group-by", "repo", "Break results down by repo or team")
+	if err := metricsDoraCmd.MarkFlagRequired("environment"); err != nil {
+		panic(err)
+	}
+}
@@ -77,7 +77,7 @@ var pipelineStatusCmd = &cobra.Command{
 			return fmt.Errorf("no PR numbers specified. Use --pr or --file")
 		}
 
-		uniquePRs := deduplicatePRs(prIDs)
+		uniquePRs := dedupeIDs(prIDs)
 
 		shouldCopy := ShouldCopyOutput(cmd)
 
@@ -110,13 +110,14 @@ func resolvePRIDs() ([]int, error) {
 	return allPRs, nil
 }
 
-func deduplicatePRs(prs []int) []int {
+// dedupeIDs removes repeated IDs, keeping the first occurrence of each
+func dedupeIDs(ids []int) []int {
 	seen := make(map[int]bool)
 	unique := []int{}
-	for _, pr := range prs {
-		if !seen[pr] {
-			seen[pr] = true
-			unique = append(unique, pr)
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
 		}
 	}
 	return unique
@@ -197,9 +198,22 @@ func buildDetailedPRCopyOutput(markdownBuilder *strings.Builder, pr *git.GitPull
 	}
 	markdownBuilder.WriteString(")\n")
 
+	// Add branch policy evaluations
+	policies := getPRPolicies(ctx, svc, pr)
+	if len(policies) > 0 {
+		markdownBuilder.WriteString("  Policies:\n")
+		for _, p := range policies {
+			if p.IsBlockingMerge() {
+				fmt.Fprintf(markdownBuilder, "    - **%s**\n", formatPolicyLine(p))
+			} else {
+				fmt.Fprintf(markdownBuilder, "    - %s\n", formatPolicyLine(p))
+			}
+		}
+	}
+
 	// Add build/deployment info
 	if pr.LastMergeCommit != nil && pr.LastMergeCommit.CommitId != nil {
-		builds, err := svc.GetBuildsForCommit(*pr.LastMergeCommit.CommitId, "", "")
+		builds, err := svc.GetBuildsForPullRequest(pr)
 		if err == nil && len(builds) > 0 {
 			markdownBuilder.WriteString("  Builds:\n")
 			for _, build := range builds {
@@ -234,11 +248,68 @@ func printDetailedPRStatus(ctx context.Context, svc *devops.DevOpsService, pr *g
 
 	printApprovalStatus(pr.Reviewers)
 
+	printPolicyEvaluations(ctx, svc, pr)
+
 	fmt.Println()
 
 	printBuildsAndDeployments(ctx, svc, pr)
 }
 
+// getPRPolicies fetches the branch policy evaluations for a PR, logging and
+// returning nil if they cannot be retrieved.
+func getPRPolicies(ctx context.Context, svc *devops.DevOpsService, pr *git.GitPullRequest) []devops.PolicyEvaluation {
+	if pr.PullRequestId == nil || pr.Repository == nil || pr.Repository.Project == nil || pr.Repository.Project.Id == nil {
+		return nil
+	}
+
+	policies, err := svc.GetPolicyEvaluations(ctx, pr.Repository.Project.Id.String(), *pr.PullRequestId)
+	if err != nil {
+		logger.Debug().Err(err).Int("pr_id", *pr.PullRequestId).Msg("Failed to get policy evaluations")
+		return nil
+	}
+
+	return policies
+}
+
+// formatPolicyLine renders a policy evaluation, flagging blocking policies that are not fulfilled.
+func formatPolicyLine(p devops.PolicyEvaluation) string {
+	line := fmt.Sprintf("%s %s: %s", devops.PolicyStatusIcon(p.Status), p.Name, devops.FormatPolicyStatus(p.Status))
+	if p.IsBlockingMerge() {
+		line += " (BLOCKING)"
+	} else if !p.IsBlocking {
+		line += " (optional)"
+	}
+	return line
+}
+
+func printPolicyEvaluations(ctx context.Context, svc *devops.DevOpsService, pr *git.GitPullRequest) {
+	policies := getPRPolicies(ctx, svc, pr)
+	if len(policies) == 0 {
+		return
+	}
+
+	blocking := devops.BlockingPolicies(policies)
+	fmt.Printf("Policies:  %d/%d passing", len(policies)-countNotPassing(policies), len(policies))
+	if len(blocking) > 0 {
+		fmt.Printf(", %d blocking", len(blocking))
+	}
+	fmt.Println()
+
+	for _, p := range policies {
+		fmt.Printf("  %s\n", formatPolicyLine(p))
+	}
+}
+
+func countNotPassing(policies []devops.PolicyEvaluation) int {
+	count := 0
+	for _, p := range policies {
+		if !p.IsPassing() {
+			count++
+		}
+	}
+	return count
+}
+
 func printApprovalStatus(reviewers *[]git.IdentityRefWithVote) {
 	var reviewersList []git.IdentityRefWithVote
 	if reviewers != nil {
@@ -257,7 +328,7 @@ func printBuildsAndDeployments(ctx context.Context, svc *devops.DevOpsService, p
 		return
 	}
 
-	builds, err := svc.GetBuildsForCommit(*pr.LastMergeCommit.CommitId, "", "")
+	builds, err := svc.GetBuildsForPullRequest(pr)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to get builds")
 		return
@@ -274,7 +345,7 @@ func printBuildsAndDeployments(ctx context.Context, svc *devops.DevOpsService, p
 		}
 		fmt.Printf("Build #%d\n", build.BuildID)
 		fmt.Printf("  Status:     %s\n", build.Status)
-		fmt.Printf("  Result:     %s\n", build.Result)
+		fmt.Printf("  Result:     %s%s\n", build.Result, formatFailedTests(ctx, svc, build))
 		fmt.Printf("  Started:    %s\n", time.Time(build.StartTime).Format("2006-01-02 15:04:05"))
 
 		if build.Status == "inProgress" {
@@ -353,6 +424,13 @@ func displayModernFormatWithCopy(svc *devops.DevOpsService, prIDs []int, shouldC
 			fmt.Fprintf(&markdownBuilder, "- **PR #%d**: %s\n", s.ID, s.Title)
 			fmt.Fprintf(&markdownBuilder, "  Repository: %s | Status: %s | CI: %s %s\n",
 				s.Repository, s.PRStatus, s.CIStatus, s.CIDescription)
+			if blocking := devops.BlockingPolicies(s.Policies); len(blocking) > 0 {
+				names := make([]string, 0, len(blocking))
+				for _, p := range blocking {
+					names = append(names, fmt.Sprintf("%s (%s)", p.Name, devops.FormatPolicyStatus(p.Status)))
+				}
+				fmt.Fprintf(&markdownBuilder, "  Blocking policies: %s\n", strings.Join(names, ", "))
+			}
 			if s.CDStatus != "" {
 				fmt.Fprintf(&markdownBuilder, "  CD: %s", s.CDStatus)
 				if s.CDDescription != "" {
@@ -403,6 +481,7 @@ func collectPRSummaries(svc *devops.DevOpsService, prIDs []int) []*devops.PRSumm
 		builds      []cache.Build
 		deployments []devops.DeploymentStatusInfo
 		pr          *git.GitPullRequest
+		policies    []devops.PolicyEvaluation
 		err         error
 	}
 
@@ -423,9 +502,10 @@ func collectPRSummaries(svc *devops.DevOpsService, prIDs []int) []*devops.PRSumm
 					continue
 				}
 				result.pr = pr
+				result.policies = getPRPolicies(context.Background(), svc, pr)
 
 				if pr.LastMergeCommit != nil && pr.LastMergeCommit.CommitId != nil && *pr.LastMergeCommit.CommitId != "" {
-					builds, err := svc.GetBuildsForCommit(*pr.LastMergeCommit.CommitId, "", "")
+					builds, err := svc.GetBuildsForPullRequest(pr)
 					if err == nil {
 						result.builds = builds
 					}
@@ -454,7 +534,7 @@ func collectPRSummaries(svc *devops.DevOpsService, prIDs []int) []*devops.PRSumm
 			continue
 		}
 
-		summary := devops.BuildPRSummary(res.pr, res.builds, convertDeployments(res.deployments))
+		summary := devops.BuildPRSummary(res.pr, res.builds, convertDeployments(res.deployments), res.policies)
 		mu.Lock()
 		summaries = append(summaries, summary)
 		mu.Unlock()
@@ -506,6 +586,26 @@ func printModernTable(summaries []*devops.PRSummary) {
 			row := formatPRSummaryRow(s, colPR, colTitle, colRepo, colStatus, colCI, colCD, colUpdated)
 			fmt.Println(row)
 		}
+
+		printBlockingPolicies(summaries)
+	}
+}
+
+func printBlockingPolicies(summaries []*devops.PRSummary) {
+	printedHeader := false
+	for _, s := range summaries {
+		blocking := devops.BlockingPolicies(s.Policies)
+		if len(blocking) == 0 {
+			continue
+		}
+		if !printedHeader {
+			fmt.Println()
+			fmt.Println("⚠ Blocking policies:")
+			printedHeader = true
+		}
+		for _, p := range blocking {
+			fmt.Printf("  PR #%d: %s\n", s.ID, formatPolicyLine(p))
+		}
 	}
 }
 
@@ -550,6 +650,27 @@ func formatPRSummaryRow(s *devops.PRSummary, colPR, colTitle, colRepo, colStatus
 		colUpdated-2, lastUpdate)
 }
 
+// formatFailedTests returns a " (N failed tests)" suffix for a completed build with
+// failing tests, or an empty string
+func formatFailedTests(ctx context.Context, svc *devops.DevOpsService, build cache.Build) string {
+	if build.Status != "completed" || build.Result == "succeeded" {
+		return ""
+	}
+
+	failed, err := svc.GetFailedTestCount(ctx, build.BuildID)
+	if err != nil {
+		logger.Debug().Err(err).Int("build_id", build.BuildID).Msg("Failed to get test results")
+		return ""
+	}
+	if failed == 0 {
+		return ""
+	}
+	if failed == 1 {
+		return " (1 failed test)"
+	}
+	return fmt.Sprintf(" (%d failed tests)", failed)
+}
+
 func readPRsFromFile(filename string) ([]int, error) {
 	file, err := os.Open(filename)
 	if err != nil {
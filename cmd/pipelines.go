@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"adoctl/pkg/azure/client"
+	"adoctl/pkg/devops"
+	"adoctl/pkg/errors"
+	"adoctl/pkg/git"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pipelinesListRefresh bool
+	pipelinesListFilter  string
+
+	pipelinesPreviewBranch string
+	pipelinesPreviewParams []string
+	pipelinesPreviewFile   string
+)
+
+// PipelineOutput represents a pipeline definition for structured output
+type PipelineOutput struct {
+	ID     int    `json:"id" yaml:"id"`
+	Name   string `json:"name" yaml:"name"`
+	Folder string `json:"folder" yaml:"folder"`
+	Path   string `json:"path" yaml:"path"`
+}
+
+var pipelinesCmd = &cobra.Command{
+	Use:     "pipeline",
+	Aliases: []string{"pipelines"},
+	Short:   "Pipeline definition commands",
+	Long:    `Commands for inspecting and validating the pipeline definitions that produce builds`,
+	Example: `  # List pipelines
+  adoctl pipeline list
+
+  # Repository, YAML file, triggers and last run per branch of a pipeline
+  adoctl pipeline show my-pipeline
+
+  # Expand the YAML of a pipeline on a branch
+  adoctl pipeline preview my-pipeline --branch feature/templates`,
+}
+
+var pipelinesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List pipeline definitions",
+	Long: `List the pipeline definitions of the project with their ID and folder.
+
+The list comes from the local cache and is refreshed from Azure DevOps once it is
+older than the definitions TTL (ADOCTL_CACHE_DEFINITIONS_TTL, 24h by default) or
+with --refresh.`,
+	Example: `  # List pipelines
+  adoctl pipeline list
+
+  # Pipelines whose name or folder contains "deploy"
+  adoctl pipeline list --filter deploy
+
+  # Refresh the cached list
+  adoctl pipeline list --refresh
+
+  # Output as JSON
+  adoctl pipeline list --format json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		definitions, err := svc.ListDefinitions(ctx, pipelinesListRefresh)
+		if err != nil {
+			return fmt.Errorf("failed to list pipelines: %w", err)
+		}
+
+		outputs := []PipelineOutput{}
+		needle := strings.ToLower(pipelinesListFilter)
+		for _, d := range definitions {
+			path := devops.DefinitionFullPath(d)
+			if needle != "" && !strings.Contains(strings.ToLower(path), needle) {
+				continue
+			}
+			outputs = append(outputs, PipelineOutput{ID: d.ID, Name: d.Name, Folder: d.Path, Path: path})
+		}
+
+		writer := NewOutputWriter(cmd.Flag("format").Value.String())
+		if writer.IsStructured() {
+			return writer.Write(outputs)
+		}
+		if writer.GetFormat() == FormatCSV {
+			rows := make([][]string, 0, len(outputs))
+			for _, o := range outputs {
+				rows = append(rows, []string{fmt.Sprint(o.ID), o.Name, o.Folder})
+			}
+			return writer.WriteCSV([]string{"id", "name", "folder"}, rows)
+		}
+
+		if len(outputs) == 0 {
+			fmt.Println("No pipelines found")
+			return nil
+		}
+
+		fmt.Printf("%-7s  %-40s  %s\n", "ID", "NAME", "FOLDER")
+		for _, o := range outputs {
+			fmt.Printf("%-7d  %-40s  %s\n", o.ID, truncateString(o.Name, 40), o.Folder)
+		}
+		fmt.Printf("\n%d pipelines\n", len(outputs))
+		return nil
+	},
+}
+
+var pipelinesShowCmd = &cobra.Command{
+	Use:   "show <pipeline>",
+	Short: "Show a pipeline definition",
+	Long: `Show the repository, YAML file, default branch and triggers of a pipeline, with
+the last run of each branch among its recent builds.
+
+The pipeline can be given by ID, name or folder path (e.g. \team\deploy).`,
+	Example: `  # Show a pipeline by name
+  adoctl pipeline show my-pipeline
+
+  # Show a pipeline by ID as JSON
+  adoctl pipeline show 42 --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		definition, err := svc.ResolveDefinition(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		info, err := svc.GetPipelineInfo(ctx, definition.ID)
+		if err != nil {
+			return err
+		}
+
+		writer := NewOutputWriter(cmd.Flag("format").Value.String())
+		if writer.IsStructured() {
+			return writer.Write(info)
+		}
+
+		printPipelineInfo(info)
+		return nil
+	},
+}
+
+var pipelinesPreviewCmd = &cobra.Command{
+	Use:   "preview <pipeline>",
+	Short: "Expand the YAML of a pipeline on a branch",
+	Long: `Dry-run a YAML pipeline on a branch and print the final YAML with every template
+expanded, without queueing a run. Template and syntax errors are reported the same
+way a real run would report them, so template changes can be validated before they
+are pushed or merged.
+
+--file previews a local YAML file in place of the pipeline's file on the branch;
+templates it references are still read from the branch.`,
+	Example: `  # Expand the pipeline on the current branch
+  adoctl pipeline preview my-pipeline
+
+  # Validate a branch with template parameters
+  adoctl pipeline preview my-pipeline --branch feature/templates --param environment=prod
+
+  # Validate local, uncommitted changes to the pipeline file
+  adoctl pipeline preview my-pipeline --branch main --file azure-pipelines.yml`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := parseKeyValues("--param", pipelinesPreviewParams)
+		if err != nil {
+			return err
+		}
+
+		yamlOverride := ""
+		if pipelinesPreviewFile != "" {
+			content, err := os.ReadFile(pipelinesPreviewFile)
+			if err != nil {
+				return errors.NewWithError(errors.ExitCodeFileOperation, "failed to read pipeline file", err)
+			}
+			yamlOverride = string(content)
+		}
+
+		branch := pipelinesPreviewBranch
+		if branch == "" {
+			if !git.IsGitRepository() {
+				return errors.ValidationError("--branch is required outside a git repository")
+			}
+			branch, err = git.GetCurrentBranch()
+			if err != nil {
+				return fmt.Errorf("failed to get current branch: %w", err)
+			}
+		}
+
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		definition, err := svc.ResolveDefinition(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		finalYAML, err := svc.PreviewPipeline(ctx, definition.ID, devops.PreviewOptions{
+			Branch:       branch,
+			Parameters:   params,
+			YAMLOverride: yamlOverride,
+		})
+		if client.IsBadRequest(err) {
+			return errors.NewWithAll(errors.ExitCodeValidation,
+				fmt.Sprintf("Pipeline %s is not valid on %s", definition.Name, branch), err,
+				"Only YAML pipelines can be previewed; check the templates referenced from the pipeline file")
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "✓ %s is valid on %s\n", definition.Name, branch)
+		fmt.Print(finalYAML)
+		if !strings.HasSuffix(finalYAML, "\n") {
+			fmt.Println()
+		}
+		return nil
+	},
+}
+
+func printPipelineInfo(info *devops.PipelineInfo) {
+	bold := color.New(color.Bold)
+
+	_, _ = bold.Printf("%s (id %d)\n", info.Name, info.ID)
+	fmt.Printf("  Folder:         %s\n", info.Folder)
+	fmt.Printf("  Repository:     %s\n", info.Repository)
+	fmt.Printf("  Default branch: %s\n", info.DefaultBranch)
+	if info.YAMLPath != "" {
+		fmt.Printf("  YAML file:      %s\n", info.YAMLPath)
+	} else {
+		fmt.Printf("  YAML file:      - (classic pipeline)\n")
+	}
+	fmt.Printf("  URL:            %s\n", info.URL)
+
+	fmt.Println()
+	_, _ = bold.Println("Triggers")
+	if len(info.Triggers) == 0 {
+		fmt.Println("  none")
+	}
+	for _, t := range info.Triggers {
+		fmt.Printf("  %s\n", formatPipelineTrigger(t))
+	}
+
+	fmt.Println()
+	_, _ = bold.Println("Last run per branch")
+	if len(info.LastRuns) == 0 {
+		fmt.Println("  no runs")
+		return
+	}
+
+	branchWidth := len("BRANCH")
+	for _, run := range info.LastRuns {
+		branchWidth = max(branchWidth, len([]rune(run.Branch)))
+	}
+	branchWidth = min(branchWidth, 40)
+
+	fmt.Printf("  %-*s  %-8s  %-22s  %-20s  %s\n", branchWidth, "BRANCH", "BUILD", "STATUS", "REASON", "QUEUED")
+	for _, run := range info.LastRuns {
+		fmt.Printf("  %-*s  %-8d  %-22s  %-20s  %s ago\n",
+			branchWidth, truncateString(run.Branch, branchWidth), run.BuildID,
+			devops.FormatBuildStatus(run.Status, run.Result), run.Reason, formatAge(time.Since(run.QueueTime)))
+	}
+}
+
+func formatPipelineTrigger(t devops.PipelineTrigger) string {
+	if t.FromYAML {
+		return fmt.Sprintf("%s (defined in YAML)", t.Type)
+	}
+
+	parts := []string{t.Type}
+	if len(t.Branches) > 0 {
+		parts = append(parts, "branches: "+strings.Join(t.Branches, ", "))
+	}
+	if len(t.Paths) > 0 {
+		parts = append(parts, "paths: "+strings.Join(t.Paths, ", "))
+	}
+	if len(t.Schedules) > 0 {
+		parts = append(parts, "at "+strings.Join(t.Schedules, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func init() {
+	pipelinesListCmd.Flags().BoolVar(&pipelinesListRefresh, "refresh", false, "Refresh the cached list from Azure DevOps")
+	pipelinesListCmd.Flags().StringVar(&pipelinesListFilter, "filter", "", "Only list pipelines whose name or folder contains this text")
+
+	pipelinesPreviewCmd.Flags().StringVar(&pipelinesPreviewBranch, "branch", "", "Branch to expand the YAML on (default: current git branch)")
+	pipelinesPreviewCmd.Flags().StringArrayVar(&pipelinesPreviewParams, "param", nil, "YAML template parameter as key=value (repeatable)")
+	pipelinesPreviewCmd.Flags().StringVar(&pipelinesPreviewFile, "file", "", "Local YAML file to preview in place of the pipeline's file")
+}
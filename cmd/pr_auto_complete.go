@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"fmt"
+
+	"adoctl/pkg/azure/client"
+	"adoctl/pkg/devops"
+	"adoctl/pkg/git"
+	"adoctl/pkg/logger"
+	"adoctl/pkg/models"
+
+	adogit "github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/spf13/cobra"
+)
+
+var (
+	autoCompleteRepoName            string
+	autoCompleteRepoID              string
+	autoCompletePRID                int
+	autoCompleteStrategy            string
+	autoCompleteDeleteSource        bool
+	autoCompleteTransitionWorkItems bool
+	autoCompleteMessage             string
+	autoCompleteCancel              bool
+	autoCompleteUseGitContext       bool
+	autoCompleteNoGitContext        bool
+)
+
+var autoCompleteCmd = &cobra.Command{
+	Use:   "auto-complete",
+	Short: "Set or cancel auto-complete on a pull request",
+	Long: `Arm auto-complete on a pull request so Azure DevOps completes it as soon as
+all branch policies pass. Auto-complete is set on behalf of the authenticated user.
+
+Use --cancel to clear auto-complete on a PR.`,
+	Example: `  # Set auto-complete on PR #123 (auto-detect repository from git)
+  adoctl pr auto-complete --pr 123
+
+  # Squash, delete the source branch and transition work items on completion
+  adoctl pr auto-complete --pr 123 --strategy squash --delete-source --transition-work-items
+
+  # Set auto-complete with a custom merge commit message
+  adoctl pr auto-complete --pr 123 --message "Merged featureXYZ"
+
+  # Cancel auto-complete
+  adoctl pr auto-complete --pr 123 --cancel`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		// Determine if we should use git context
+		useGitContext := autoCompleteUseGitContext && !autoCompleteNoGitContext && git.IsGitRepository()
+
+		repoID, repoName, err := ResolveRepoID(svc, autoCompleteRepoName, autoCompleteRepoID, useGitContext)
+		if err != nil {
+			return err
+		}
+
+		pr, err := svc.GetPullRequest(ctx, autoCompletePRID)
+		if err != nil {
+			return fmt.Errorf("failed to get PR #%d: %w", autoCompletePRID, err)
+		}
+
+		if err = validatePRRepo(pr, repoID, autoCompletePRID); err != nil {
+			return err
+		}
+
+		if pr.Status == nil || adogit.PullRequestStatus(*pr.Status) != adogit.PullRequestStatusValues.Active {
+			return fmt.Errorf("PR #%d is not active", autoCompletePRID)
+		}
+
+		current := models.PullRequestFromAzure(pr)
+
+		details := map[string]string{
+			"PR":         fmt.Sprintf("#%d", autoCompletePRID),
+			"Title":      current.Title,
+			"Repository": repoName,
+		}
+
+		if autoCompleteCancel {
+			if !current.HasAutoComplete() {
+				fmt.Printf("Auto-complete is not set on PR #%d\n", autoCompletePRID)
+				return nil
+			}
+			details["Set By"] = current.AutoCompleteSetBy.DisplayName
+
+			if IsDryRun() {
+				PrintDryRunAction("cancel auto-complete", details)
+				return nil
+			}
+
+			if err = RequireConfirmation("cancel auto-complete on this pull request", details); err != nil {
+				return err
+			}
+
+			if _, err = svc.CancelPullRequestAutoComplete(ctx, repoID, autoCompletePRID); err != nil {
+				return fmt.Errorf("failed to cancel auto-complete on PR #%d: %w", autoCompletePRID, err)
+			}
+
+			logger.Info().Msg("Auto-complete canceled successfully")
+			fmt.Printf("PR ID: %d\n", autoCompletePRID)
+			return nil
+		}
+
+		if autoCompleteStrategy != "" {
+			details["Strategy"] = autoCompleteStrategy
+		}
+		if autoCompleteDeleteSource {
+			details["Delete Source Branch"] = "yes"
+		}
+		if autoCompleteTransitionWorkItems {
+			details["Transition Work Items"] = "yes"
+		}
+		if autoCompleteMessage != "" {
+			details["Custom Message"] = "yes"
+		}
+
+		if IsDryRun() {
+			PrintDryRunAction("set auto-complete", details)
+			return nil
+		}
+
+		if err = RequireConfirmation("set auto-complete on this pull request", details); err != nil {
+			return err
+		}
+
+		var strategyPtr *client.GitPullRequestMergeStrategy
+		if autoCompleteStrategy != "" {
+			strategy := client.GitPullRequestMergeStrategy(autoCompleteStrategy)
+			strategyPtr = &strategy
+		}
+
+		result, err := svc.SetPullRequestAutoComplete(ctx, repoID, autoCompletePRID, strategyPtr, autoCompleteDeleteSource, autoCompleteTransitionWorkItems, autoCompleteMessage)
+		if err != nil {
+			return fmt.Errorf("failed to set auto-complete on PR #%d: %w", autoCompletePRID, err)
+		}
+
+		updated := models.PullRequestFromAzure(result)
+
+		logger.Info().Msg("Auto-complete set successfully")
+		fmt.Printf("PR ID: %d\n", autoCompletePRID)
+		if updated.HasAutoComplete() {
+			fmt.Printf("Auto-complete set by: %s\n", updated.AutoCompleteSetBy.DisplayName)
+		}
+		if updated.URL != "" {
+			fmt.Printf("URL: %s\n", updated.URL)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	autoCompleteCmd.Flags().StringVar(&autoCompleteRepoName, "repository-name", "", "Repository name (auto-detected from git if not specified)")
+	autoCompleteCmd.Flags().StringVar(&autoCompleteRepoID, "repo-id", "", "Repository ID (alternative to --repository-name)")
+	autoCompleteCmd.Flags().IntVar(&autoCompletePRID, "pr", 0, "Pull request ID")
+	autoCompleteCmd.Flags().StringVar(&autoCompleteStrategy, "strategy", "", "Merge strategy: noFastForward, squash, rebase, rebaseMerge (default: noFastForward)")
+	autoCompleteCmd.Flags().BoolVar(&autoCompleteDeleteSource, "delete-source", false, "Delete source branch after completion")
+	autoCompleteCmd.Flags().BoolVar(&autoCompleteTransitionWorkItems, "transition-work-items", false, "Transition linked work items after completion")
+	autoCompleteCmd.Flags().StringVar(&autoCompleteMessage, "message", "", "Custom merge commit message")
+	autoCompleteCmd.Flags().BoolVar(&autoCompleteCancel, "cancel", false, "Cancel auto-complete")
+	autoCompleteCmd.Flags().BoolVar(&autoCompleteUseGitContext, "use-git-context", true, "Use git context for auto-detection when in a git repository")
+	autoCompleteCmd.Flags().BoolVar(&autoCompleteNoGitContext, "no-git-context", false, "Disable git context auto-detection")
+
+	if err := autoCompleteCmd.MarkFlagRequired("pr"); err != nil {
+		panic(err)
+	}
+	autoCompleteCmd.MarkFlagsMutuallyExclusive("repository-name", "repo-id")
+	autoCompleteCmd.MarkFlagsMutuallyExclusive("use-git-context", "no-git-context")
+	autoCompleteCmd.MarkFlagsMutuallyExclusive("cancel", "strategy")
+	autoCompleteCmd.MarkFlagsMutuallyExclusive("cancel", "delete-source")
+	autoCompleteCmd.MarkFlagsMutuallyExclusive("cancel", "transition-work-items")
+	autoCompleteCmd.MarkFlagsMutuallyExclusive("cancel", "message")
+}
@@ -25,7 +25,10 @@ var (
 var bulkCreateCmd = &cobra.Command{
 	Use:   "bulk-create",
 	Short: "Bulk create pull requests across all repos",
-	Long:  `Creates PRs across all repositories that have the specified source branch.`,
+	Long: `Creates PRs across all repositories that have the specified source branch.
+
+Unlike the bulk commands that act on existing PRs (link-workitems, merge-queue),
+bulk-create has no --query: a query selects pull requests, and there are none yet.`,
 	Example: `  # Create PRs from feature branch to main in all repos
   adoctl pr bulk-create --source-branch feature --target-branch main --title "Merge feature"
 
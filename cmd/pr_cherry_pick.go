@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"adoctl/pkg/devops"
+	"adoctl/pkg/git"
+	"adoctl/pkg/logger"
+	"adoctl/pkg/progress"
+
+	adogit "github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cherryPickRepoName      string
+	cherryPickRepoID        string
+	cherryPickPRID          int
+	cherryPickOnto          []string
+	cherryPickUseGitContext bool
+	cherryPickNoGitContext  bool
+)
+
+var cherryPickCmd = &cobra.Command{
+	Use:   "cherry-pick",
+	Short: "Cherry-pick a pull request onto other branches",
+	Long: `Cherry-pick the changes of a pull request onto one or more branches using the
+Azure DevOps server-side cherry-pick operation.
+
+For each target branch a new branch is generated, and once it is ready a pull
+request is opened with the work items of the original PR linked. --timeout applies
+to each target branch separately.`,
+	Example: `  # Cherry-pick PR #123 onto a release branch
+  adoctl pr cherry-pick --pr 123 --onto release/a
+
+  # Cherry-pick onto several release branches
+  adoctl pr cherry-pick --pr 123 --onto release/a --onto release/b
+
+  # Allow more time for the server to generate the branches
+  adoctl pr cherry-pick --pr 123 --onto release/a --timeout 5m`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		// Determine if we should use git context
+		useGitContext := cherryPickUseGitContext && !cherryPickNoGitContext && git.IsGitRepository()
+
+		repoID, repoName, err := ResolveRepoID(svc, cherryPickRepoName, cherryPickRepoID, useGitContext)
+		if err != nil {
+			return err
+		}
+
+		pr, err := svc.GetPullRequest(ctx, cherryPickPRID)
+		if err != nil {
+			return fmt.Errorf("failed to get PR #%d: %w", cherryPickPRID, err)
+		}
+
+		if err = validatePRRepo(pr, repoID, cherryPickPRID); err != nil {
+			return err
+		}
+
+		if pr.Status != nil && adogit.PullRequestStatus(*pr.Status) == adogit.PullRequestStatusValues.Abandoned {
+			return fmt.Errorf("PR #%d is abandoned", cherryPickPRID)
+		}
+
+		title := ""
+		if pr.Title != nil {
+			title = *pr.Title
+		}
+
+		branches := make([]string, 0, len(cherryPickOnto))
+		for _, onto := range cherryPickOnto {
+			branches = append(branches, fmt.Sprintf("%s (as %s)", onto, devops.CherryPickBranchName(cherryPickPRID, onto)))
+		}
+
+		details := map[string]string{
+			"PR":         fmt.Sprintf("#%d", cherryPickPRID),
+			"Title":      title,
+			"Repository": repoName,
+			"Onto":       strings.Join(branches, ", "),
+		}
+
+		if IsDryRun() {
+			PrintDryRunAction("cherry-pick pull request", details)
+			return nil
+		}
+
+		if err = RequireConfirmation("cherry-pick this pull request", details); err != nil {
+			return err
+		}
+
+		spinner := progress.NewSpinner(fmt.Sprintf("Cherry-picking PR #%d onto %d branch(es)...", cherryPickPRID, len(cherryPickOnto)))
+		spinner.Start()
+		// The prompt may outlast the request timeout, and each branch gets a fresh one
+		results := svc.CherryPickPullRequest(GetContext, pr, cherryPickOnto)
+		spinner.Stop()
+
+		return printRefOperationResults("Cherry-pick", results)
+	},
+}
+
+var (
+	revertRepoName      string
+	revertRepoID        string
+	revertPRID          int
+	revertUseGitContext bool
+	revertNoGitContext  bool
+)
+
+var revertCmd = &cobra.Command{
+	Use:   "revert",
+	Short: "Revert a completed pull request",
+	Long: `Revert a completed pull request using the Azure DevOps server-side revert
+operation.
+
+A revert branch is generated from the PR's target branch, and once it is ready a
+pull request is opened with the work items of the original PR linked.`,
+	Example: `  # Revert PR #123
+  adoctl pr revert --pr 123
+
+  # Revert with explicit repository
+  adoctl pr revert --repository-name my-repo --pr 123`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		// Determine if we should use git context
+		useGitContext := revertUseGitContext && !revertNoGitContext && git.IsGitRepository()
+
+		repoID, repoName, err := ResolveRepoID(svc, revertRepoName, revertRepoID, useGitContext)
+		if err != nil {
+			return err
+		}
+
+		pr, err := svc.GetPullRequest(ctx, revertPRID)
+		if err != nil {
+			return fmt.Errorf("failed to get PR #%d: %w", revertPRID, err)
+		}
+
+		if err = validatePRRepo(pr, repoID, revertPRID); err != nil {
+			return err
+		}
+
+		if pr.Status == nil || adogit.PullRequestStatus(*pr.Status) != adogit.PullRequestStatusValues.Completed {
+			return fmt.Errorf("PR #%d is not completed; only completed PRs can be reverted", revertPRID)
+		}
+
+		title := ""
+		if pr.Title != nil {
+			title = *pr.Title
+		}
+		target := ""
+		if pr.TargetRefName != nil {
+			target = strings.TrimPrefix(*pr.TargetRefName, "refs/heads/")
+		}
+
+		details := map[string]string{
+			"PR":         fmt.Sprintf("#%d", revertPRID),
+			"Title":      title,
+			"Repository": repoName,
+			"Onto":       fmt.Sprintf("%s (as %s)", target, devops.RevertBranchName(revertPRID)),
+		}
+
+		if IsDryRun() {
+			PrintDryRunAction("revert pull request", details)
+			return nil
+		}
+
+		revertCtx, revertCancel, err := ConfirmAndGetContext("revert this pull request", details)
+		if err != nil {
+			return err
+		}
+		defer revertCancel()
+
+		spinner := progress.NewSpinner(fmt.Sprintf("Reverting PR #%d...", revertPRID))
+		spinner.Start()
+		result := svc.RevertPullRequest(revertCtx, pr)
+		spinner.Stop()
+
+		return printRefOperationResults("Revert", []devops.RefOperationResult{result})
+	},
+}
+
+func printRefOperationResults(operation string, results []devops.RefOperationResult) error {
+	failed := 0
+	for _, result := range results {
+		if result.Error != nil {
+			failed++
+			fmt.Printf("✗ %s onto %s: %v\n", operation, result.OntoBranch, result.Error)
+			continue
+		}
+
+		fmt.Printf("✓ %s onto %s: PR #%d (%s)\n", operation, result.OntoBranch, result.PullRequest.ID, result.GeneratedBranch)
+		if result.PullRequest.URL != "" {
+			fmt.Printf("  URL: %s\n", result.PullRequest.URL)
+		}
+	}
+
+	logger.Info().Int("succeeded", len(results)-failed).Int("failed", failed).Msgf("%s finished", operation)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d %s operation(s) failed", failed, len(results), strings.ToLower(operation))
+	}
+	return nil
+}
+
+func init() {
+	cherryPickCmd.Flags().StringVar(&cherryPickRepoName, "repository-name", "", "Repository name (auto-detected from git if not specified)")
+	cherryPickCmd.Flags().StringVar(&cherryPickRepoID, "repo-id", "", "Repository ID (alternative to --repository-name)")
+	cherryPickCmd.Flags().IntVar(&cherryPickPRID, "pr", 0, "Pull request ID to cherry-pick")
+	cherryPickCmd.Flags().StringArrayVar(&cherryPickOnto, "onto", []string{}, "Branch to cherry-pick onto (can specify multiple)")
+	cherryPickCmd.Flags().BoolVar(&cherryPickUseGitContext, "use-git-context", true, "Use git context for auto-detection when in a git repository")
+	cherryPickCmd.Flags().BoolVar(&cherryPickNoGitContext, "no-git-context", false, "Disable git context auto-detection")
+
+	if err := cherryPickCmd.MarkFlagRequired("pr"); err != nil {
+		panic(err)
+	}
+	if err := cherryPickCmd.MarkFlagRequired("onto"); err != nil {
+		panic(err)
+	}
+	cherryPickCmd.MarkFlagsMutuallyExclusive("repository-name", "repo-id")
+	cherryPickCmd.MarkFlagsMutuallyExclusive("use-git-context", "no-git-context")
+
+	revertCmd.Flags().StringVar(&revertRepoName, "repository-name", "", "Repository name (auto-detected from git if not specified)")
+	revertCmd.Flags().StringVar(&revertRepoID, "repo-id", "", "Repository ID (alternative to --repository-name)")
+	revertCmd.Flags().IntVar(&revertPRID, "pr", 0, "Pull request ID to revert")
+	revertCmd.Flags().BoolVar(&revertUseGitContext, "use-git-context", true, "Use git context for auto-detection when in a git repository")
+	revertCmd.Flags().BoolVar(&revertNoGitContext, "no-git-context", false, "Disable git context auto-detection")
+
+	if err := revertCmd.MarkFlagRequired("pr"); err != nil {
+		panic(err)
+	}
+	revertCmd.MarkFlagsMutuallyExclusive("repository-name", "repo-id")
+	revertCmd.MarkFlagsMutuallyExclusive("use-git-context", "no-git-context")
+}
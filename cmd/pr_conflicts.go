@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"adoctl/pkg/devops"
+	"adoctl/pkg/git"
+	"adoctl/pkg/logger"
+	"adoctl/pkg/progress"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	conflictsRepoName      string
+	conflictsRepoID        string
+	conflictsPRID          int
+	conflictsLocal         bool
+	conflictsUseGitContext bool
+	conflictsNoGitContext  bool
+)
+
+// ConflictsOutput is the structured output of pr conflicts
+type ConflictsOutput struct {
+	PullRequestID int                 `json:"pullRequestId" yaml:"pullRequestId"`
+	SourceBranch  string              `json:"sourceBranch" yaml:"sourceBranch"`
+	TargetBranch  string              `json:"targetBranch" yaml:"targetBranch"`
+	MergeStatus   string              `json:"mergeStatus" yaml:"mergeStatus"`
+	Conflicts     []devops.PRConflict `json:"conflicts" yaml:"conflicts"`
+	Local         []git.LocalConflict `json:"local,omitempty" yaml:"local,omitempty"`
+}
+
+var conflictsCmd = &cobra.Command{
+	Use:   "conflicts",
+	Short: "Show the merge conflicts of a pull request",
+	Long: `List the files that conflict when merging a pull request, with the conflict type
+(source/target, e.g. edit/edit or delete/edit) and which side changed what.
+
+With --local, the merge is reproduced in a temporary worktree of the current
+repository, using the branches on origin, and the conflict markers of each file
+are shown. The current checkout is not touched.`,
+	Example: `  # List conflicting files of PR #123
+  adoctl pr conflicts --pr 123
+
+  # Also show the conflict markers from a local merge
+  adoctl pr conflicts --pr 123 --local
+
+  # Output as JSON
+  adoctl pr conflicts --pr 123 --format json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		if conflictsLocal && !git.IsGitRepository() {
+			return fmt.Errorf("--local must be run inside a git repository")
+		}
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		// Determine if we should use git context
+		useGitContext := conflictsUseGitContext && !conflictsNoGitContext && git.IsGitRepository()
+
+		repoID, _, err := ResolveRepoID(svc, conflictsRepoName, conflictsRepoID, useGitContext)
+		if err != nil {
+			return err
+		}
+
+		pr, err := svc.GetPullRequest(ctx, conflictsPRID)
+		if err != nil {
+			return fmt.Errorf("failed to get PR #%d: %w", conflictsPRID, err)
+		}
+
+		if err = validatePRRepo(pr, repoID, conflictsPRID); err != nil {
+			return err
+		}
+
+		output := ConflictsOutput{PullRequestID: conflictsPRID}
+		if pr.SourceRefName != nil {
+			output.SourceBranch = strings.TrimPrefix(*pr.SourceRefName, "refs/heads/")
+		}
+		if pr.TargetRefName != nil {
+			output.TargetBranch = strings.TrimPrefix(*pr.TargetRefName, "refs/heads/")
+		}
+		if pr.MergeStatus != nil {
+			output.MergeStatus = string(*pr.MergeStatus)
+		}
+
+		output.Conflicts, err = svc.GetPullRequestConflicts(ctx, repoID, conflictsPRID)
+		if err != nil {
+			return err
+		}
+
+		if conflictsLocal {
+			spinner := progress.NewSpinner(fmt.Sprintf("Merging %s into %s locally...", output.SourceBranch, output.TargetBranch))
+			spinner.Start()
+			if fetchErr := git.Fetch(""); fetchErr != nil {
+				logger.Warn().Err(fetchErr).Msg("Failed to fetch, using local refs")
+			}
+			output.Local, err = git.ReproduceMerge("origin/"+output.SourceBranch, "origin/"+output.TargetBranch)
+			spinner.Stop()
+			if err != nil {
+				return err
+			}
+		}
+
+		writer := NewOutputWriter(cmd.Flag("format").Value.String())
+		if writer.IsStructured() {
+			return writer.Write(output)
+		}
+
+		printConflicts(output)
+		return nil
+	},
+}
+
+func printConflicts(output ConflictsOutput) {
+	fmt.Printf("PR #%d: %s → %s (merge status: %s)\n\n", output.PullRequestID, output.SourceBranch, output.TargetBranch, output.MergeStatus)
+
+	if len(output.Conflicts) == 0 {
+		fmt.Println("✓ No unresolved conflicts reported by Azure DevOps")
+	} else {
+		fmt.Printf("%d conflicting file(s):\n", len(output.Conflicts))
+		pathWidth := 0
+		for _, c := range output.Conflicts {
+			pathWidth = max(pathWidth, len(c.Path))
+		}
+		for _, c := range output.Conflicts {
+			fmt.Printf("  %-*s  %-14s %s\n", pathWidth, c.Path, c.Type, c.Side)
+		}
+	}
+
+	if !conflictsLocal {
+		return
+	}
+
+	fmt.Println()
+	if len(output.Local) == 0 {
+		fmt.Println("✓ Local merge completed without conflicts")
+		return
+	}
+
+	fmt.Printf("Local merge of origin/%s into origin/%s:\n", output.SourceBranch, output.TargetBranch)
+	for _, c := range output.Local {
+		fmt.Printf("\n── %s ──\n", c.Path)
+		if len(c.Hunks) == 0 {
+			fmt.Println("  (no conflict markers; the file was deleted or renamed)")
+			continue
+		}
+		fmt.Println(strings.Join(c.Hunks, "\n...\n"))
+	}
+}
+
+func init() {
+	conflictsCmd.Flags().StringVar(&conflictsRepoName, "repository-name", "", "Repository name (auto-detected from git if not specified)")
+	conflictsCmd.Flags().StringVar(&conflictsRepoID, "repo-id", "", "Repository ID (alternative to --repository-name)")
+	conflictsCmd.Flags().IntVar(&conflictsPRID, "pr", 0, "Pull request ID")
+	conflictsCmd.Flags().BoolVar(&conflictsLocal, "local", false, "Reproduce the merge in a temporary worktree and show the conflict markers")
+	conflictsCmd.Flags().BoolVar(&conflictsUseGitContext, "use-git-context", true, "Use git context for auto-detection when in a git repository")
+	conflictsCmd.Flags().BoolVar(&conflictsNoGitContext, "no-git-context", false, "Disable git context auto-detection")
+
+	if err := conflictsCmd.MarkFlagRequired("pr"); err != nil {
+		panic(err)
+	}
+	conflictsCmd.MarkFlagsMutuallyExclusive("repository-name", "repo-id")
+	conflictsCmd.MarkFlagsMutuallyExclusive("use-git-context", "no-git-context")
+}
@@ -1,12 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"adoctl/pkg/devops"
+	"adoctl/pkg/errors"
 	"adoctl/pkg/git"
 	"adoctl/pkg/logger"
+	"adoctl/pkg/utils"
 
 	"github.com/spf13/cobra"
 )
@@ -22,6 +26,9 @@ var (
 	createWorkItemIDs   []string
 	createUseGitContext bool
 	createNoGitContext  bool
+	createGenerateDesc  bool
+	createNoEdit        bool
+	createNoTemplate    bool
 )
 
 var createCmd = &cobra.Command{
@@ -31,7 +38,15 @@ var createCmd = &cobra.Command{
 
 When run from within a git repository with an Azure DevOps remote, this command can
 auto-detect the repository, source branch, and target branch. It can also extract
-work item IDs from branch names like "feature/PBI-12345".`,
+work item IDs from branch names like "feature/PBI-12345".
+
+Unless --description is given, the description is taken from the repository's PR
+template (.azuredevops/pull_request_template.md, the per-branch variants under
+pull_request_template/branches/, and the .vsts, docs and root locations).
+With --generate-description, the template is filled with the commits of the source
+branch since its merge base with the target branch and the titles of the linked
+work items, then opened in $EDITOR. It needs git context, so it cannot be combined
+with --no-git-context or used outside a git repository.`,
 	Example: `  # Create a PR with explicit settings
   adoctl pr create --repository-name myrepo --source-branch feature --target-branch main --title "My feature"
 
@@ -46,6 +61,9 @@ work item IDs from branch names like "feature/PBI-12345".`,
   # Create a PR and link work items (auto-extracted from branch like feature/PBI-123)
   adoctl pr create --title "Fix issue"
 
+  # Generate the description from commits and work items, then edit it
+  adoctl pr create --title "My feature" --generate-description
+
   # Create a PR with explicit work items
   adoctl pr create --repository-name myrepo --source-branch feature --target-branch main \
     --title "Fix bug 123" --description "This PR fixes bug 123" --work-item-id 123`,
@@ -61,6 +79,9 @@ work item IDs from branch names like "feature/PBI-12345".`,
 
 		// Determine if we should use git context
 		useGitContext := createUseGitContext && !createNoGitContext && git.IsGitRepository()
+		if createGenerateDesc && !useGitContext {
+			return errors.ValidationError("--generate-description needs git context: run it in a git repository without --no-git-context")
+		}
 
 		// Resolve repository
 		repoID, repoName, err := ResolveRepoID(svc, createRepoName, createRepoID, useGitContext)
@@ -100,13 +121,25 @@ work item IDs from branch names like "feature/PBI-12345".`,
 			logger.Info().Strs("workItemIDs", workItemIDs).Msg("Auto-linked work items from branch name")
 		}
 
+		description := createDescription
+		if description == "" && useGitContext {
+			description, err = resolvePRDescription(ctx, svc, sourceBranch, targetBranch, workItemIDs)
+			if err != nil {
+				return err
+			}
+		}
+
 		logger.Debug().
 			Str("sourceBranch", sourceBranch).
 			Str("targetBranch", targetBranch).
 			Str("title", title).
 			Msg("Creating PR")
 
-		result, err := svc.CreatePullRequest(ctx, repoID, sourceBranch, targetBranch, title, createDescription, createReviewers, workItemIDs, true)
+		// Editing the description may outlast the request timeout, so start a fresh one
+		createCtx, createCancel := GetContext()
+		defer createCancel()
+
+		result, err := svc.CreatePullRequest(createCtx, repoID, sourceBranch, targetBranch, title, description, createReviewers, workItemIDs, true)
 		if err != nil {
 			return fmt.Errorf("error creating PR: %w", err)
 		}
@@ -153,6 +186,80 @@ work item IDs from branch names like "feature/PBI-12345".`,
 	},
 }
 
+// resolvePRDescription builds the PR description from the repository template and,
+// with --generate-description, from the commits of the source branch and linked work items.
+func resolvePRDescription(ctx context.Context, svc *devops.DevOpsService, sourceBranch, targetBranch string, workItemIDs []string) (string, error) {
+	template := ""
+	if !createNoTemplate {
+		var err error
+		template, err = git.LoadPRTemplate(targetBranch)
+		if err != nil {
+			logger.Warn().Err(err).Msg("Failed to load PR template")
+		} else if template != "" {
+			logger.Debug().Str("targetBranch", targetBranch).Msg("Using PR template")
+		}
+	}
+
+	if !createGenerateDesc {
+		return template, nil
+	}
+
+	// The source branch may only exist on the remote when it is not checked out
+	head := sourceBranch
+	if !git.BranchExists(head) {
+		head = "origin/" + sourceBranch
+	}
+
+	commits := []git.Commit{}
+	base, err := git.GetMergeBase("origin/"+targetBranch, head)
+	if err != nil {
+		base, err = git.GetMergeBase(targetBranch, head)
+	}
+	if err != nil {
+		if current, _ := git.GetCurrentBranch(); current == sourceBranch {
+			logger.Warn().Err(err).Msg("Could not find merge base, using recent commits")
+			commits, _ = git.GetRecentCommits(10)
+		} else {
+			logger.Warn().Err(err).Str("sourceBranch", sourceBranch).Msg("Could not find merge base, leaving out commits")
+		}
+	} else {
+		commits, err = git.GetCommitsSince(base, head)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	workItems := []string{}
+	ids := []int{}
+	for _, id := range workItemIDs {
+		if n, convErr := strconv.Atoi(id); convErr == nil {
+			ids = append(ids, n)
+		}
+	}
+	if len(ids) > 0 {
+		items, wiErr := svc.Client().GetWorkItems(ctx, ids)
+		if wiErr != nil {
+			logger.Warn().Err(wiErr).Msg("Failed to get work item titles")
+		}
+		for _, item := range items {
+			fields, _ := item["fields"].(map[string]any)
+			title, _ := fields["System.Title"].(string)
+			workItems = append(workItems, fmt.Sprintf("#%d %s", utils.ToInt(item["id"]), title))
+		}
+	}
+
+	description := git.GenerateDescription(template, commits, workItems)
+	if createNoEdit {
+		return description, nil
+	}
+
+	edited, err := EditInEditor(description, "adoctl-pr-*.md")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(edited), nil
+}
+
 func init() {
 	createCmd.Flags().StringVar(&createRepoName, "repository-name", "", "Repository name (auto-detected from git if not specified)")
 	createCmd.Flags().StringVar(&createRepoID, "repo-id", "", "Repository ID (alternative to --repository-name)")
@@ -165,6 +272,11 @@ func init() {
 	createCmd.Flags().BoolVar(&createUseGitContext, "use-git-context", true, "Use git context for auto-detection when in a git repository")
 	createCmd.Flags().BoolVar(&createNoGitContext, "no-git-context", false, "Disable git context auto-detection")
 
+	createCmd.Flags().BoolVar(&createGenerateDesc, "generate-description", false, "Generate the description from commits since the merge base and linked work items")
+	createCmd.Flags().BoolVar(&createNoEdit, "no-edit", false, "Do not open $EDITOR for the generated description")
+	createCmd.Flags().BoolVar(&createNoTemplate, "no-template", false, "Do not use the repository PR template")
+
 	createCmd.MarkFlagsMutuallyExclusive("repository-name", "repo-id")
+	createCmd.MarkFlagsMutuallyExclusive("description", "generate-description")
 	createCmd.MarkFlagsMutuallyExclusive("use-git-context", "no-git-context")
 }
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"adoctl/pkg/devops"
+	"adoctl/pkg/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	inboxIncludeMine bool
+)
+
+// InboxEntryOutput represents a reviewer inbox entry for structured output
+type InboxEntryOutput struct {
+	ID           int      `json:"id" yaml:"id"`
+	Title        string   `json:"title" yaml:"title"`
+	Repository   string   `json:"repository" yaml:"repository"`
+	Author       string   `json:"author" yaml:"author"`
+	URL          string   `json:"url" yaml:"url"`
+	CreatedAt    string   `json:"createdAt" yaml:"createdAt"`
+	AgeHours     int      `json:"ageHours" yaml:"ageHours"`
+	FilesChanged int      `json:"filesChanged" yaml:"filesChanged"`
+	Commented    bool     `json:"commented" yaml:"commented"`
+	MyVote       string   `json:"myVote" yaml:"myVote"`
+	ViaTeam      string   `json:"viaTeam,omitempty" yaml:"viaTeam,omitempty"`
+	Mine         bool     `json:"mine" yaml:"mine"`
+	WaitingOn    []string `json:"waitingOn,omitempty" yaml:"waitingOn,omitempty"`
+}
+
+var inboxCmd = &cobra.Command{
+	Use:   "inbox",
+	Short: "List pull requests awaiting your review",
+	Long: `List active pull requests across all repositories where you, or a team you
+belong to, are a reviewer that has not voted yet or is waiting for the author.
+
+PRs are grouped by repository and show their age, the number of files changed
+and whether you have already commented.`,
+	Example: `  # Show PRs awaiting my review
+  adoctl pr inbox
+
+  # Also show my own PRs that are waiting on reviewers
+  adoctl pr inbox --include-mine
+
+  # Output as JSON
+  adoctl pr inbox --format json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		inbox, err := svc.GetReviewInbox(ctx, inboxIncludeMine)
+		if err != nil {
+			return fmt.Errorf("error building review inbox: %w", err)
+		}
+
+		logger.Info().Int("count", inbox.Count()).Msg("Found pull requests awaiting review")
+
+		output := NewOutputWriter(cmd.Flag("format").Value.String())
+		if output.IsStructured() {
+			entries := []InboxEntryOutput{}
+			for _, repo := range inbox.Repositories() {
+				for _, entry := range inbox.Groups[repo] {
+					entries = append(entries, mapToInboxEntryOutput(entry))
+				}
+			}
+			return output.Write(entries)
+		}
+
+		var plainBuilder strings.Builder
+		var markdownBuilder strings.Builder
+
+		fmt.Fprintf(&plainBuilder, "📥 Review inbox for %s (%d PRs)\n", inbox.User.DisplayName, inbox.Count())
+		markdownBuilder.WriteString("**Review Inbox**\n\n")
+
+		for _, repo := range inbox.Repositories() {
+			fmt.Fprintf(&plainBuilder, "\n%s\n", repo)
+			fmt.Fprintf(&markdownBuilder, "**%s**\n", repo)
+
+			for _, entry := range inbox.Groups[repo] {
+				details := formatInboxDetails(entry)
+				fmt.Fprintf(&plainBuilder, "  #%-6d %s\n", entry.PR.ID, entry.PR.Title)
+				fmt.Fprintf(&plainBuilder, "          %s\n", details)
+				fmt.Fprintf(&markdownBuilder, "- %s %s (%s)\n",
+					GenerateMarkdownLink(entry.PR.URL, fmt.Sprintf("PR #%d", entry.PR.ID)), entry.PR.Title, details)
+			}
+			markdownBuilder.WriteString("\n")
+		}
+
+		return OutputWithCopy(cmd.OutOrStdout(), plainBuilder.String(), markdownBuilder.String(), ShouldCopyOutput(cmd))
+	},
+}
+
+func formatInboxDetails(entry devops.InboxEntry) string {
+	parts := []string{
+		formatAge(entry.Age()),
+		fmt.Sprintf("%d files", entry.FilesChanged),
+	}
+
+	if entry.IsMine {
+		parts = append(parts, "mine, waiting on "+strings.Join(entry.WaitingOn, ", "))
+	} else {
+		parts = append(parts, "by "+entry.PR.CreatedBy.DisplayName)
+		if entry.ViaTeam != "" {
+			parts = append(parts, "via "+entry.ViaTeam)
+		}
+		if entry.MyVote != 0 {
+			parts = append(parts, formatVote(&entry.MyVote))
+		}
+	}
+
+	if entry.HasCommented {
+		parts = append(parts, "💬 commented")
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// formatAge renders a duration as a compact age such as 3d, 5h or 12m
+func formatAge(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+}
+
+func mapToInboxEntryOutput(entry devops.InboxEntry) InboxEntryOutput {
+	createdAt := ""
+	if !entry.CreatedAt.IsZero() {
+		createdAt = entry.CreatedAt.Format(time.RFC3339)
+	}
+
+	return InboxEntryOutput{
+		ID:           entry.PR.ID,
+		Title:        entry.PR.Title,
+		Repository:   entry.PR.Repository.Name,
+		Author:       entry.PR.CreatedBy.DisplayName,
+		URL:          entry.PR.URL,
+		CreatedAt:    createdAt,
+		AgeHours:     int(entry.Age().Hours()),
+		FilesChanged: entry.FilesChanged,
+		Commented:    entry.HasCommented,
+		MyVote:       formatVote(&entry.MyVote),
+		ViaTeam:      entry.ViaTeam,
+		Mine:         entry.IsMine,
+		WaitingOn:    entry.WaitingOn,
+	}
+}
+
+func init() {
+	inboxCmd.Flags().BoolVar(&inboxIncludeMine, "include-mine", false, "Also show my own PRs that are waiting on reviewers")
+}
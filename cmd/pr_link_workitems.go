@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"adoctl/pkg/devops"
 	"adoctl/pkg/logger"
@@ -13,12 +15,15 @@ import (
 var (
 	linkPRIDs       []string
 	linkWorkItemIDs []string
+	linkQuery       string
 )
 
 var linkWorkItemsCmd = &cobra.Command{
 	Use:   "link-workitems",
 	Short: "Link work items to pull requests",
-	Long:  `Link multiple work items to one or more existing pull requests.`,
+	Long: `Link multiple work items to one or more existing pull requests.
+
+PRs can be given by ID or selected with a query expression (see 'adoctl pr list --help').`,
 	Example: `  # Link a single work item to a PR
   adoctl pr link-workitems --pr-id 123 --work-item-id 456
 
@@ -26,10 +31,13 @@ var linkWorkItemsCmd = &cobra.Command{
   adoctl pr link-workitems --pr-id 123 --work-item-id 456 --work-item-id 789
 
   # Link work items to multiple PRs
-  adoctl pr link-workitems --pr-id 123 --pr-id 456 --work-item-id 789`,
+  adoctl pr link-workitems --pr-id 123 --pr-id 456 --work-item-id 789
+
+  # Link a work item to every active PR from a release branch
+  adoctl pr link-workitems --query 'status:active source:/^release\//' --work-item-id 789`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if len(linkPRIDs) == 0 {
-			return fmt.Errorf("at least one PR ID must be specified")
+		if len(linkPRIDs) == 0 && linkQuery == "" {
+			return fmt.Errorf("at least one PR ID or a query must be specified")
 		}
 		if len(linkWorkItemIDs) == 0 {
 			return fmt.Errorf("at least one work item ID must be specified")
@@ -51,6 +59,41 @@ var linkWorkItemsCmd = &cobra.Command{
 
 		prRepoMap := buildPRRepoMap(prs)
 
+		if linkQuery != "" {
+			query, err := parsePRQuery(ctx, svc, linkQuery)
+			if err != nil {
+				return err
+			}
+
+			matched := []string{}
+			for _, pr := range prs {
+				if query.Match(pr) {
+					matched = append(matched, strconv.Itoa(pr.ID))
+				}
+			}
+			if len(matched) == 0 {
+				fmt.Println("No pull requests match the query.")
+				return nil
+			}
+
+			details := map[string]string{
+				"Query":      linkQuery,
+				"PRs":        strings.Join(matched, ", "),
+				"Work items": strings.Join(linkWorkItemIDs, ", "),
+			}
+
+			if IsDryRun() {
+				PrintDryRunAction("link work items to pull requests", details)
+				return nil
+			}
+
+			if err = RequireConfirmation(fmt.Sprintf("link work items to %d pull request(s)", len(matched)), details); err != nil {
+				return err
+			}
+
+			linkPRIDs = append(linkPRIDs, matched...)
+		}
+
 		successCount, failureCount := linkWorkItemsToPRs(svc, prRepoMap)
 
 		printLinkWorkItemsSummary(successCount, failureCount)
@@ -112,4 +155,5 @@ func printLinkWorkItemsSummary(successCount, failureCount int) {
 func init() {
 	linkWorkItemsCmd.Flags().StringArrayVar(&linkPRIDs, "pr-id", []string{}, "Pull request IDs to link (can be specified multiple times)")
 	linkWorkItemsCmd.Flags().StringArrayVar(&linkWorkItemIDs, "work-item-id", []string{}, "Work item IDs to link (can be specified multiple times)")
+	linkWorkItemsCmd.Flags().StringVar(&linkQuery, "query", "", "Select PRs with a query expression or saved query name")
 }
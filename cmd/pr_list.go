@@ -19,6 +19,7 @@ var (
 	listCreator       string
 	listTitleFuzzy    string
 	listRepoFuzzy     string
+	listQuery         string
 	listCurrentBranch bool
 	listUseGitContext bool
 	listNoGitContext  bool
@@ -29,6 +30,11 @@ var listCmd = &cobra.Command{
 	Short: "List pull requests",
 	Long: `List pull requests with optional filtering by repository, status, branch, and creator.
 
+The --query flag accepts a query expression such as
+'status:active author:me target:main title~"login" repo:/^api-/ age>7d', or the
+name of a query saved with 'adoctl config queries set'. A status in the query
+takes precedence over --status.
+
 When run from within a git repository with an Azure DevOps remote, this command can
 auto-detect the repository and filter by the current branch.`,
 	Example: `  # List active PRs (default)
@@ -56,7 +62,13 @@ auto-detect the repository and filter by the current branch.`,
   adoctl pr list --status active --title-fuzzy "login"
 
   # List PRs for repos matching fuzzy pattern
-  adoctl pr list --repo-fuzzy "api"`,
+  adoctl pr list --repo-fuzzy "api"
+
+  # List my PRs older than a week that have conflicts
+  adoctl pr list --query 'author:me age>7d conflicts:true'
+
+  # List PRs awaiting a team's review using a regex on the repository
+  adoctl pr list --query 'reviewer:@team-x repo:/^api-/ draft:false'`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := GetContext()
 		defer cancel()
@@ -97,6 +109,11 @@ auto-detect the repository and filter by the current branch.`,
 			return err
 		}
 
+		query, err := parsePRQuery(ctx, svc, listQuery)
+		if err != nil {
+			return err
+		}
+
 		prFilter := &filter.PRFilter{
 			TitleFuzzy:   listTitleFuzzy,
 			RepoFuzzy:    listRepoFuzzy,
@@ -104,6 +121,7 @@ auto-detect the repository and filter by the current branch.`,
 			TargetBranch: listTargetBranch,
 			Status:       listStatus,
 			CreatorID:    creatorID,
+			Query:        query,
 		}
 
 		prs, err := svc.ListPullRequestsWithFilter(ctx, repoID, prFilter)
@@ -138,6 +156,7 @@ func init() {
 	listCmd.Flags().StringVar(&listCreator, "creator", "", "Filter by creator (use 'self', name, or ID)")
 	listCmd.Flags().StringVar(&listTitleFuzzy, "title-fuzzy", "", "Filter PR title by fuzzy match")
 	listCmd.Flags().StringVar(&listRepoFuzzy, "repo-fuzzy", "", "Filter repository by fuzzy match")
+	listCmd.Flags().StringVar(&listQuery, "query", "", "Filter with a query expression or saved query name (e.g. 'author:me age>7d')")
 	listCmd.Flags().BoolVar(&listCurrentBranch, "current-branch", false, "Filter PRs to only show those from the current git branch")
 	listCmd.Flags().BoolVar(&listUseGitContext, "use-git-context", true, "Use git context for auto-detection when in a git repository")
 	listCmd.Flags().BoolVar(&listNoGitContext, "no-git-context", false, "Disable git context auto-detection")
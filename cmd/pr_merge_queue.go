@@ -0,0 +1,375 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"adoctl/pkg/devops"
+	"adoctl/pkg/logger"
+
+	"github.com/spf13/cobra"
+)
+
+// DefaultMergeQueueState is the state file of pr merge-queue when --state is not set
+const DefaultMergeQueueState = ".adoctl-merge-queue.json"
+
+var (
+	mergeQueuePRIDs        []int
+	mergeQueueQuery        string
+	mergeQueueStrategy     string
+	mergeQueueDeleteSource bool
+	mergeQueueOnFailure    string
+	mergeQueueState        string
+	mergeQueueResume       bool
+	mergeQueueInterval     int
+	mergeQueueMaxWait      time.Duration
+	mergeQueueReport       string
+)
+
+// MergeQueueItemOutput is a merge queue item for structured output
+type MergeQueueItemOutput struct {
+	ID       int    `json:"id" yaml:"id"`
+	Title    string `json:"title" yaml:"title"`
+	Status   string `json:"status" yaml:"status"`
+	Reason   string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Duration string `json:"duration,omitempty" yaml:"duration,omitempty"`
+}
+
+var mergeQueueCmd = &cobra.Command{
+	Use:   "merge-queue",
+	Short: "Merge a set of pull requests one after another once each is green",
+	Long: `Merge pull requests sequentially. For each PR, in order, the queue waits until it
+is approved, its blocking branch policies (including build validation) pass and the
+merge check succeeds, then completes it with the given strategy and waits for the
+completion before moving on, so each PR is validated against the previous merges.
+
+PRs given with --pr keep their order; PRs selected with --query are ordered by
+creation date, oldest first.
+
+When a PR has conflicts, fails a policy, is rejected or does not become ready
+within --max-wait, the queue either pauses (--on-failure pause, the default) or
+skips it and continues (--on-failure skip).
+
+Progress is saved to a state file after every step. An interrupted or paused queue
+continues with --resume; PRs that failed are retried.`,
+	Example: `  # Merge three PRs in order
+  adoctl pr merge-queue --pr 101 --pr 102 --pr 103 --strategy squash
+
+  # Merge every active PR into release/1.4, skipping the ones that fail
+  adoctl pr merge-queue --query 'status:active target:release/1.4' --on-failure skip
+
+  # Preview the queue
+  adoctl pr merge-queue --query 'target:release/1.4' --dry-run
+
+  # Continue after an interruption or a pause
+  adoctl pr merge-queue --resume`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !mergeQueueResume && len(mergeQueuePRIDs) == 0 && mergeQueueQuery == "" {
+			return fmt.Errorf("specify PRs with --pr or --query, or use --resume")
+		}
+		if mergeQueueOnFailure != devops.QueueOnFailurePause && mergeQueueOnFailure != devops.QueueOnFailureSkip {
+			return fmt.Errorf("invalid --on-failure %q: must be pause or skip", mergeQueueOnFailure)
+		}
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		var queue *devops.MergeQueue
+		if mergeQueueResume {
+			queue, err = devops.LoadMergeQueue(mergeQueueState)
+			if err != nil {
+				return err
+			}
+			if requeued := queue.Requeue(); requeued > 0 {
+				fmt.Printf("Retrying %d failed PR(s)\n", requeued)
+			}
+		} else {
+			if _, statErr := os.Stat(mergeQueueState); statErr == nil {
+				return fmt.Errorf("a merge queue is already in progress (%s); use --resume or remove the file", mergeQueueState)
+			}
+			queue, err = buildMergeQueue(svc)
+			if err != nil {
+				return err
+			}
+		}
+
+		if queue.Next() == nil {
+			fmt.Println("Nothing left to merge.")
+			return finishMergeQueue(cmd, queue)
+		}
+
+		details := map[string]string{
+			"Order":      formatQueueOrder(queue),
+			"Strategy":   valueOrDefault(queue.Strategy, "noFastForward"),
+			"On failure": queue.OnFailure,
+			"State file": mergeQueueState,
+		}
+		if queue.DeleteSource {
+			details["Delete Source Branch"] = "yes"
+		}
+
+		if IsDryRun() {
+			PrintDryRunAction("merge pull requests sequentially", details)
+			return nil
+		}
+
+		if err = RequireConfirmation(fmt.Sprintf("merge %d pull request(s)", queue.Count(devops.QueueItemPending)), details); err != nil {
+			return err
+		}
+
+		if err = queue.Save(mergeQueueState); err != nil {
+			return err
+		}
+
+		paused := runMergeQueue(svc, queue)
+
+		if err := queue.Save(mergeQueueState); err != nil {
+			return err
+		}
+
+		if err := finishMergeQueue(cmd, queue); err != nil {
+			return err
+		}
+
+		if paused != nil {
+			return fmt.Errorf("merge queue paused at PR #%d: %s; fix it and run with --resume", paused.PullRequestID, paused.Reason)
+		}
+
+		// Nothing left to resume
+		if err := os.Remove(mergeQueueState); err != nil && !os.IsNotExist(err) {
+			logger.Warn().Err(err).Msg("Failed to remove merge queue state")
+		}
+
+		if skipped := queue.Count(devops.QueueItemSkipped); skipped > 0 {
+			return fmt.Errorf("%d of %d PR(s) were skipped", skipped, len(queue.Items))
+		}
+		return nil
+	},
+}
+
+// buildMergeQueue selects and orders the PRs of a new queue
+func buildMergeQueue(svc *devops.DevOpsService) (*devops.MergeQueue, error) {
+	ctx, cancel := GetContext()
+	defer cancel()
+
+	queue := &devops.MergeQueue{
+		Strategy:     mergeQueueStrategy,
+		DeleteSource: mergeQueueDeleteSource,
+		OnFailure:    mergeQueueOnFailure,
+		CreatedAt:    time.Now(),
+	}
+
+	seen := map[int]bool{}
+	for _, id := range mergeQueuePRIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		pr, err := svc.GetPullRequest(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get PR #%d: %w", id, err)
+		}
+
+		item := devops.MergeQueueItem{PullRequestID: id, Status: devops.QueueItemPending}
+		if pr.Repository != nil && pr.Repository.Id != nil {
+			item.RepositoryID = pr.Repository.Id.String()
+		}
+		if pr.Title != nil {
+			item.Title = *pr.Title
+		}
+		queue.Items = append(queue.Items, item)
+	}
+
+	if mergeQueueQuery != "" {
+		query, err := parsePRQuery(ctx, svc, mergeQueueQuery)
+		if err != nil {
+			return nil, err
+		}
+
+		prs, err := svc.ListPullRequests(ctx, "", "active", "", "", "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list PRs: %w", err)
+		}
+
+		sort.Slice(prs, func(i, j int) bool {
+			return prs[i].CreationDate.Before(prs[j].CreationDate)
+		})
+
+		for _, pr := range prs {
+			if seen[pr.ID] || !query.Match(pr) {
+				continue
+			}
+			seen[pr.ID] = true
+			queue.Items = append(queue.Items, devops.MergeQueueItem{
+				PullRequestID: pr.ID,
+				RepositoryID:  pr.Repository.ID,
+				Title:         pr.Title,
+				Status:        devops.QueueItemPending,
+			})
+		}
+	}
+
+	if len(queue.Items) == 0 {
+		return nil, fmt.Errorf("no pull requests to merge")
+	}
+
+	return queue, nil
+}
+
+// runMergeQueue processes pending items in order. It returns the item the queue
+// paused on, or nil when every item was processed.
+func runMergeQueue(svc *devops.DevOpsService, queue *devops.MergeQueue) *devops.MergeQueueItem {
+	interval := time.Duration(mergeQueueInterval) * time.Second
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	total := len(queue.Items)
+	for item := queue.Next(); item != nil; item = queue.Next() {
+		position := total - queue.Count(devops.QueueItemPending) + 1
+		fmt.Printf("\n[%d/%d] PR #%d: %s\n", position, total, item.PullRequestID, item.Title)
+		item.StartedAt = time.Now()
+
+		lastReason := ""
+		gate, err := svc.WaitForMergeGate(GetContext, item.PullRequestID, interval, mergeQueueMaxWait, func(g devops.MergeGate) {
+			if g.Reason != lastReason {
+				fmt.Printf("  … %s\n", g.Reason)
+				lastReason = g.Reason
+			}
+		})
+
+		switch {
+		case err != nil:
+			item.Status, item.Reason = devops.QueueItemFailed, err.Error()
+		case gate.State == devops.GateMerged:
+			item.Status, item.Reason = devops.QueueItemMerged, "already completed"
+		case gate.State == devops.GateBlocked:
+			item.Status, item.Reason = devops.QueueItemFailed, gate.Reason
+		default:
+			fmt.Println("  ✓ ready, merging")
+			if err := svc.CompleteQueuedPullRequest(GetContext, item, queue, interval, mergeQueueMaxWait); err != nil {
+				item.Status, item.Reason = devops.QueueItemFailed, err.Error()
+			} else {
+				item.Status = devops.QueueItemMerged
+			}
+		}
+		item.FinishedAt = time.Now()
+
+		if item.Status == devops.QueueItemMerged {
+			fmt.Printf("  ✓ merged in %s\n", formatAge(item.FinishedAt.Sub(item.StartedAt)))
+		} else {
+			fmt.Printf("  ✗ %s\n", item.Reason)
+			if queue.OnFailure == devops.QueueOnFailurePause {
+				return item
+			}
+			item.Status = devops.QueueItemSkipped
+		}
+
+		if err := queue.Save(mergeQueueState); err != nil {
+			logger.Warn().Err(err).Msg("Failed to save merge queue state")
+		}
+	}
+
+	return nil
+}
+
+// finishMergeQueue prints the final report, and writes it to --report when set
+func finishMergeQueue(cmd *cobra.Command, queue *devops.MergeQueue) error {
+	writer := NewOutputWriter(cmd.Flag("format").Value.String())
+	if writer.IsStructured() {
+		output := make([]MergeQueueItemOutput, 0, len(queue.Items))
+		for _, item := range queue.Items {
+			output = append(output, mapToMergeQueueItemOutput(item))
+		}
+		return writer.Write(output)
+	}
+
+	report := buildMergeQueueReport(queue)
+	fmt.Println()
+	fmt.Print(report)
+
+	if mergeQueueReport != "" {
+		if err := os.WriteFile(mergeQueueReport, []byte(report), 0600); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Report saved to %s\n", mergeQueueReport)
+	}
+	return nil
+}
+
+func buildMergeQueueReport(queue *devops.MergeQueue) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Merge Queue\n\n")
+	fmt.Fprintf(&sb, "- Merged: %d\n", queue.Count(devops.QueueItemMerged))
+	fmt.Fprintf(&sb, "- Skipped: %d\n", queue.Count(devops.QueueItemSkipped))
+	fmt.Fprintf(&sb, "- Failed: %d\n", queue.Count(devops.QueueItemFailed))
+	fmt.Fprintf(&sb, "- Pending: %d\n\n", queue.Count(devops.QueueItemPending))
+
+	sb.WriteString("| PR | Title | Status | Duration | Reason |\n")
+	sb.WriteString("|----|-------|--------|----------|--------|\n")
+	for _, item := range queue.Items {
+		out := mapToMergeQueueItemOutput(item)
+		fmt.Fprintf(&sb, "| #%d | %s | %s | %s | %s |\n", out.ID, escapeMarkdownCell(out.Title), out.Status, out.Duration, escapeMarkdownCell(out.Reason))
+	}
+
+	return sb.String()
+}
+
+func mapToMergeQueueItemOutput(item devops.MergeQueueItem) MergeQueueItemOutput {
+	output := MergeQueueItemOutput{
+		ID:     item.PullRequestID,
+		Title:  item.Title,
+		Status: item.Status,
+		Reason: item.Reason,
+	}
+	if !item.StartedAt.IsZero() && !item.FinishedAt.IsZero() {
+		output.Duration = formatAge(item.FinishedAt.Sub(item.StartedAt))
+	}
+	return output
+}
+
+func formatQueueOrder(queue *devops.MergeQueue) string {
+	ids := []string{}
+	for _, item := range queue.Items {
+		if item.Status == devops.QueueItemPending {
+			ids = append(ids, "#"+strconv.Itoa(item.PullRequestID))
+		}
+	}
+	return strings.Join(ids, " → ")
+}
+
+func escapeMarkdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
+
+func valueOrDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func init() {
+	mergeQueueCmd.Flags().IntSliceVar(&mergeQueuePRIDs, "pr", []int{}, "Pull request ID to merge, in order (can specify multiple)")
+	mergeQueueCmd.Flags().StringVar(&mergeQueueQuery, "query", "", "Select active PRs with a query expression or saved query name")
+	mergeQueueCmd.Flags().StringVar(&mergeQueueStrategy, "strategy", "", "Merge strategy: noFastForward, squash, rebase, rebaseMerge (default: noFastForward)")
+	mergeQueueCmd.Flags().BoolVar(&mergeQueueDeleteSource, "delete-source", false, "Delete source branches after merge")
+	mergeQueueCmd.Flags().StringVar(&mergeQueueOnFailure, "on-failure", devops.QueueOnFailurePause, "What to do when a PR cannot be merged: pause or skip")
+	mergeQueueCmd.Flags().StringVar(&mergeQueueState, "state", DefaultMergeQueueState, "State file used to resume the queue")
+	mergeQueueCmd.Flags().BoolVar(&mergeQueueResume, "resume", false, "Resume the queue recorded in the state file")
+	mergeQueueCmd.Flags().IntVar(&mergeQueueInterval, "interval", 30, "Polling interval in seconds")
+	mergeQueueCmd.Flags().DurationVar(&mergeQueueMaxWait, "max-wait", 2*time.Hour, "Maximum time to wait for each PR to become ready (0 for no limit)")
+	mergeQueueCmd.Flags().StringVar(&mergeQueueReport, "report", "", "Write the final report to a markdown file")
+
+	mergeQueueCmd.MarkFlagsMutuallyExclusive("resume", "pr")
+	mergeQueueCmd.MarkFlagsMutuallyExclusive("resume", "query")
+}
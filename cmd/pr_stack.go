@@ -0,0 +1,588 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"adoctl/pkg/devops"
+	"adoctl/pkg/git"
+	"adoctl/pkg/logger"
+
+	adogit "github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/spf13/cobra"
+)
+
+var (
+	stackName     string
+	stackBase     string
+	stackRepoName string
+	stackPush     bool
+	stackNoRebase bool
+)
+
+var stackCmd = &cobra.Command{
+	Use:   "stack",
+	Short: "Manage stacks of dependent pull requests",
+	Long: `Manage stacks of dependent branches, such as A → B → C, where each branch
+has a pull request targeting the branch below it.
+
+Stacks are recorded in the repository's .git directory. Commands that take --name
+default to the stack containing the current branch.`,
+}
+
+var stackCreateCmd = &cobra.Command{
+	Use:   "create <branch>...",
+	Short: "Create a stack and its chained pull requests",
+	Long: `Record a stack from branches given bottom to top, and open a pull request for
+each branch targeting the previous one. The bottom branch targets --base.
+
+Existing active PRs between the same branches are reused.`,
+	Example: `  # Stack three branches on main
+  adoctl pr stack create --name login feature/login-api feature/login-ui feature/login-docs
+
+  # Push the branches first, and stack on develop
+  adoctl pr stack create --name login --base develop --push feature/login-api feature/login-ui`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !git.IsGitRepository() {
+			return fmt.Errorf("pr stack must be run inside a git repository")
+		}
+
+		stacks, path, err := loadStacks()
+		if err != nil {
+			return err
+		}
+
+		base := stackBase
+		if base == "" {
+			base, _ = git.GetDefaultBranch()
+		}
+
+		stack := git.Stack{Name: stackName, Base: base}
+		for _, branch := range args {
+			if !git.BranchExists(branch) {
+				return fmt.Errorf("branch '%s' does not exist locally", branch)
+			}
+			stack.Branches = append(stack.Branches, git.StackBranch{Name: branch})
+		}
+
+		if err := stacks.Add(stack); err != nil {
+			return err
+		}
+		recorded := stacks.Get(stackName)
+
+		if IsDryRun() {
+			PrintDryRunAction("create stacked pull requests", map[string]string{
+				"Stack": stackName,
+				"Chain": formatStackChain(recorded),
+			})
+			return nil
+		}
+
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		repoID, _, err := ResolveRepoID(svc, stackRepoName, "", true)
+		if err != nil {
+			return err
+		}
+
+		if stackPush {
+			for _, b := range recorded.Branches {
+				if err := git.Push("", b.Name, false); err != nil {
+					return fmt.Errorf("failed to push %s: %w", b.Name, err)
+				}
+			}
+		}
+
+		for i := range recorded.Branches {
+			branch := &recorded.Branches[i]
+			target := recorded.Parent(i)
+
+			if commit, baseErr := git.GetMergeBase(stackParentRef(recorded, i), branch.Name); baseErr == nil {
+				branch.ParentCommit = commit
+			}
+
+			existing, listErr := svc.ListPullRequests(ctx, repoID, "active", target, branch.Name, "")
+			if listErr == nil && len(existing) > 0 {
+				branch.PullRequestID = existing[0].ID
+				fmt.Printf("✓ %s → %s: using existing PR #%d\n", branch.Name, target, branch.PullRequestID)
+				continue
+			}
+
+			title, _ := git.GetBranchSubject(branch.Name)
+			if title == "" {
+				title = branch.Name
+			}
+
+			workItemIDs := []string{}
+			if id := git.ExtractWorkItemID(branch.Name); id != "" {
+				workItemIDs = append(workItemIDs, id)
+			}
+
+			pr, createErr := svc.CreatePullRequest(ctx, repoID, branch.Name, target, title, stackDescription(recorded, i), nil, workItemIDs, true)
+			if createErr != nil {
+				// Keep the PRs created so far so the stack can be completed later
+				if saveErr := stacks.Save(path); saveErr != nil {
+					logger.Warn().Err(saveErr).Msg("Failed to save stack")
+				}
+				return fmt.Errorf("error creating PR for %s: %w", branch.Name, createErr)
+			}
+
+			branch.PullRequestID = pr.ID
+			fmt.Printf("✓ %s → %s: created PR #%d\n", branch.Name, target, pr.ID)
+			if pr.URL != "" {
+				fmt.Printf("  URL: %s\n", pr.URL)
+			}
+		}
+
+		if err := stacks.Save(path); err != nil {
+			return err
+		}
+
+		logger.Info().Str("stack", stackName).Int("branches", len(recorded.Branches)).Msg("Stack created")
+		return nil
+	},
+}
+
+var stackStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the status of a stack",
+	Long: `Show each branch of a stack with its pull request, whether it targets the
+expected parent, and its pipeline status.`,
+	Example: `  # Status of the stack containing the current branch
+  adoctl pr stack status
+
+  # Status of a named stack
+  adoctl pr stack status --name login`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stacks, _, err := loadStacks()
+		if err != nil {
+			return err
+		}
+
+		stack, err := resolveStack(stacks, stackName)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		fmt.Printf("📚 Stack '%s'\n\n", stack.Name)
+		fmt.Printf("  %s\n", stack.Base)
+
+		prIDs := []int{}
+		for i, branch := range stack.Branches {
+			indent := strings.Repeat("  ", i+1)
+			if branch.PullRequestID == 0 {
+				fmt.Printf("%s└─ %s (no PR)\n", indent, branch.Name)
+				continue
+			}
+
+			prIDs = append(prIDs, branch.PullRequestID)
+			pr, prErr := svc.GetPullRequest(ctx, branch.PullRequestID)
+			if prErr != nil {
+				fmt.Printf("%s└─ %s PR #%d (%v)\n", indent, branch.Name, branch.PullRequestID, prErr)
+				continue
+			}
+
+			fmt.Printf("%s└─ %s PR #%d %s%s\n", indent, branch.Name, branch.PullRequestID,
+				formatPRStatusValue(pr), stackBranchNote(stack, i, pr))
+		}
+		fmt.Println()
+
+		if len(prIDs) == 0 {
+			return nil
+		}
+
+		summaries := collectPRSummaries(svc, prIDs)
+		ordered := make([]*devops.PRSummary, 0, len(summaries))
+		for _, id := range prIDs {
+			for _, s := range summaries {
+				if s.ID == id {
+					ordered = append(ordered, s)
+				}
+			}
+		}
+		printModernTable(ordered)
+
+		return nil
+	},
+}
+
+var stackSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Retarget and restack a stack after changes",
+	Long: `Bring a stack up to date:
+
+  1. Branches whose PR has been completed are removed from the stack
+  2. PRs are retargeted so each one targets the branch below it (or the base)
+  3. Each branch is rebased locally onto its parent, replaying only its own commits
+  4. With --push, rebased branches are pushed with --force-with-lease
+
+A failed rebase is aborted and leaves that branch, and the ones above it, unchanged.`,
+	Example: `  # Sync the stack containing the current branch after its bottom PR merged
+  adoctl pr stack sync --push
+
+  # Preview what sync would do
+  adoctl pr stack sync --name login --dry-run
+
+  # Only retarget PRs, without rebasing local branches
+  adoctl pr stack sync --no-rebase`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stacks, path, err := loadStacks()
+		if err != nil {
+			return err
+		}
+
+		stack, err := resolveStack(stacks, stackName)
+		if err != nil {
+			return err
+		}
+
+		if !stackNoRebase && !IsDryRun() && git.HasUncommittedChanges() {
+			return fmt.Errorf("working tree has uncommitted changes; commit or stash them before syncing")
+		}
+
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		repoID, _, err := ResolveRepoID(svc, stackRepoName, "", true)
+		if err != nil {
+			return err
+		}
+
+		if err := git.Fetch(""); err != nil {
+			logger.Warn().Err(err).Msg("Failed to fetch, using local refs")
+		}
+
+		// Drop branches whose PR has been completed
+		merged := []string{}
+		targets := map[string]string{}
+		for i := 0; i < len(stack.Branches); {
+			branch := stack.Branches[i]
+			if branch.PullRequestID == 0 {
+				i++
+				continue
+			}
+
+			pr, prErr := svc.GetPullRequest(ctx, branch.PullRequestID)
+			if prErr != nil {
+				return fmt.Errorf("failed to get PR #%d: %w", branch.PullRequestID, prErr)
+			}
+
+			if pr.Status != nil && *pr.Status == adogit.PullRequestStatusValues.Completed {
+				merged = append(merged, fmt.Sprintf("%s (PR #%d)", branch.Name, branch.PullRequestID))
+				stack.RemoveBranch(branch.Name)
+				continue
+			}
+			if pr.Status != nil && *pr.Status == adogit.PullRequestStatusValues.Abandoned {
+				logger.Warn().Int("pr_id", branch.PullRequestID).Str("branch", branch.Name).Msg("PR in stack is abandoned")
+			}
+
+			if pr.TargetRefName != nil {
+				targets[branch.Name] = strings.TrimPrefix(*pr.TargetRefName, "refs/heads/")
+			}
+			i++
+		}
+
+		retargets := []int{}
+		for i, branch := range stack.Branches {
+			if branch.PullRequestID != 0 && targets[branch.Name] != stack.Parent(i) {
+				retargets = append(retargets, i)
+			}
+		}
+
+		details := map[string]string{
+			"Stack":  stack.Name,
+			"Merged": formatListOrNone(merged),
+			"Chain":  formatStackChain(stack),
+		}
+		retargetLines := []string{}
+		for _, i := range retargets {
+			retargetLines = append(retargetLines, fmt.Sprintf("PR #%d → %s", stack.Branches[i].PullRequestID, stack.Parent(i)))
+		}
+		details["Retarget"] = formatListOrNone(retargetLines)
+		if !stackNoRebase {
+			details["Rebase"] = "each branch onto its parent"
+		}
+
+		if IsDryRun() {
+			PrintDryRunAction("sync stack", details)
+			return nil
+		}
+
+		if err := RequireConfirmation("sync this stack", details); err != nil {
+			return err
+		}
+
+		originalBranch, _ := git.GetCurrentBranch()
+
+		syncErr := restackBranches(stack)
+
+		if originalBranch != "" && git.BranchExists(originalBranch) {
+			if err := git.Checkout(originalBranch); err != nil {
+				logger.Warn().Err(err).Str("branch", originalBranch).Msg("Failed to switch back to original branch")
+			}
+		}
+
+		if syncErr == nil {
+			for _, i := range retargets {
+				branch := stack.Branches[i]
+				if _, err := svc.RetargetPullRequest(ctx, repoID, branch.PullRequestID, stack.Parent(i)); err != nil {
+					syncErr = err
+					break
+				}
+				fmt.Printf("✓ Retargeted PR #%d to %s\n", branch.PullRequestID, stack.Parent(i))
+			}
+		}
+
+		for _, m := range merged {
+			fmt.Printf("✓ Removed merged %s\n", m)
+		}
+
+		if len(stack.Branches) == 0 {
+			if err := stacks.Remove(stack.Name); err != nil {
+				return err
+			}
+			fmt.Printf("✓ Stack '%s' is fully merged and was removed\n", stack.Name)
+		}
+
+		if err := stacks.Save(path); err != nil {
+			return err
+		}
+
+		return syncErr
+	},
+}
+
+var stackListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recorded stacks",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stacks, _, err := loadStacks()
+		if err != nil {
+			return err
+		}
+
+		if len(stacks.Stacks) == 0 {
+			fmt.Println("No stacks recorded.")
+			fmt.Println("Use 'adoctl pr stack create --name <name> <branch>...' to create one.")
+			return nil
+		}
+
+		for i := range stacks.Stacks {
+			fmt.Printf("%s: %s\n", stacks.Stacks[i].Name, formatStackChain(&stacks.Stacks[i]))
+		}
+		return nil
+	},
+}
+
+var stackDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Forget a stack",
+	Long:  `Remove a recorded stack. Branches and pull requests are left untouched.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stacks, path, err := loadStacks()
+		if err != nil {
+			return err
+		}
+
+		if err := stacks.Remove(stackName); err != nil {
+			return err
+		}
+
+		if err := stacks.Save(path); err != nil {
+			return err
+		}
+
+		fmt.Printf("Stack '%s' removed.\n", stackName)
+		return nil
+	},
+}
+
+// restackBranches rebases each branch of the stack onto its parent, bottom to top,
+// pushing rebased branches when --push is set.
+func restackBranches(stack *git.Stack) error {
+	for i := range stack.Branches {
+		branch := &stack.Branches[i]
+		parentRef := stackParentRef(stack, i)
+
+		parentCommit, err := git.GetCommitHash(parentRef)
+		if err != nil {
+			return err
+		}
+		if branch.ParentCommit == parentCommit {
+			continue
+		}
+
+		if stackNoRebase {
+			continue
+		}
+
+		upstream := branch.ParentCommit
+		if upstream == "" {
+			upstream, err = git.GetMergeBase(parentRef, branch.Name)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := git.RebaseOnto(parentRef, upstream, branch.Name); err != nil {
+			return err
+		}
+		branch.ParentCommit = parentCommit
+		fmt.Printf("✓ Rebased %s onto %s\n", branch.Name, stack.Parent(i))
+
+		if stackPush {
+			if err := git.Push("", branch.Name, true); err != nil {
+				return fmt.Errorf("failed to push %s: %w", branch.Name, err)
+			}
+			fmt.Printf("✓ Pushed %s\n", branch.Name)
+		}
+	}
+
+	return nil
+}
+
+func loadStacks() (*git.Stacks, string, error) {
+	path, err := git.StacksPath()
+	if err != nil {
+		return nil, "", err
+	}
+
+	stacks, err := git.LoadStacks(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return stacks, path, nil
+}
+
+// resolveStack returns the named stack, or the stack containing the current branch
+func resolveStack(stacks *git.Stacks, name string) (*git.Stack, error) {
+	if name != "" {
+		stack := stacks.Get(name)
+		if stack == nil {
+			return nil, fmt.Errorf("stack '%s' not found", name)
+		}
+		return stack, nil
+	}
+
+	branch, err := git.GetCurrentBranch()
+	if err != nil {
+		return nil, err
+	}
+
+	stack := stacks.FindByBranch(branch)
+	if stack == nil {
+		return nil, fmt.Errorf("branch '%s' is not part of a stack; use --name", branch)
+	}
+	return stack, nil
+}
+
+// stackParentRef returns the ref the i-th branch is rebased onto: the remote base
+// branch at the bottom of the stack, the local parent branch above it.
+func stackParentRef(stack *git.Stack, i int) string {
+	if i == 0 {
+		if _, err := git.GetCommitHash("origin/" + stack.Base); err == nil {
+			return "origin/" + stack.Base
+		}
+	}
+	return stack.Parent(i)
+}
+
+func stackDescription(stack *git.Stack, i int) string {
+	lines := []string{
+		fmt.Sprintf("Part %d of %d of stack '%s'.", i+1, len(stack.Branches), stack.Name),
+	}
+	if i > 0 && stack.Branches[i-1].PullRequestID != 0 {
+		lines = append(lines, fmt.Sprintf("Depends on !%d (%s).", stack.Branches[i-1].PullRequestID, stack.Branches[i-1].Name))
+	}
+	return strings.Join(lines, "\n\n")
+}
+
+func stackBranchNote(stack *git.Stack, i int, pr *adogit.GitPullRequest) string {
+	if pr.Status != nil && *pr.Status == adogit.PullRequestStatusValues.Completed {
+		return " (merged, run 'pr stack sync')"
+	}
+	if pr.TargetRefName != nil && strings.TrimPrefix(*pr.TargetRefName, "refs/heads/") != stack.Parent(i) {
+		return fmt.Sprintf(" (targets %s, expected %s: run 'pr stack sync')", strings.TrimPrefix(*pr.TargetRefName, "refs/heads/"), stack.Parent(i))
+	}
+	return ""
+}
+
+func formatPRStatusValue(pr *adogit.GitPullRequest) string {
+	if pr.IsDraft != nil && *pr.IsDraft {
+		return "draft"
+	}
+	if pr.Status == nil {
+		return "unknown"
+	}
+	return string(*pr.Status)
+}
+
+func formatStackChain(stack *git.Stack) string {
+	parts := []string{stack.Base}
+	for _, b := range stack.Branches {
+		if b.PullRequestID != 0 {
+			parts = append(parts, fmt.Sprintf("%s (#%d)", b.Name, b.PullRequestID))
+		} else {
+			parts = append(parts, b.Name)
+		}
+	}
+	return strings.Join(parts, " ← ")
+}
+
+func formatListOrNone(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
+
+func init() {
+	stackCreateCmd.Flags().StringVar(&stackName, "name", "", "Stack name (required)")
+	stackCreateCmd.Flags().StringVar(&stackBase, "base", "", "Branch the bottom of the stack targets (default: repository default branch)")
+	stackCreateCmd.Flags().StringVar(&stackRepoName, "repository-name", "", "Repository name (auto-detected from git if not specified)")
+	stackCreateCmd.Flags().BoolVar(&stackPush, "push", false, "Push the branches before creating the PRs")
+	if err := stackCreateCmd.MarkFlagRequired("name"); err != nil {
+		panic(err)
+	}
+
+	stackStatusCmd.Flags().StringVar(&stackName, "name", "", "Stack name (default: stack of the current branch)")
+
+	stackSyncCmd.Flags().StringVar(&stackName, "name", "", "Stack name (default: stack of the current branch)")
+	stackSyncCmd.Flags().StringVar(&stackRepoName, "repository-name", "", "Repository name (auto-detected from git if not specified)")
+	stackSyncCmd.Flags().BoolVar(&stackPush, "push", false, "Push rebased branches with --force-with-lease")
+	stackSyncCmd.Flags().BoolVar(&stackNoRebase, "no-rebase", false, "Only retarget PRs, do not rebase local branches")
+
+	stackDeleteCmd.Flags().StringVar(&stackName, "name", "", "Stack name (required)")
+	if err := stackDeleteCmd.MarkFlagRequired("name"); err != nil {
+		panic(err)
+	}
+
+	stackCmd.AddCommand(stackCreateCmd)
+	stackCmd.AddCommand(stackStatusCmd)
+	stackCmd.AddCommand(stackSyncCmd)
+	stackCmd.AddCommand(stackListCmd)
+	stackCmd.AddCommand(stackDeleteCmd)
+}
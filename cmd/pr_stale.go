@@ -0,0 +1,387 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"adoctl/pkg/devops"
+	"adoctl/pkg/filter"
+	"adoctl/pkg/logger"
+	"adoctl/pkg/progress"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	staleRepoName   string
+	staleOlderThan  string
+	staleNoActivity string
+	staleNudge      bool
+	staleAbandon    bool
+	staleDraft      bool
+	staleMessage    string
+	staleReport     string
+)
+
+// StalePROutput represents a stale PR for structured output
+type StalePROutput struct {
+	ID          int    `json:"id" yaml:"id"`
+	Title       string `json:"title" yaml:"title"`
+	Repository  string `json:"repository" yaml:"repository"`
+	Author      string `json:"author" yaml:"author"`
+	URL         string `json:"url" yaml:"url"`
+	Draft       bool   `json:"draft" yaml:"draft"`
+	CreatedAt   string `json:"createdAt" yaml:"createdAt"`
+	LastUpdate  string `json:"lastUpdate" yaml:"lastUpdate"`
+	LastCommit  string `json:"lastCommit,omitempty" yaml:"lastCommit,omitempty"`
+	LastComment string `json:"lastComment,omitempty" yaml:"lastComment,omitempty"`
+	IdleDays    int    `json:"idleDays" yaml:"idleDays"`
+}
+
+// staleAction is the outcome of one cleanup action, kept for the summary report
+type staleAction struct {
+	name    string
+	dryRun  bool
+	skipped bool
+	results []devops.StaleActionResult
+}
+
+var staleCmd = &cobra.Command{
+	Use:   "stale",
+	Short: "Find and clean up stale pull requests",
+	Long: `List active pull requests that are older than --older-than and, with
+--no-activity, have had no commits, comments or votes for that long. PRs are sorted
+by last update and show the dates of their last commit and last comment.
+
+Stale PRs can be cleaned up with:
+  --nudge    post a comment mentioning the author (template set with --message)
+  --draft    convert them to drafts
+  --abandon  abandon them (asks for confirmation)
+
+All actions respect --dry-run. Every run writes a markdown summary report.
+
+The --message template supports {author}, {title}, {id}, {idle} and {lastUpdate}.`,
+	Example: `  # List PRs open for more than 30 days
+  adoctl pr stale
+
+  # List PRs older than 30 days without activity in the last 14 days
+  adoctl pr stale --older-than 30d --no-activity 14d
+
+  # Remind authors of stale PRs
+  adoctl pr stale --no-activity 14d --nudge
+
+  # Preview abandoning very old PRs
+  adoctl pr stale --older-than 90d --no-activity 60d --abandon --dry-run
+
+  # Convert stale PRs in one repository to drafts
+  adoctl pr stale --repository-name myrepo --no-activity 21d --draft`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		olderThan, err := filter.ParseAge(staleOlderThan)
+		if err != nil {
+			return fmt.Errorf("invalid --older-than: %w", err)
+		}
+
+		noActivity := time.Duration(0)
+		if staleNoActivity != "" {
+			noActivity, err = filter.ParseAge(staleNoActivity)
+			if err != nil {
+				return fmt.Errorf("invalid --no-activity: %w", err)
+			}
+		}
+
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		repoID := ""
+		if staleRepoName != "" {
+			repoID, _, err = ResolveRepoID(svc, staleRepoName, "", false)
+			if err != nil {
+				return fmt.Errorf("could not determine repository: %w", err)
+			}
+		}
+
+		spinner := progress.NewSpinner("Looking for stale pull requests...")
+		spinner.Start()
+		stale, err := svc.GetStalePullRequests(ctx, repoID, olderThan, noActivity)
+		spinner.Stop()
+		if err != nil {
+			return fmt.Errorf("error finding stale PRs: %w", err)
+		}
+
+		logger.Info().Int("count", len(stale)).Msg("Found stale pull requests")
+
+		now := time.Now()
+		output := NewOutputWriter(cmd.Flag("format").Value.String())
+		if output.IsStructured() {
+			entries := make([]StalePROutput, 0, len(stale))
+			for _, pr := range stale {
+				entries = append(entries, mapToStalePROutput(pr, now))
+			}
+			if err := output.Write(entries); err != nil {
+				return err
+			}
+		} else {
+			printStalePullRequests(stale, now)
+		}
+
+		actions := []staleAction{}
+		if len(stale) > 0 {
+			actions, err = runStaleActions(svc, stale)
+			if err != nil {
+				return err
+			}
+		}
+
+		reportPath := staleReport
+		if reportPath == "" {
+			reportPath = fmt.Sprintf("stale-prs-%s.md", now.Format("20060102-150405"))
+		}
+		report := buildStaleReport(stale, actions, olderThan, noActivity, now)
+		if err := os.WriteFile(reportPath, []byte(report), 0600); err != nil {
+			return fmt.Errorf("error writing report: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Report saved to %s\n", reportPath)
+
+		for _, action := range actions {
+			if failed := countStaleFailures(action.results); failed > 0 {
+				return fmt.Errorf("%d of %d %s action(s) failed", failed, len(action.results), action.name)
+			}
+		}
+
+		return nil
+	},
+}
+
+// runStaleActions applies the requested cleanup actions to the stale PRs
+func runStaleActions(svc *devops.DevOpsService, stale []devops.StalePullRequest) ([]staleAction, error) {
+	ctx, cancel := GetContext()
+	defer cancel()
+
+	ids := make([]string, 0, len(stale))
+	for _, pr := range stale {
+		ids = append(ids, fmt.Sprintf("#%d", pr.PR.ID))
+	}
+	details := map[string]string{
+		"PRs":   strings.Join(ids, ", "),
+		"Count": fmt.Sprintf("%d", len(stale)),
+	}
+
+	actions := []staleAction{}
+
+	if staleNudge {
+		action := staleAction{name: "nudge"}
+		template := staleMessage
+		if template == "" {
+			template = devops.DefaultNudgeTemplate
+		}
+		nudgeDetails := copyDetails(details)
+		nudgeDetails["Message"] = template
+
+		switch {
+		case IsDryRun():
+			PrintDryRunAction("nudge the authors of stale pull requests", nudgeDetails)
+			action.dryRun = true
+		case RequireConfirmation("comment on stale pull requests", nudgeDetails) != nil:
+			action.skipped = true
+		default:
+			action.results = svc.NudgeStalePullRequests(ctx, stale, template)
+			printStaleActionResults("Nudged", action.results)
+		}
+		actions = append(actions, action)
+	}
+
+	if staleDraft {
+		action := staleAction{name: "draft"}
+		switch {
+		case IsDryRun():
+			PrintDryRunAction("convert stale pull requests to drafts", details)
+			action.dryRun = true
+		case RequireConfirmation("convert stale pull requests to drafts", details) != nil:
+			action.skipped = true
+		default:
+			action.results = svc.ConvertStalePullRequestsToDraft(ctx, stale)
+			printStaleActionResults("Converted to draft", action.results)
+		}
+		actions = append(actions, action)
+	}
+
+	if staleAbandon {
+		action := staleAction{name: "abandon", dryRun: IsDryRun()}
+		confirmed, err := ConfirmDestructive("abandon stale pull requests", details)
+		if err != nil {
+			return actions, err
+		}
+		if confirmed {
+			comment := "Abandoned as stale by adoctl."
+			action.results = svc.AbandonStalePullRequests(ctx, stale, comment)
+			printStaleActionResults("Abandoned", action.results)
+		} else if !action.dryRun {
+			action.skipped = true
+		}
+		actions = append(actions, action)
+	}
+
+	for _, action := range actions {
+		if action.skipped {
+			fmt.Printf("Skipped %s: not confirmed\n", action.name)
+		}
+	}
+
+	return actions, nil
+}
+
+func printStalePullRequests(stale []devops.StalePullRequest, now time.Time) {
+	if len(stale) == 0 {
+		fmt.Println("No stale pull requests found.")
+		return
+	}
+
+	fmt.Printf("🕸  %d stale pull request(s)\n\n", len(stale))
+	for _, pr := range stale {
+		draft := ""
+		if pr.PR.IsDraft {
+			draft = " [draft]"
+		}
+		fmt.Printf("#%-6d %s%s\n", pr.PR.ID, pr.PR.Title, draft)
+		fmt.Printf("        %s · by %s\n", pr.PR.Repository.Name, pr.PR.CreatedBy.DisplayName)
+		fmt.Printf("        created %s ago · last update %s · last commit %s · last comment %s\n",
+			formatAge(now.Sub(pr.PR.CreationDate)),
+			formatSince(pr.LastUpdate, now),
+			formatSince(pr.LastCommit, now),
+			formatSince(pr.LastComment, now))
+	}
+	fmt.Println()
+}
+
+func printStaleActionResults(verb string, results []devops.StaleActionResult) {
+	for _, result := range results {
+		if result.Error != nil {
+			fmt.Printf("✗ PR #%d: %v\n", result.PR.ID, result.Error)
+			continue
+		}
+		fmt.Printf("✓ %s PR #%d\n", verb, result.PR.ID)
+	}
+}
+
+// formatSince renders how long ago t was, or "never" for the zero time
+func formatSince(t, now time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return formatAge(now.Sub(t)) + " ago"
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func copyDetails(details map[string]string) map[string]string {
+	result := make(map[string]string, len(details))
+	for k, v := range details {
+		result[k] = v
+	}
+	return result
+}
+
+func countStaleFailures(results []devops.StaleActionResult) int {
+	failed := 0
+	for _, result := range results {
+		if result.Error != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+func mapToStalePROutput(pr devops.StalePullRequest, now time.Time) StalePROutput {
+	return StalePROutput{
+		ID:          pr.PR.ID,
+		Title:       pr.PR.Title,
+		Repository:  pr.PR.Repository.Name,
+		Author:      pr.PR.CreatedBy.DisplayName,
+		URL:         pr.PR.URL,
+		Draft:       pr.PR.IsDraft,
+		CreatedAt:   formatDate(pr.PR.CreationDate),
+		LastUpdate:  formatDate(pr.LastUpdate),
+		LastCommit:  formatDate(pr.LastCommit),
+		LastComment: formatDate(pr.LastComment),
+		IdleDays:    int(pr.IdleFor(now).Hours() / 24),
+	}
+}
+
+// buildStaleReport renders the markdown summary written after every run
+func buildStaleReport(stale []devops.StalePullRequest, actions []staleAction, olderThan, noActivity time.Duration, now time.Time) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Stale Pull Requests\n\n")
+	fmt.Fprintf(&sb, "- Generated: %s\n", now.Format("2006-01-02 15:04"))
+	fmt.Fprintf(&sb, "- Older than: %s\n", formatAge(olderThan))
+	if noActivity > 0 {
+		fmt.Fprintf(&sb, "- No activity for: %s\n", formatAge(noActivity))
+	}
+	fmt.Fprintf(&sb, "- Stale PRs: %d\n\n", len(stale))
+
+	if len(stale) > 0 {
+		sb.WriteString("| PR | Repository | Author | Created | Last update | Last commit | Last comment |\n")
+		sb.WriteString("|----|------------|--------|---------|-------------|-------------|--------------|\n")
+		for _, pr := range stale {
+			fmt.Fprintf(&sb, "| %s %s | %s | %s | %s | %s | %s | %s |\n",
+				GenerateMarkdownLink(pr.PR.URL, fmt.Sprintf("#%d", pr.PR.ID)),
+				strings.ReplaceAll(pr.PR.Title, "|", "\\|"),
+				pr.PR.Repository.Name,
+				pr.PR.CreatedBy.DisplayName,
+				pr.PR.CreationDate.Format("2006-01-02"),
+				formatSince(pr.LastUpdate, now),
+				formatSince(pr.LastCommit, now),
+				formatSince(pr.LastComment, now))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(actions) > 0 {
+		sb.WriteString("## Actions\n\n")
+		for _, action := range actions {
+			switch {
+			case action.dryRun:
+				fmt.Fprintf(&sb, "- %s: dry run, would apply to %d PR(s)\n", action.name, len(stale))
+			case action.skipped:
+				fmt.Fprintf(&sb, "- %s: skipped, not confirmed\n", action.name)
+			default:
+				failed := countStaleFailures(action.results)
+				fmt.Fprintf(&sb, "- %s: %d succeeded, %d failed\n", action.name, len(action.results)-failed, failed)
+				for _, result := range action.results {
+					if result.Error != nil {
+						fmt.Fprintf(&sb, "  - #%d: %v\n", result.PR.ID, result.Error)
+					}
+				}
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+func init() {
+	staleCmd.Flags().StringVar(&staleRepoName, "repository-name", "", "Only look at this repository (default: all repositories)")
+	staleCmd.Flags().StringVar(&staleOlderThan, "older-than", "30d", "Minimum PR age (e.g. 30d, 2w)")
+	staleCmd.Flags().StringVar(&staleNoActivity, "no-activity", "", "Only PRs without commits, comments or votes for this long (e.g. 14d)")
+	staleCmd.Flags().BoolVar(&staleNudge, "nudge", false, "Post a comment mentioning the author of each stale PR")
+	staleCmd.Flags().BoolVar(&staleDraft, "draft", false, "Convert stale PRs to drafts")
+	staleCmd.Flags().BoolVar(&staleAbandon, "abandon", false, "Abandon stale PRs")
+	staleCmd.Flags().StringVar(&staleMessage, "message", "", "Nudge comment template (default: a reminder mentioning the author)")
+	staleCmd.Flags().StringVar(&staleReport, "report", "", "Summary report path (default: stale-prs-<timestamp>.md)")
+
+	staleCmd.MarkFlagsMutuallyExclusive("abandon", "nudge")
+	staleCmd.MarkFlagsMutuallyExclusive("abandon", "draft")
+}
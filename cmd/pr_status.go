@@ -168,6 +168,11 @@ func formatPRStatus(ctx context.Context, svc *devops.DevOpsService, repoID strin
 		fmt.Fprintf(&markdownBuilder, " | Author: %s", author)
 	}
 
+	if pr.HasAutoComplete() {
+		fmt.Fprintf(&plainBuilder, "  Auto-complete: set by %s\n", pr.AutoCompleteSetBy.DisplayName)
+		fmt.Fprintf(&markdownBuilder, " | Auto-complete: %s", pr.AutoCompleteSetBy.DisplayName)
+	}
+
 	// URL
 	if url != "" {
 		fmt.Fprintf(&plainBuilder, "  URL: %s\n", url)
@@ -189,6 +194,27 @@ func formatPRStatus(ctx context.Context, svc *devops.DevOpsService, repoID strin
 		}
 	}
 
+	// Get branch policy evaluations
+	if pr.Repository.Project.ID != "" {
+		policies, err := svc.GetPolicyEvaluations(ctx, pr.Repository.Project.ID, prID)
+		if err != nil {
+			logger.Debug().Err(err).Int("pr_id", prID).Msg("Failed to get policy evaluations")
+		} else if len(policies) > 0 {
+			plainBuilder.WriteString("  Policies:\n")
+			for _, p := range policies {
+				fmt.Fprintf(&plainBuilder, "    - %s\n", formatPolicyLine(p))
+			}
+
+			if blocking := devops.BlockingPolicies(policies); len(blocking) > 0 {
+				names := make([]string, 0, len(blocking))
+				for _, p := range blocking {
+					names = append(names, fmt.Sprintf("%s (%s)", p.Name, devops.FormatPolicyStatus(p.Status)))
+				}
+				fmt.Fprintf(&markdownBuilder, "  **Blocking policies:** %s\n", strings.Join(names, ", "))
+			}
+		}
+	}
+
 	// Get work items
 	workItems, err := svc.Client().GetPullRequestWorkItems(ctx, repoID, prID)
 	if err == nil && len(workItems) > 0 {
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+
+	"adoctl/pkg/devops"
+	"adoctl/pkg/git"
+	"adoctl/pkg/models"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	viewRepoName      string
+	viewRepoID        string
+	viewPRID          int
+	viewUseGitContext bool
+	viewNoGitContext  bool
+)
+
+var viewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "Show details of a pull request",
+	Long: `Show the details of a single pull request, including reviewers, linked work
+items and whether auto-complete is set.`,
+	Example: `  # View PR #123 (auto-detect repository from git)
+  adoctl pr view --pr 123
+
+  # View PR with explicit repository
+  adoctl pr view --repository-name my-repo --pr 123
+
+  # View PR and copy to clipboard
+  adoctl pr view --pr 123 --copy`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		// Determine if we should use git context
+		useGitContext := viewUseGitContext && !viewNoGitContext && git.IsGitRepository()
+
+		pr, err := svc.GetPullRequest(ctx, viewPRID)
+		if err != nil {
+			return fmt.Errorf("failed to get PR #%d: %w", viewPRID, err)
+		}
+
+		repoID := ""
+		if viewRepoName != "" || viewRepoID != "" || useGitContext {
+			repoID, _, err = ResolveRepoID(svc, viewRepoName, viewRepoID, useGitContext)
+			if err != nil {
+				return err
+			}
+			if err = validatePRRepo(pr, repoID, viewPRID); err != nil {
+				return err
+			}
+		}
+
+		modelPR := models.PullRequestFromAzure(pr)
+		if repoID == "" {
+			repoID = modelPR.Repository.ID
+		}
+
+		plain, markdown := formatPRStatus(ctx, svc, repoID, modelPR)
+		fmt.Print(plain)
+
+		if ShouldCopyOutput(cmd) {
+			if err := CopyToClipboard(markdown); err != nil {
+				return fmt.Errorf("failed to copy to clipboard: %w", err)
+			}
+			fmt.Println("✓ Copied to clipboard!")
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	viewCmd.Flags().StringVar(&viewRepoName, "repository-name", "", "Repository name (auto-detected from git if not specified)")
+	viewCmd.Flags().StringVar(&viewRepoID, "repo-id", "", "Repository ID (alternative to --repository-name)")
+	viewCmd.Flags().IntVar(&viewPRID, "pr", 0, "Pull request ID")
+	viewCmd.Flags().BoolVar(&viewUseGitContext, "use-git-context", true, "Use git context for auto-detection when in a git repository")
+	viewCmd.Flags().BoolVar(&viewNoGitContext, "no-git-context", false, "Disable git context auto-detection")
+
+	if err := viewCmd.MarkFlagRequired("pr"); err != nil {
+		panic(err)
+	}
+	viewCmd.MarkFlagsMutuallyExclusive("repository-name", "repo-id")
+	viewCmd.MarkFlagsMutuallyExclusive("use-git-context", "no-git-context")
+}
@@ -9,6 +9,11 @@ func RegisterCommands(root *cobra.Command) {
 	root.AddCommand(prCmd)
 	root.AddCommand(buildCmd)
 	root.AddCommand(deploymentCmd)
+	root.AddCommand(releaseCmd)
+	root.AddCommand(pipelinesCmd)
+	root.AddCommand(varsCmd)
+	root.AddCommand(traceCmd)
+	root.AddCommand(metricsCmd)
 	root.AddCommand(reposCmd)
 	root.AddCommand(workItemCmd)
 	root.AddCommand(reportCmd)
@@ -19,10 +24,19 @@ func RegisterCommands(root *cobra.Command) {
 		createCmd,
 		bulkCreateCmd,
 		listCmd,
+		inboxCmd,
+		staleCmd,
+		conflictsCmd,
+		viewCmd,
 		statusCmd,
 		linkWorkItemsCmd,
 		mergeCmd,
+		mergeQueueCmd,
+		autoCompleteCmd,
 		abandonCmd,
+		cherryPickCmd,
+		revertCmd,
+		stackCmd,
 		approveCmd,
 		pipelineCmd,
 	)
@@ -30,10 +44,43 @@ func RegisterCommands(root *cobra.Command) {
 	buildCmd.AddCommand(
 		syncBuildsCmd,
 		searchBuildsCmd,
+		buildRunCmd,
+		buildWatchCmd,
+		buildLogsCmd,
+		buildCancelCmd,
+		buildRetryCmd,
+		buildRetainCmd,
+		buildTagCmd,
+		buildArtifactsCmd,
+		buildTestsCmd,
+		buildStatsCmd,
 	)
 
 	deploymentCmd.AddCommand(
 		syncDeploymentsCmd,
 		searchDeploymentsCmd,
+		deploymentApprovalsCmd,
+		deploymentApproveCmd,
+		deploymentRejectCmd,
+		deploymentMatrixCmd,
+	)
+
+	metricsCmd.AddCommand(metricsDoraCmd)
+
+	releaseCmd.AddCommand(
+		releaseCreateCmd,
+		releaseDeployCmd,
+	)
+
+	pipelinesCmd.AddCommand(
+		pipelinesListCmd,
+		pipelinesShowCmd,
+		pipelinesPreviewCmd,
+	)
+
+	varsCmd.AddCommand(
+		varsGroupCmd,
+		varsPipelineCmd,
+		varsDiffCmd,
 	)
 }
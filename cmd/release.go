@@ -0,0 +1,14 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Release commands",
+	Long:  `Commands for creating releases and deploying their environments`,
+	Example: `  # Create a release of a definition with a specific build
+  adoctl release create --definition my-app --artifact-version build=1234
+
+  # Redeploy an environment of a release and wait for the result
+  adoctl release deploy --release 567 --environment staging --wait`,
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+
+	"adoctl/pkg/devops"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	releaseCreateDefinition  string
+	releaseCreateArtifacts   []string
+	releaseCreateDescription string
+)
+
+var releaseCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a release",
+	Long: `Create a release of a release definition. Artifact versions are given as
+alias=version, where version is usually a build ID; "build" selects the primary
+artifact. Artifacts without a version use the definition's default version.
+
+Environments with automated triggers start deploying as soon as the release is created.`,
+	Example: `  # Create a release with the default artifact versions
+  adoctl release create --definition my-app
+
+  # Pin the primary build artifact
+  adoctl release create --definition my-app --artifact-version build=1234
+
+  # Pin artifacts by alias, with a description
+  adoctl release create --definition 12 --artifact-version _api=1234 --artifact-version _web=1240 --description "Hotfix"
+
+  # Show what would be created
+  adoctl release create --definition my-app --artifact-version build=1234 --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		versions, err := parseKeyValues("--artifact-version", releaseCreateArtifacts)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		definition, err := svc.ResolveReleaseDefinition(ctx, releaseCreateDefinition)
+		if err != nil {
+			return err
+		}
+
+		artifacts, err := devops.ResolveArtifactVersions(definition, versions)
+		if err != nil {
+			return err
+		}
+
+		details := map[string]string{
+			"Definition": fmt.Sprintf("%s (id %d)", *definition.Name, *definition.Id),
+		}
+		if len(artifacts) > 0 {
+			resolved := make(map[string]string, len(artifacts))
+			for _, a := range artifacts {
+				resolved[*a.Alias] = *a.InstanceReference.Id
+			}
+			details["Artifacts"] = formatKeyValues(resolved)
+		} else {
+			details["Artifacts"] = "definition defaults"
+		}
+		if releaseCreateDescription != "" {
+			details["Description"] = releaseCreateDescription
+		}
+
+		if IsDryRun() {
+			PrintDryRunAction("create a release", details)
+			return nil
+		}
+
+		createCtx, createCancel, err := ConfirmAndGetContext("create a release", details)
+		if err != nil {
+			return err
+		}
+		defer createCancel()
+
+		created, err := svc.CreateRelease(createCtx, *definition.Id, artifacts, releaseCreateDescription)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Created release %s (id %d) of %s\n", *created.Name, *created.Id, *definition.Name)
+		fmt.Printf("  %s\n", svc.ReleaseWebURL(*created.Id))
+		if created.Environments != nil {
+			for _, env := range *created.Environments {
+				status := ""
+				if env.Status != nil {
+					status = string(*env.Status)
+				}
+				fmt.Printf("  %-24s %s\n", *env.Name, status)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	releaseCreateCmd.Flags().StringVar(&releaseCreateDefinition, "definition", "", "Release definition ID or name (required)")
+	releaseCreateCmd.Flags().StringArrayVar(&releaseCreateArtifacts, "artifact-version", nil, "Artifact version as alias=version (repeatable)")
+	releaseCreateCmd.Flags().StringVar(&releaseCreateDescription, "description", "", "Release description")
+
+	if err := releaseCreateCmd.MarkFlagRequired("definition"); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"adoctl/pkg/devops"
+	"adoctl/pkg/errors"
+	"adoctl/pkg/logger"
+	"adoctl/pkg/progress"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/release"
+	"github.com/spf13/cobra"
+)
+
+var (
+	releaseDeployID          int
+	releaseDeployEnvironment string
+	releaseDeployComment     string
+	releaseDeployWait        bool
+	releaseDeployInterval    int
+	releaseDeployWaitTimeout time.Duration
+)
+
+var releaseDeployCmd = &cobra.Command{
+	Use:   "deploy",
+	Short: "Deploy or redeploy an environment of a release",
+	Long: `Start the deployment of a release environment, or redeploy it when it was
+already deployed. The new deployment is saved to the deployments cache.
+
+With --wait the command follows the deployment until it completes, including time
+spent waiting on approvals and gates, and exits with a code mapped from the result:
+0 succeeded, 11 failed or rejected, 12 partially succeeded, 8 canceled. The wait
+gives up after --wait-timeout with exit code 9; the deployment keeps running.`,
+	Example: `  # Deploy a release to staging
+  adoctl release deploy --release 567 --environment staging
+
+  # Redeploy production and wait for the result
+  adoctl release deploy --release 567 --environment production --comment "Redeploy after config fix" --wait
+
+  # Show what would be deployed
+  adoctl release deploy --release 567 --environment production --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if releaseDeployID <= 0 {
+			return errors.ValidationError("--release must be a release ID")
+		}
+
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		rel, err := svc.GetRelease(ctx, releaseDeployID)
+		if err != nil {
+			return err
+		}
+		env, err := devops.FindReleaseEnvironment(rel, releaseDeployEnvironment)
+		if err != nil {
+			return errors.ValidationError(err.Error())
+		}
+
+		status := "notStarted"
+		if env.Status != nil {
+			status = string(*env.Status)
+		}
+		details := map[string]string{
+			"Release":        fmt.Sprintf("%s (id %d)", *rel.Name, *rel.Id),
+			"Environment":    *env.Name,
+			"Current status": status,
+		}
+		if releaseDeployComment != "" {
+			details["Comment"] = releaseDeployComment
+		}
+
+		action := "deploy a release environment"
+		if status != string(release.EnvironmentStatusValues.NotStarted) {
+			action = "redeploy a release environment"
+		}
+
+		if IsDryRun() {
+			PrintDryRunAction(action, details)
+			return nil
+		}
+
+		deployCtx, deployCancel, err := ConfirmAndGetContext(action, details)
+		if err != nil {
+			return err
+		}
+		defer deployCancel()
+
+		if _, err := svc.DeployEnvironment(deployCtx, *rel.Id, *env.Id, releaseDeployComment); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Deployment of %s to %s started\n", *rel.Name, *env.Name)
+		fmt.Printf("  %s\n", svc.ReleaseWebURL(*rel.Id))
+
+		if _, err := svc.SyncLatestDeployment(deployCtx, rel, env); err != nil {
+			logger.Debug().Err(err).Int("release_id", *rel.Id).Msg("Failed to save new deployment")
+		}
+
+		if !releaseDeployWait {
+			return nil
+		}
+
+		return waitForReleaseDeployment(svc, rel, env)
+	},
+}
+
+// waitForReleaseDeployment follows a release environment until its deployment
+// completes, saves the final deployment and maps its status to an error
+func waitForReleaseDeployment(svc *devops.DevOpsService, rel *release.Release, env *release.ReleaseEnvironment) error {
+	interval := time.Duration(releaseDeployInterval) * time.Second
+	if interval <= 0 {
+		interval = 15 * time.Second
+	}
+
+	var (
+		waitCtx context.Context
+		cancel  context.CancelFunc
+	)
+	if releaseDeployWaitTimeout > 0 {
+		waitCtx, cancel = context.WithTimeout(context.Background(), releaseDeployWaitTimeout)
+	} else {
+		waitCtx, cancel = context.WithCancel(context.Background())
+	}
+	defer cancel()
+
+	newCtx := func() (context.Context, context.CancelFunc) {
+		return context.WithTimeout(waitCtx, globalTimeout)
+	}
+
+	spinner := progress.NewSpinner(fmt.Sprintf("Waiting for %s...", *env.Name))
+	spinner.Start()
+	started := time.Now()
+	final, err := svc.WaitForEnvironment(waitCtx, newCtx, *rel.Id, *env.Id, interval, func(e *release.ReleaseEnvironment) {
+		status := "queued"
+		if e.Status != nil {
+			status = string(*e.Status)
+		}
+		spinner.SetMessage(fmt.Sprintf("%s %s (%s)", *env.Name, status, formatAge(time.Since(started))))
+	})
+	spinner.Stop()
+	if err != nil {
+		if waitCtx.Err() == context.DeadlineExceeded {
+			return errors.TimeoutError(fmt.Sprintf("deployment of %s to %s did not finish within %s", *rel.Name, *env.Name, releaseDeployWaitTimeout))
+		}
+		return err
+	}
+
+	ctx, ctxCancel := GetContext()
+	deployment, err := svc.SyncLatestDeployment(ctx, rel, final)
+	ctxCancel()
+	if err != nil {
+		logger.Debug().Err(err).Int("release_id", *rel.Id).Msg("Failed to save deployment")
+	}
+
+	status := string(*final.Status)
+	shown, operation := status, ""
+	if deployment != nil {
+		if deployment.DeploymentStatus != nil {
+			shown = string(*deployment.DeploymentStatus)
+		}
+		if deployment.OperationStatus != nil {
+			operation = string(*deployment.OperationStatus)
+		}
+	}
+	fmt.Printf("Deployment of %s to %s completed: %s\n", *rel.Name, *env.Name, devops.FormatDeploymentStatus(shown, operation))
+
+	if resultErr := errors.DeploymentResultError(*rel.Name, *env.Name, status); resultErr != nil {
+		return resultErr
+	}
+	return nil
+}
+
+func init() {
+	releaseDeployCmd.Flags().IntVar(&releaseDeployID, "release", 0, "Release ID (required)")
+	releaseDeployCmd.Flags().StringVar(&releaseDeployEnvironment, "environment", "", "Environment name (required)")
+	releaseDeployCmd.Flags().StringVar(&releaseDeployComment, "comment", "", "Comment recorded with the deployment")
+	releaseDeployCmd.Flags().BoolVar(&releaseDeployWait, "wait", false, "Wait for the deployment to complete; the exit code follows the result")
+	releaseDeployCmd.Flags().IntVar(&releaseDeployInterval, "interval", 15, "Seconds between status checks with --wait")
+	releaseDeployCmd.Flags().DurationVar(&releaseDeployWaitTimeout, "wait-timeout", 2*time.Hour, "Maximum time to wait with --wait (0 for no limit)")
+
+	for _, flag := range []string{"release", "environment"} {
+		if err := releaseDeployCmd.MarkFlagRequired(flag); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"adoctl/pkg/config"
 	"adoctl/pkg/devops"
 	"adoctl/pkg/filter"
 	"adoctl/pkg/git"
@@ -28,6 +29,7 @@ var (
 	reportTitleFuzzy     string
 	reportRepoRegex      string
 	reportRepoFuzzy      string
+	reportQuery          string
 	reportUseGitContext  bool
 	reportNoGitContext   bool
 )
@@ -60,6 +62,12 @@ var reportCmd = &cobra.Command{
   # Use fuzzy match for title
   adoctl report --title-fuzzy "login bug"
 
+  # Filter with a query expression
+  adoctl report --query 'status:active target:main age>7d conflicts:true'
+
+  # Use a saved query
+  adoctl report --query stale-mine
+
   # Hide requirement warnings
   adoctl report --status active --no-warnings`,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -85,6 +93,11 @@ var reportCmd = &cobra.Command{
 			return err
 		}
 
+		query, err := parsePRQuery(ctx, svc, reportQuery)
+		if err != nil {
+			return err
+		}
+
 		prFilter := &filter.PRFilter{
 			TitleRegex:   reportTitleRegex,
 			TitleFuzzy:   reportTitleFuzzy,
@@ -94,6 +107,7 @@ var reportCmd = &cobra.Command{
 			TargetBranch: reportTargetBranch,
 			Status:       reportStatus,
 			CreatorID:    creatorID,
+			Query:        query,
 		}
 
 		prs, err := svc.ListPullRequestsWithFilter(ctx, repoID, prFilter)
@@ -112,6 +126,29 @@ func resolveCreator(svc *devops.DevOpsService, creator string) (string, error) {
 	return svc.ResolveCreator(creator)
 }
 
+// parsePRQuery compiles a --query expression, expanding saved queries from the
+// config and resolving "me" to the authenticated user. It returns nil for an empty expression.
+func parsePRQuery(ctx context.Context, svc *devops.DevOpsService, expr string) (*filter.Query, error) {
+	if expr == "" {
+		return nil, nil
+	}
+
+	query, err := filter.ParseQuery(expr, config.GetSavedQueries())
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+
+	if query.ReferencesCurrentUser() {
+		user, err := svc.GetCurrentUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+		query.CurrentUserID = user.ID
+	}
+
+	return query, nil
+}
+
 func outputReport(ctx context.Context, svc *devops.DevOpsService, prs []models.PullRequest, shouldCopy bool) error {
 	report := svc.GenerateMessageReport(ctx, prs, !reportNoWarnings, reportWorkItems)
 
@@ -157,6 +194,7 @@ func init() {
 	reportCmd.Flags().StringVar(&reportTitleFuzzy, "title-fuzzy", "", "Filter PR title by fuzzy match")
 	reportCmd.Flags().StringVar(&reportRepoRegex, "repo-regex", "", "Filter repository by regex pattern")
 	reportCmd.Flags().StringVar(&reportRepoFuzzy, "repo-fuzzy", "", "Filter repository by fuzzy match")
+	reportCmd.Flags().StringVar(&reportQuery, "query", "", "Filter with a query expression or saved query name (e.g. 'author:me age>7d')")
 	reportCmd.Flags().BoolVar(&reportUseGitContext, "use-git-context", true, "Use git context for auto-detection when in a git repository")
 	reportCmd.Flags().BoolVar(&reportNoGitContext, "no-git-context", false, "Disable git context auto-detection")
 
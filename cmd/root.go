@@ -95,7 +95,7 @@ func init() {
 	RegisterCommands(rootCmd)
 
 	rootCmd.PersistentFlags().DurationVar(&globalTimeout, "timeout", defaultTimeout, "Timeout for API requests (e.g., 30s, 1m)")
-	rootCmd.PersistentFlags().StringVar(&outputFormat, "format", "table", "Output format (table, modern, json, yaml)")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "format", "table", "Output format (table, modern, json, yaml, csv where supported)")
 	rootCmd.PersistentFlags().BoolVar(&dryRunFlag, "dry-run", false, "Show what would be done without making changes")
 	rootCmd.PersistentFlags().BoolVarP(&assumeYesFlag, "yes", "y", false, "Skip confirmation prompts")
 	rootCmd.PersistentFlags().BoolVar(&copyToClipboardFlag, "copy", false, "Copy output to clipboard (supports rich HTML for Teams)")
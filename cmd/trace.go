@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"adoctl/pkg/devops"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	traceRepoName string
+	traceRepoID   string
+)
+
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
+
+var traceCmd = &cobra.Command{
+	Use:   "trace <commit|pr|workitem> <id> | trace <sha|!pr|#workitem>",
+	Short: "Trace a change through PR, build and release",
+	Long: `Walk a change from a work item, pull request or commit to the environments it
+reached: work item, linked PRs, merge commit, CI builds, releases, and the latest
+deployment of each environment, with timestamps for each hop.
+
+The target is given as a kind and an ID, or as a single argument: a commit SHA,
+!<id> for a pull request, or #<id> for a work item. Builds and deployments come
+from the cache, which is synced first.
+
+A commit is looked up in the repositories of its cached builds, or in the
+repository given with --repository-name or --repo-id.`,
+	Example: `  # Where is my work item?
+  adoctl trace workitem 4711
+  adoctl trace '#4711'
+
+  # Trace a pull request
+  adoctl trace pr 123
+  adoctl trace '!123'
+
+  # Trace a commit (short SHAs are expanded from the builds cache)
+  adoctl trace a1b2c3d
+
+  # As JSON for scripting
+  adoctl trace pr 123 --format json`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kind, target, err := parseTraceTarget(args)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		var trace *devops.Trace
+		switch kind {
+		case "workitem":
+			id, _ := strconv.Atoi(target)
+			trace, err = svc.TraceWorkItem(ctx, id)
+		case "pr":
+			id, _ := strconv.Atoi(target)
+			trace, err = svc.TracePullRequest(ctx, id)
+		default:
+			repoID := ""
+			if traceRepoName != "" || traceRepoID != "" {
+				repoID, _, err = ResolveRepoID(svc, traceRepoName, traceRepoID, false)
+				if err != nil {
+					return err
+				}
+			}
+			trace, err = svc.TraceCommit(ctx, target, repoID)
+		}
+		if err != nil {
+			return err
+		}
+
+		writer := NewOutputWriter(cmd.Flag("format").Value.String())
+		if writer.IsStructured() {
+			return writer.Write(trace)
+		}
+
+		fmt.Print(renderTraceTree(buildTraceTree(trace)))
+		return nil
+	},
+}
+
+// parseTraceTarget returns the kind (commit, pr or workitem) and ID of the trace target
+func parseTraceTarget(args []string) (string, string, error) {
+	if len(args) == 2 {
+		kind := strings.ToLower(args[0])
+		switch kind {
+		case "commit":
+			if !commitSHAPattern.MatchString(args[1]) {
+				return "", "", fmt.Errorf("invalid commit SHA: %s", args[1])
+			}
+			return kind, args[1], nil
+		case "pr", "workitem", "wi":
+			if kind == "wi" {
+				kind = "workitem"
+			}
+			if _, err := strconv.Atoi(args[1]); err != nil {
+				return "", "", fmt.Errorf("invalid %s ID: %s", kind, args[1])
+			}
+			return kind, args[1], nil
+		default:
+			return "", "", fmt.Errorf("unknown trace target '%s' (use commit, pr or workitem)", args[0])
+		}
+	}
+
+	arg := args[0]
+	if strings.HasPrefix(arg, "!") || strings.HasPrefix(arg, "#") {
+		kind := "pr"
+		if arg[0] == '#' {
+			kind = "workitem"
+		}
+		if _, err := strconv.Atoi(arg[1:]); err != nil {
+			return "", "", fmt.Errorf("invalid %s ID: %s", kind, arg[1:])
+		}
+		return kind, arg[1:], nil
+	}
+	if _, err := strconv.Atoi(arg); err == nil {
+		return "", "", fmt.Errorf("'%s' could be a PR or a work item, use 'trace pr %s' or 'trace workitem %s'", arg, arg, arg)
+	}
+	if !commitSHAPattern.MatchString(arg) {
+		return "", "", fmt.Errorf("invalid trace target: %s", arg)
+	}
+	return "commit", arg, nil
+}
+
+// traceNode is a line of the trace tree with its children
+type traceNode struct {
+	label    string
+	children []traceNode
+}
+
+func buildTraceTree(trace *devops.Trace) traceNode {
+	switch {
+	case trace.WorkItem != nil:
+		wi := trace.WorkItem
+		root := traceNode{label: fmt.Sprintf("Work item #%d %s: %s [%s]%s", wi.ID, wi.Type, wi.Title, wi.State, traceTimes("created", wi.CreatedAt, "", nil))}
+		for _, pr := range wi.PullRequests {
+			root.children = append(root.children, tracePRNode(pr))
+		}
+		if len(root.children) == 0 {
+			root.children = append(root.children, traceNode{label: faint("no linked pull requests")})
+		}
+		return root
+	case len(trace.PullRequests) == 1 && trace.Commit == nil:
+		return tracePRNode(trace.PullRequests[0])
+	case trace.Commit != nil:
+		root := traceCommitNode(*trace.Commit)
+		root.children = append([]traceNode{{label: faint("no pull request found for this commit")}}, root.children...)
+		return root
+	default:
+		root := traceNode{label: "Trace of " + trace.Subject}
+		for _, pr := range trace.PullRequests {
+			root.children = append(root.children, tracePRNode(pr))
+		}
+		return root
+	}
+}
+
+func tracePRNode(pr devops.TracePullRequest) traceNode {
+	closed := "closed"
+	if pr.Status == "completed" {
+		closed = "merged"
+	}
+	node := traceNode{label: fmt.Sprintf("PR !%d %s [%s] %s → %s (%s)%s",
+		pr.ID, pr.Title, traceStatus(pr.Status), pr.SourceBranch, pr.TargetBranch, pr.Repository,
+		traceTimes("created", pr.CreatedAt, closed, pr.ClosedAt))}
+
+	switch {
+	case pr.MergeCommit != nil:
+		node.children = append(node.children, traceCommitNode(*pr.MergeCommit))
+	case pr.Status == "active":
+		node.children = append(node.children, traceNode{label: faint("not merged yet")})
+	}
+	return node
+}
+
+func traceCommitNode(c devops.TraceCommit) traceNode {
+	node := traceNode{label: "Commit " + devops.ShortCommit(c.Commit)}
+	for _, b := range c.Builds {
+		node.children = append(node.children, traceBuildNode(b))
+	}
+	if len(c.Builds) == 0 {
+		node.children = append(node.children, traceNode{label: faint("no cached builds")})
+	}
+	return node
+}
+
+func traceBuildNode(b devops.TraceBuild) traceNode {
+	status := b.Status
+	if b.Result != "" {
+		status = b.Result
+	}
+	node := traceNode{label: fmt.Sprintf("Build #%d %s [%s]%s",
+		b.ID, b.Definition, traceStatus(status), traceTimes("started", &b.StartedAt, "finished", b.CompletedAt))}
+
+	for _, r := range b.Releases {
+		release := traceNode{label: fmt.Sprintf("Release %s (%s)", r.Name, r.Definition)}
+		for _, env := range r.Environments {
+			release.children = append(release.children, traceNode{label: fmt.Sprintf("%s [%s]%s",
+				env.Environment, traceStatus(env.Status), traceTimes("started", &env.StartedAt, "finished", env.CompletedAt))})
+		}
+		node.children = append(node.children, release)
+	}
+	return node
+}
+
+// traceTimes formats the timestamps of a hop, omitting the ones that are not set
+func traceTimes(firstLabel string, first *time.Time, secondLabel string, second *time.Time) string {
+	parts := []string{}
+	if first != nil && !first.IsZero() {
+		parts = append(parts, firstLabel+" "+first.Local().Format("2006-01-02 15:04"))
+	}
+	if second != nil && !second.IsZero() {
+		parts = append(parts, secondLabel+" "+second.Local().Format("2006-01-02 15:04"))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "  " + faint(strings.Join(parts, ", "))
+}
+
+func traceStatus(status string) string {
+	switch status {
+	case "succeeded", "completed":
+		return color.GreenString(status)
+	case "failed", "rejected", "abandoned", "canceled":
+		return color.RedString(status)
+	case "partiallySucceeded", "inProgress", "active", "queued", "notStarted":
+		return color.YellowString(status)
+	default:
+		return status
+	}
+}
+
+func faint(s string) string {
+	return color.New(color.Faint).Sprint(s)
+}
+
+// renderTraceTree draws a node and its descendants with box-drawing branches
+func renderTraceTree(root traceNode) string {
+	var b strings.Builder
+	b.WriteString(root.label + "\n")
+	renderTraceChildren(&b, root.children, "")
+	return b.String()
+}
+
+func renderTraceChildren(b *strings.Builder, children []traceNode, prefix string) {
+	for i, child := range children {
+		branch, indent := "├── ", "│   "
+		if i == len(children)-1 {
+			branch, indent = "└── ", "    "
+		}
+		b.WriteString(prefix + branch + child.label + "\n")
+		renderTraceChildren(b, child.children, prefix+indent)
+	}
+}
+
+func init() {
+	traceCmd.Flags().StringVar(&traceRepoName, "repository-name", "", "Repository of the commit (default: the repositories of its builds)")
+	traceCmd.Flags().StringVar(&traceRepoID, "repo-id", "", "Repository ID (alternative to --repository-name)")
+	traceCmd.MarkFlagsMutuallyExclusive("repository-name", "repo-id")
+}
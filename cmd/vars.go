@@ -0,0 +1,631 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"adoctl/pkg/devops"
+	"adoctl/pkg/errors"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// maskedValue replaces secret values in output
+const maskedValue = "********"
+
+var (
+	varsSetSecret        bool
+	varsSetFromFile      string
+	varsSetStdin         bool
+	varsSetAllowOverride bool
+	varsDiffAll          bool
+)
+
+var varsCmd = &cobra.Command{
+	Use:     "vars",
+	Aliases: []string{"variables"},
+	Short:   "Variable group and pipeline variable commands",
+	Long: `Commands for managing library variable groups and the variables defined on
+pipeline definitions.
+
+Secret values are never shown: Azure DevOps does not return them, and output masks
+them. New secret values are read from a file or stdin, never from the command line.
+Every change asks for confirmation and honours --dry-run.`,
+	Example: `  # List variable groups and show one
+  adoctl vars group list
+  adoctl vars group show app-staging
+
+  # Set a variable, and a secret read from stdin
+  adoctl vars group set app-staging LOG_LEVEL debug
+  echo -n "$TOKEN" | adoctl vars group set app-staging API_TOKEN --secret --stdin --yes
+
+  # Compare the groups of two environments
+  adoctl vars diff app-staging app-production`,
+}
+
+var varsGroupCmd = &cobra.Command{
+	Use:   "group",
+	Short: "Manage library variable groups",
+	Long:  `List, show and change the variables of library variable groups`,
+}
+
+var varsGroupListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List variable groups",
+	Example: `  # List variable groups
+  adoctl vars group list
+
+  # As JSON, with their variables (secrets have no value)
+  adoctl vars group list --format json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		groups, err := svc.ListVariableGroups(ctx)
+		if err != nil {
+			return err
+		}
+
+		writer := NewOutputWriter(cmd.Flag("format").Value.String())
+		if writer.IsStructured() {
+			return writer.Write(groups)
+		}
+
+		if len(groups) == 0 {
+			fmt.Println("No variable groups found")
+			return nil
+		}
+
+		fmt.Printf("%-6s  %-40s  %-9s  %-14s  %s\n", "ID", "NAME", "VARIABLES", "TYPE", "MODIFIED")
+		for _, g := range groups {
+			modified := "-"
+			if !g.ModifiedOn.IsZero() {
+				modified = formatAge(time.Since(g.ModifiedOn)) + " ago"
+				if g.ModifiedBy != "" {
+					modified += " by " + g.ModifiedBy
+				}
+			}
+			fmt.Printf("%-6d  %-40s  %-9d  %-14s  %s\n", g.ID, truncateString(g.Name, 40), len(g.Variables), g.Type, modified)
+		}
+		return nil
+	},
+}
+
+var varsGroupShowCmd = &cobra.Command{
+	Use:   "show <group>",
+	Short: "Show the variables of a variable group",
+	Long:  `Show the variables of a variable group, by ID or name. Secret values are masked.`,
+	Example: `  # Show a group by name
+  adoctl vars group show app-staging
+
+  # Show a group by ID as JSON
+  adoctl vars group show 12 --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		group, err := svc.GetVariableGroup(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		writer := NewOutputWriter(cmd.Flag("format").Value.String())
+		if writer.IsStructured() {
+			return writer.Write(group)
+		}
+
+		_, _ = color.New(color.Bold).Printf("%s (id %d)\n", group.Name, group.ID)
+		if group.Description != "" {
+			fmt.Printf("  %s\n", group.Description)
+		}
+		if group.Type == "AzureKeyVault" {
+			fmt.Println("  Linked to an Azure Key Vault")
+		}
+		fmt.Println()
+		printVariables(group.Variables)
+		return nil
+	},
+}
+
+var varsGroupSetCmd = &cobra.Command{
+	Use:   "set <group> <name> [value]",
+	Short: "Set a variable of a variable group",
+	Long: `Add or change a variable of a variable group. The other variables of the group,
+including secret values, are kept. A changed variable keeps its secret and
+read-only settings unless --secret is passed; --secret=false turns a secret into
+a plain variable.
+
+The value is the third argument, the content of --from-file or stdin with --stdin;
+a single trailing newline is dropped from file and stdin values. Secret values
+(--secret) must come from a file or stdin so they stay out of shell history.`,
+	Example: `  # Set a plain variable
+  adoctl vars group set app-staging LOG_LEVEL debug
+
+  # Set a secret from a file
+  adoctl vars group set app-staging API_TOKEN --secret --from-file ./token.txt
+
+  # Set a secret from stdin (stdin cannot also answer the prompt, so --yes is needed)
+  echo -n "$TOKEN" | adoctl vars group set app-staging API_TOKEN --secret --stdin --yes
+
+  # Preview the change
+  adoctl vars group set app-staging LOG_LEVEL debug --dry-run`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		value, err := readVariableValue(args[2:], os.Stdin)
+		if err != nil {
+			return err
+		}
+		name := args[1]
+
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		group, err := svc.GetVariableGroup(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		current := findVariable(group.Variables, name)
+		secret, err := secretChange(cmd, current, args[2:])
+		if err != nil {
+			return err
+		}
+
+		details := map[string]string{
+			"Group":    fmt.Sprintf("%s (id %d)", group.Name, group.ID),
+			"Variable": name,
+		}
+		addValueDetails(details, value, current, secret)
+
+		action := fmt.Sprintf("set %s in variable group %s", name, group.Name)
+		if IsDryRun() {
+			PrintDryRunAction(action, details)
+			return nil
+		}
+		updateCtx, updateCancel, err := ConfirmAndGetContext(action, details)
+		if err != nil {
+			return err
+		}
+		defer updateCancel()
+
+		if _, err := svc.UpdateGroupVariables(updateCtx, group.ID, map[string]devops.VariableChange{
+			name: {Value: value, Secret: secret},
+		}, nil); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Set %s in %s\n", name, group.Name)
+		return nil
+	},
+}
+
+var varsGroupUnsetCmd = &cobra.Command{
+	Use:   "unset <group> <name>...",
+	Short: "Remove variables from a variable group",
+	Example: `  # Remove a variable
+  adoctl vars group unset app-staging OLD_FLAG
+
+  # Remove several variables without prompting
+  adoctl vars group unset app-staging OLD_FLAG LEGACY_URL --yes`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names := args[1:]
+
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		group, err := svc.GetVariableGroup(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		details := map[string]string{
+			"Group":     fmt.Sprintf("%s (id %d)", group.Name, group.ID),
+			"Variables": strings.Join(names, ", "),
+		}
+
+		action := fmt.Sprintf("remove %d variable(s) from variable group %s", len(names), group.Name)
+		if IsDryRun() {
+			PrintDryRunAction(action, details)
+			return nil
+		}
+		updateCtx, updateCancel, err := ConfirmAndGetContext(action, details)
+		if err != nil {
+			return err
+		}
+		defer updateCancel()
+
+		if _, err := svc.UpdateGroupVariables(updateCtx, group.ID, nil, names); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Removed %s from %s\n", strings.Join(names, ", "), group.Name)
+		return nil
+	},
+}
+
+var varsPipelineCmd = &cobra.Command{
+	Use:   "pipeline",
+	Short: "Manage pipeline definition variables",
+	Long: `List and set the variables defined on a pipeline definition (the Variables
+button of the pipeline editor). Variables declared in the YAML file are not included.`,
+}
+
+var varsPipelineListCmd = &cobra.Command{
+	Use:   "list <pipeline>",
+	Short: "List the variables of a pipeline",
+	Long: `List the variables defined on a pipeline, given by ID, name or folder path.
+Secret values are masked.`,
+	Example: `  # List the variables of a pipeline
+  adoctl vars pipeline list my-pipeline
+
+  # As JSON
+  adoctl vars pipeline list 42 --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		definition, err := svc.ResolveDefinition(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		variables, err := svc.ListPipelineVariables(ctx, definition.ID)
+		if err != nil {
+			return err
+		}
+
+		writer := NewOutputWriter(cmd.Flag("format").Value.String())
+		if writer.IsStructured() {
+			return writer.Write(variables)
+		}
+
+		_, _ = color.New(color.Bold).Printf("%s (id %d)\n\n", definition.Name, definition.ID)
+		printVariables(variables)
+		return nil
+	},
+}
+
+var varsPipelineSetCmd = &cobra.Command{
+	Use:   "set <pipeline> <name> [value]",
+	Short: "Set a variable of a pipeline",
+	Long: `Add or change a variable defined on a pipeline definition. The other variables,
+including secret values, are kept. A changed variable keeps its secret and
+allow-override settings unless --secret or --allow-override is passed.
+
+The value is read the same way as for 'vars group set': the third argument,
+--from-file or --stdin, and secret values only from a file or stdin.`,
+	Example: `  # Set a variable that can be overridden when queueing a run
+  adoctl vars pipeline set my-pipeline DEPLOY_RING canary --allow-override
+
+  # Set a secret from a file
+  adoctl vars pipeline set my-pipeline NPM_TOKEN --secret --from-file ./npm-token.txt
+
+  # Preview the change
+  adoctl vars pipeline set my-pipeline DEPLOY_RING canary --dry-run`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		value, err := readVariableValue(args[2:], os.Stdin)
+		if err != nil {
+			return err
+		}
+		name := args[1]
+
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		definition, err := svc.ResolveDefinition(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		variables, err := svc.ListPipelineVariables(ctx, definition.ID)
+		if err != nil {
+			return err
+		}
+		current := findVariable(variables, name)
+		secret, err := secretChange(cmd, current, args[2:])
+		if err != nil {
+			return err
+		}
+
+		// --allow-override changes the setting only when passed
+		var allowOverride *bool
+		if cmd.Flags().Changed("allow-override") {
+			allowOverride = &varsSetAllowOverride
+		}
+
+		details := map[string]string{
+			"Pipeline": fmt.Sprintf("%s (id %d)", definition.Name, definition.ID),
+			"Variable": name,
+		}
+		addValueDetails(details, value, current, secret)
+		switch {
+		case allowOverride != nil:
+			details["Allow override"] = fmt.Sprint(*allowOverride)
+		case current != nil:
+			details["Allow override"] = fmt.Sprintf("%t (unchanged)", current.AllowOverride)
+		default:
+			details["Allow override"] = "false"
+		}
+
+		action := fmt.Sprintf("set %s on pipeline %s", name, definition.Name)
+		if IsDryRun() {
+			PrintDryRunAction(action, details)
+			return nil
+		}
+		updateCtx, updateCancel, err := ConfirmAndGetContext(action, details)
+		if err != nil {
+			return err
+		}
+		defer updateCancel()
+
+		if err := svc.SetPipelineVariable(updateCtx, definition.ID, name, devops.VariableChange{
+			Value:         value,
+			Secret:        secret,
+			AllowOverride: allowOverride,
+		}); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Set %s on %s\n", name, definition.Name)
+		return nil
+	},
+}
+
+var varsDiffCmd = &cobra.Command{
+	Use:   "diff <group-a> <group-b>",
+	Short: "Compare the variables of two variable groups",
+	Long: `Compare two variable groups, typically the same settings for two environments.
+Variables only in the first group are shown as removed, only in the second as
+added. Secret values cannot be read, so secrets present in both groups are listed
+as not comparable. Use --all to include identical variables.`,
+	Example: `  # Differences between staging and production
+  adoctl vars diff app-staging app-production
+
+  # Every variable, as JSON
+  adoctl vars diff app-staging app-production --all --format json`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := GetContext()
+		defer cancel()
+
+		svc, err := devops.NewServiceFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to create devops service: %w", err)
+		}
+		defer svc.Close()
+
+		left, err := svc.GetVariableGroup(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		right, err := svc.GetVariableGroup(ctx, args[1])
+		if err != nil {
+			return err
+		}
+
+		diffs := []devops.VariableDiff{}
+		for _, d := range devops.DiffVariableGroups(left, right) {
+			if d.Status != "same" || varsDiffAll {
+				diffs = append(diffs, d)
+			}
+		}
+
+		writer := NewOutputWriter(cmd.Flag("format").Value.String())
+		if writer.IsStructured() {
+			return writer.Write(diffs)
+		}
+
+		fmt.Printf("--- %s (id %d)\n+++ %s (id %d)\n\n", left.Name, left.ID, right.Name, right.ID)
+		if len(diffs) == 0 {
+			fmt.Println("No differences")
+			return nil
+		}
+
+		for _, d := range diffs {
+			switch d.Status {
+			case "added":
+				_, _ = color.New(color.FgGreen).Printf("+ %s = %s\n", d.Name, displayVariable(d.Right))
+			case "removed":
+				_, _ = color.New(color.FgRed).Printf("- %s = %s\n", d.Name, displayVariable(d.Left))
+			case "changed":
+				_, _ = color.New(color.FgYellow).Printf("~ %s: %s → %s\n", d.Name, displayVariable(d.Left), displayVariable(d.Right))
+			case "secret":
+				fmt.Printf("? %s = %s (secret in both, not compared)\n", d.Name, maskedValue)
+			default:
+				fmt.Printf("  %s = %s\n", d.Name, displayVariable(d.Left))
+			}
+		}
+		return nil
+	},
+}
+
+// readVariableValue returns the value of a set command from its optional value
+// argument, --from-file or --stdin. Exactly one source must be given, and secret
+// values are refused on the command line, where they would end up in shell history.
+func readVariableValue(valueArgs []string, stdin io.Reader) (string, error) {
+	sources := len(valueArgs)
+	if varsSetFromFile != "" {
+		sources++
+	}
+	if varsSetStdin {
+		sources++
+	}
+	if sources != 1 {
+		return "", errors.ValidationError("give the value as an argument, with --from-file or with --stdin (exactly one)")
+	}
+
+	switch {
+	case len(valueArgs) == 1:
+		if varsSetSecret {
+			return "", errors.NewWithSuggestion(errors.ExitCodeValidation,
+				"secret values cannot be passed on the command line",
+				"Use --from-file <path> or --stdin")
+		}
+		return valueArgs[0], nil
+	case varsSetFromFile != "":
+		content, err := os.ReadFile(varsSetFromFile)
+		if err != nil {
+			return "", errors.NewWithError(errors.ExitCodeFileOperation, "failed to read value file", err)
+		}
+		return trimTrailingNewline(string(content)), nil
+	default:
+		// The confirmation prompt reads stdin too, so it cannot be answered here
+		if !IsAssumeYes() && !IsDryRun() {
+			return "", errors.ValidationError("reading the value from stdin requires --yes or --dry-run")
+		}
+		content, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", errors.NewWithError(errors.ExitCodeFileOperation, "failed to read value from stdin", err)
+		}
+		return trimTrailingNewline(string(content)), nil
+	}
+}
+
+// secretChange returns the secret setting of a set command: --secret when it was
+// passed, or nil to keep the setting of an existing variable. An existing secret
+// keeps being a secret, so its new value is refused on the command line too.
+func secretChange(cmd *cobra.Command, current *devops.Variable, valueArgs []string) (*bool, error) {
+	if cmd.Flags().Changed("secret") {
+		return &varsSetSecret, nil
+	}
+	if current != nil && current.Secret && len(valueArgs) > 0 {
+		return nil, errors.NewWithSuggestion(errors.ExitCodeValidation,
+			fmt.Sprintf("%s is a secret; its value cannot be passed on the command line", current.Name),
+			"Use --from-file <path> or --stdin, or --secret=false to store it as plain text")
+	}
+	return nil, nil
+}
+
+// addValueDetails describes the new and current value of a variable in the
+// confirmation details, with a warning when a secret becomes plain text
+func addValueDetails(details map[string]string, value string, current *devops.Variable, secret *bool) {
+	isSecret := current != nil && current.Secret
+	if secret != nil {
+		isSecret = *secret
+	}
+	details["Value"] = displayValue(value, isSecret)
+	if current == nil {
+		return
+	}
+	details["Current value"] = displayValue(current.Value, current.Secret)
+	if current.Secret && !isSecret {
+		details["Warning"] = "the secret becomes a plain text variable and its value will be visible"
+	}
+}
+
+func findVariable(variables []devops.Variable, name string) *devops.Variable {
+	for i := range variables {
+		if strings.EqualFold(variables[i].Name, name) {
+			return &variables[i]
+		}
+	}
+	return nil
+}
+
+func trimTrailingNewline(s string) string {
+	s = strings.TrimSuffix(s, "\n")
+	return strings.TrimSuffix(s, "\r")
+}
+
+func displayValue(value string, secret bool) string {
+	if secret {
+		return maskedValue
+	}
+	return value
+}
+
+func displayVariable(v *devops.Variable) string {
+	if v.Secret {
+		return maskedValue + " (secret)"
+	}
+	return v.Value
+}
+
+func printVariables(variables []devops.Variable) {
+	if len(variables) == 0 {
+		fmt.Println("No variables")
+		return
+	}
+
+	nameWidth := 0
+	for _, v := range variables {
+		nameWidth = max(nameWidth, len([]rune(v.Name)))
+	}
+	nameWidth = min(nameWidth, 40)
+
+	for _, v := range variables {
+		flags := []string{}
+		if v.Secret {
+			flags = append(flags, "secret")
+		}
+		if v.ReadOnly {
+			flags = append(flags, "read-only")
+		}
+		if v.AllowOverride {
+			flags = append(flags, "settable at queue time")
+		}
+		line := fmt.Sprintf("%-*s  %s", nameWidth, truncateString(v.Name, nameWidth), displayValue(v.Value, v.Secret))
+		if len(flags) > 0 {
+			line += color.New(color.Faint).Sprintf("  [%s]", strings.Join(flags, ", "))
+		}
+		fmt.Println(line)
+	}
+}
+
+func init() {
+	for _, setCmd := range []*cobra.Command{varsGroupSetCmd, varsPipelineSetCmd} {
+		setCmd.Flags().BoolVar(&varsSetSecret, "secret", false, "Store the value as a secret (read from --from-file or --stdin); an existing variable keeps its setting when omitted")
+		setCmd.Flags().StringVar(&varsSetFromFile, "from-file", "", "Read the value from a file")
+		setCmd.Flags().BoolVar(&varsSetStdin, "stdin", false, "Read the value from stdin (requires --yes or --dry-run)")
+	}
+	varsPipelineSetCmd.Flags().BoolVar(&varsSetAllowOverride, "allow-override", false, "Let the variable be set when queueing a run; an existing variable keeps its setting when omitted")
+	varsDiffCmd.Flags().BoolVar(&varsDiffAll, "all", false, "Include variables with the same value in both groups")
+
+	varsGroupCmd.AddCommand(varsGroupListCmd, varsGroupShowCmd, varsGroupSetCmd, varsGroupUnsetCmd)
+	varsPipelineCmd.AddCommand(varsPipelineListCmd, varsPipelineSetCmd)
+}
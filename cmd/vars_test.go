@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadVariableValue(t *testing.T) {
+	dir := t.TempDir()
+	valueFile := filepath.Join(dir, "value.txt")
+	if err := os.WriteFile(valueFile, []byte("from-file\r\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		args      []string
+		fromFile  string
+		stdin     bool
+		secret    bool
+		assumeYes bool
+		dryRun    bool
+		input     string
+		want      string
+		wantErr   string
+	}{
+		{name: "argument", args: []string{"debug"}, want: "debug"},
+		{name: "argument keeps newlines", args: []string{"a\n"}, want: "a\n"},
+		{name: "secret on the command line is refused", args: []string{"hunter2"}, secret: true, wantErr: "cannot be passed on the command line"},
+		{name: "file drops one trailing newline", fromFile: valueFile, want: "from-file"},
+		{name: "secret from file", fromFile: valueFile, secret: true, want: "from-file"},
+		{name: "missing file", fromFile: filepath.Join(dir, "missing"), wantErr: "failed to read value file"},
+		{name: "stdin with --yes", stdin: true, assumeYes: true, input: "token\n", want: "token"},
+		{name: "stdin with --dry-run", stdin: true, dryRun: true, input: "token", want: "token"},
+		{name: "stdin drops only one newline", stdin: true, assumeYes: true, input: "token\n\n", want: "token\n"},
+		{name: "stdin requires --yes", stdin: true, input: "token\n", wantErr: "requires --yes"},
+		{name: "no source", wantErr: "exactly one"},
+		{name: "argument and file", args: []string{"x"}, fromFile: valueFile, wantErr: "exactly one"},
+		{name: "file and stdin", fromFile: valueFile, stdin: true, assumeYes: true, wantErr: "exactly one"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			varsSetFromFile, varsSetStdin, varsSetSecret = tt.fromFile, tt.stdin, tt.secret
+			assumeYesFlag, dryRunFlag = tt.assumeYes, tt.dryRun
+			t.Cleanup(func() {
+				varsSetFromFile, varsSetStdin, varsSetSecret = "", false, false
+				assumeYesFlag, dryRunFlag = false, false
+			})
+
+			got, err := readVariableValue(tt.args, strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readVariableValue() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readVariableValue() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("readVariableValue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
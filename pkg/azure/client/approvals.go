@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/release"
+)
+
+// GetPendingApprovals returns the pending release approvals assigned to the given
+// user, including those assigned to the user's groups when includeGroups is set
+func (c *Client) GetPendingApprovals(ctx context.Context, assignedTo string, includeGroups bool) ([]release.ReleaseApproval, error) {
+	project := c.GetProject()
+	status := release.ApprovalStatusValues.Pending
+	top := 100
+	approvals := []release.ReleaseApproval{}
+	var continuationToken *int
+
+	for {
+		args := release.GetApprovalsArgs{
+			Project:                 &project,
+			StatusFilter:            &status,
+			Top:                     &top,
+			ContinuationToken:       continuationToken,
+			IncludeMyGroupApprovals: &includeGroups,
+		}
+		if assignedTo != "" {
+			args.AssignedToFilter = &assignedTo
+		}
+
+		result, err := c.ReleaseClient.GetApprovals(ctx, args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get release approvals: %w", err)
+		}
+
+		approvals = append(approvals, result.Value...)
+
+		next, err := strconv.Atoi(result.ContinuationToken)
+		if err != nil || len(result.Value) == 0 {
+			break
+		}
+		continuationToken = &next
+	}
+
+	return approvals, nil
+}
+
+// UpdateApproval approves or rejects a release approval with an optional comment
+func (c *Client) UpdateApproval(ctx context.Context, approvalID int, status release.ApprovalStatus, comment string) (*release.ReleaseApproval, error) {
+	project := c.GetProject()
+	args := release.UpdateReleaseApprovalArgs{
+		Project:    &project,
+		ApprovalId: &approvalID,
+		Approval: &release.ReleaseApproval{
+			Status:   &status,
+			Comments: &comment,
+		},
+	}
+
+	result, err := c.ReleaseClient.UpdateReleaseApproval(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update approval %d: %w", approvalID, err)
+	}
+
+	return result, nil
+}
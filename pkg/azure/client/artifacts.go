@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/build"
+)
+
+// GetBuildArtifacts returns the artifacts published by a build
+func (c *Client) GetBuildArtifacts(ctx context.Context, buildID int) ([]build.BuildArtifact, error) {
+	project := c.GetProject()
+	args := build.GetArtifactsArgs{
+		Project: &project,
+		BuildId: &buildID,
+	}
+
+	result, err := c.BuildClient.GetArtifacts(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get build artifacts: %w", err)
+	}
+	if result == nil {
+		return []build.BuildArtifact{}, nil
+	}
+
+	return *result, nil
+}
+
+// DownloadArtifact streams an artifact download URL to w through the authenticated
+// connection. It returns the total number of bytes written.
+func (c *Client) DownloadArtifact(ctx context.Context, downloadURL string, w io.Writer) (int64, error) {
+	httpClient := c.Connection.GetClientByUrl(downloadURL)
+
+	req, err := httpClient.CreateRequestMessage(ctx, http.MethodGet, downloadURL, "", nil, "", "application/zip", nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create artifact request: %w", err)
+	}
+
+	resp, err := httpClient.SendRequest(req)
+	if err != nil {
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		return 0, fmt.Errorf("failed to download artifact: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	written, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return written, fmt.Errorf("failed to read artifact data: %w", err)
+	}
+
+	return written, nil
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+)
+
+func TestDownloadArtifact(t *testing.T) {
+	payload := bytes.Repeat([]byte("artifact"), 4096)
+	wantAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte(":secret-pat"))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != wantAuth {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/drop":
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = w.Write(payload)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{Connection: azuredevops.NewPatConnection(server.URL, "secret-pat")}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		path    string
+		wantErr bool
+	}{
+		{name: "streams the artifact", ctx: context.Background(), path: "/drop"},
+		{name: "missing artifact", ctx: context.Background(), path: "/missing", wantErr: true},
+		{name: "canceled download", ctx: canceled, path: "/drop", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			written, err := c.DownloadArtifact(tt.ctx, server.URL+tt.path, &buf)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DownloadArtifact() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if written != int64(len(payload)) {
+				t.Errorf("written = %d, want %d", written, len(payload))
+			}
+			if !bytes.Equal(buf.Bytes(), payload) {
+				t.Error("downloaded content does not match")
+			}
+		})
+	}
+}
@@ -4,14 +4,23 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"adoctl/pkg/utils"
 
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/build"
 )
 
+// GetBuilds lists the builds of the project. The API requires the project, and
+// params may set $top, minTime (time.DateTime layout, as sent by build sync),
+// branchName, definitions (comma-separated IDs) and queryOrder.
 func (c *Client) GetBuilds(ctx context.Context, params map[string]string) ([]build.Build, error) {
-	args := build.GetBuildsArgs{}
+	project := c.GetProject()
+	args := build.GetBuildsArgs{
+		Project: &project,
+	}
 
 	if topStr, ok := params["$top"]; ok && topStr != "" {
 		if top, err := strconv.Atoi(topStr); err == nil {
@@ -19,6 +28,31 @@ func (c *Client) GetBuilds(ctx context.Context, params map[string]string) ([]bui
 		}
 	}
 
+	if minTimeStr, ok := params["minTime"]; ok && minTimeStr != "" {
+		if minTime, err := time.Parse(time.DateTime, minTimeStr); err == nil {
+			args.MinTime = &azuredevops.Time{Time: minTime}
+		}
+	}
+
+	if branchName, ok := params["branchName"]; ok && branchName != "" {
+		args.BranchName = &branchName
+	}
+
+	if definitionsStr, ok := params["definitions"]; ok && definitionsStr != "" {
+		definitions := []int{}
+		for _, idStr := range strings.Split(definitionsStr, ",") {
+			if id, err := strconv.Atoi(strings.TrimSpace(idStr)); err == nil {
+				definitions = append(definitions, id)
+			}
+		}
+		args.Definitions = &definitions
+	}
+
+	if queryOrder, ok := params["queryOrder"]; ok && queryOrder != "" {
+		order := build.BuildQueryOrder(queryOrder)
+		args.QueryOrder = &order
+	}
+
 	result, err := c.BuildClient.GetBuilds(ctx, args)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get builds: %w", err)
@@ -32,7 +66,9 @@ func (c *Client) GetBuilds(ctx context.Context, params map[string]string) ([]bui
 }
 
 func (c *Client) GetBuildByID(ctx context.Context, buildID int) (*build.Build, error) {
+	project := c.GetProject()
 	args := build.GetBuildArgs{
+		Project: &project,
 		BuildId: &buildID,
 	}
 
@@ -43,3 +79,225 @@ func (c *Client) GetBuildByID(ctx context.Context, buildID int) (*build.Build, e
 
 	return result, nil
 }
+
+// GetDefinitions returns all build definitions of the project
+func (c *Client) GetDefinitions(ctx context.Context) ([]build.BuildDefinitionReference, error) {
+	project := c.GetProject()
+	definitions := []build.BuildDefinitionReference{}
+	continuationToken := ""
+
+	for {
+		args := build.GetDefinitionsArgs{
+			Project: &project,
+		}
+		if continuationToken != "" {
+			args.ContinuationToken = &continuationToken
+		}
+
+		result, err := c.BuildClient.GetDefinitions(ctx, args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get build definitions: %w", err)
+		}
+
+		definitions = append(definitions, result.Value...)
+
+		if result.ContinuationToken == "" {
+			break
+		}
+		continuationToken = result.ContinuationToken
+	}
+
+	return definitions, nil
+}
+
+// GetDefinition returns a build definition with its repository, process and triggers
+func (c *Client) GetDefinition(ctx context.Context, definitionID int) (*build.BuildDefinition, error) {
+	project := c.GetProject()
+	args := build.GetDefinitionArgs{
+		Project:      &project,
+		DefinitionId: &definitionID,
+	}
+
+	definition, err := c.BuildClient.GetDefinition(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get build definition %d: %w", definitionID, err)
+	}
+
+	return definition, nil
+}
+
+// UpdateDefinition saves a build definition. Secret variables sent without a value
+// keep the value stored in the current revision.
+func (c *Client) UpdateDefinition(ctx context.Context, definition *build.BuildDefinition) (*build.BuildDefinition, error) {
+	if definition.Id == nil {
+		return nil, fmt.Errorf("build definition has no ID")
+	}
+	project := c.GetProject()
+	args := build.UpdateDefinitionArgs{
+		Project:                         &project,
+		DefinitionId:                    definition.Id,
+		Definition:                      definition,
+		SecretsSourceDefinitionId:       definition.Id,
+		SecretsSourceDefinitionRevision: definition.Revision,
+	}
+
+	updated, err := c.BuildClient.UpdateDefinition(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update build definition %d: %w", *definition.Id, err)
+	}
+
+	return updated, nil
+}
+
+// GetBuildTimeline returns the timeline of a build: its stages, jobs and tasks
+func (c *Client) GetBuildTimeline(ctx context.Context, buildID int) (*build.Timeline, error) {
+	project := c.GetProject()
+	args := build.GetBuildTimelineArgs{
+		Project: &project,
+		BuildId: &buildID,
+	}
+
+	result, err := c.BuildClient.GetBuildTimeline(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get build timeline: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetBuildLogLines returns the lines of a build log from startLine (1-based) on;
+// a startLine of 0 returns the whole log
+func (c *Client) GetBuildLogLines(ctx context.Context, buildID, logID int, startLine uint64) ([]string, error) {
+	project := c.GetProject()
+	args := build.GetBuildLogLinesArgs{
+		Project: &project,
+		BuildId: &buildID,
+		LogId:   &logID,
+	}
+	if startLine > 0 {
+		args.StartLine = &startLine
+	}
+
+	result, err := c.BuildClient.GetBuildLogLines(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get build log %d: %w", logID, err)
+	}
+
+	if result == nil {
+		return []string{}, nil
+	}
+
+	return *result, nil
+}
+
+// CancelBuild requests cancellation of a queued or running build
+func (c *Client) CancelBuild(ctx context.Context, buildID int) (*build.Build, error) {
+	project := c.GetProject()
+	args := build.UpdateBuildArgs{
+		Project: &project,
+		BuildId: &buildID,
+		Build:   &build.Build{Status: &build.BuildStatusValues.Cancelling},
+	}
+
+	result, err := c.BuildClient.UpdateBuild(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel build %d: %w", buildID, err)
+	}
+
+	return result, nil
+}
+
+// RetryBuild reruns the failed and canceled jobs of a completed build in place
+func (c *Client) RetryBuild(ctx context.Context, buildID int) (*build.Build, error) {
+	project := c.GetProject()
+	args := build.UpdateBuildArgs{
+		Project: &project,
+		BuildId: &buildID,
+		Build:   &build.Build{},
+		Retry:   utils.Ptr(true),
+	}
+
+	result, err := c.BuildClient.UpdateBuild(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retry build %d: %w", buildID, err)
+	}
+
+	return result, nil
+}
+
+// QueueBuild queues a new build
+func (c *Client) QueueBuild(ctx context.Context, b *build.Build) (*build.Build, error) {
+	project := c.GetProject()
+	args := build.QueueBuildArgs{
+		Project: &project,
+		Build:   b,
+	}
+
+	result, err := c.BuildClient.QueueBuild(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue build: %w", err)
+	}
+
+	return result, nil
+}
+
+// AddRetentionLease retains a build for the given number of days
+func (c *Client) AddRetentionLease(ctx context.Context, buildID, definitionID, days int, ownerID string) error {
+	project := c.GetProject()
+	leases := []build.NewRetentionLease{{
+		RunId:        &buildID,
+		DefinitionId: &definitionID,
+		DaysValid:    &days,
+		OwnerId:      &ownerID,
+	}}
+	args := build.AddRetentionLeasesArgs{
+		Project:   &project,
+		NewLeases: &leases,
+	}
+
+	if _, err := c.BuildClient.AddRetentionLeases(ctx, args); err != nil {
+		return fmt.Errorf("failed to retain build %d: %w", buildID, err)
+	}
+
+	return nil
+}
+
+// AddBuildTags adds tags to a build and returns its resulting tags
+func (c *Client) AddBuildTags(ctx context.Context, buildID int, tags []string) ([]string, error) {
+	project := c.GetProject()
+	args := build.AddBuildTagsArgs{
+		Project: &project,
+		BuildId: &buildID,
+		Tags:    &tags,
+	}
+
+	result, err := c.BuildClient.AddBuildTags(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to tag build %d: %w", buildID, err)
+	}
+	if result == nil {
+		return []string{}, nil
+	}
+
+	return *result, nil
+}
+
+// DeleteBuildTag removes a tag from a build and returns its remaining tags
+func (c *Client) DeleteBuildTag(ctx context.Context, buildID int, tag string) ([]string, error) {
+	project := c.GetProject()
+	args := build.DeleteBuildTagArgs{
+		Project: &project,
+		BuildId: &buildID,
+		Tag:     &tag,
+	}
+
+	result, err := c.BuildClient.DeleteBuildTag(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove tag from build %d: %w", buildID, err)
+	}
+	if result == nil {
+		return []string{}, nil
+	}
+
+	return *result, nil
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -12,7 +13,13 @@ import (
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/build"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/identity"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/location"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/pipelines"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/policy"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/release"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/test"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/workitemtracking"
 )
 
@@ -28,6 +35,14 @@ type Client struct {
 	ReleaseClient  release.Client
 	WorkItemClient workitemtracking.Client
 	CoreClient     core.Client
+	LocationClient location.Client
+	PolicyClient   policy.Client
+	IdentityClient identity.Client
+	// PipelinesClient runs YAML pipelines with template parameters
+	PipelinesClient pipelines.Client
+	TestClient      test.Client
+	// TaskAgentClient manages library variable groups
+	TaskAgentClient taskagent.Client
 }
 
 func init() {
@@ -85,14 +100,44 @@ func NewClientWithConfig(cfg *config.Config) (*Client, error) {
 		return nil, fmt.Errorf("failed to create core client: %w", err)
 	}
 
+	locationClient := location.NewClient(ctx, connection)
+
+	policyClient, err := policy.NewClient(ctx, connection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create policy client: %w", err)
+	}
+
+	identityClient, err := identity.NewClient(ctx, connection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create identity client: %w", err)
+	}
+
+	pipelinesClient := pipelines.NewClient(ctx, connection)
+
+	testClient, err := test.NewClient(ctx, connection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create test client: %w", err)
+	}
+
+	taskAgentClient, err := taskagent.NewClient(ctx, connection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create task agent client: %w", err)
+	}
+
 	return &Client{
-		config:         &cfg.Azure,
-		Connection:     connection,
-		GitClient:      gitClient,
-		BuildClient:    buildClient,
-		ReleaseClient:  releaseClient,
-		WorkItemClient: workItemClient,
-		CoreClient:     coreClient,
+		config:          &cfg.Azure,
+		Connection:      connection,
+		GitClient:       gitClient,
+		BuildClient:     buildClient,
+		ReleaseClient:   releaseClient,
+		WorkItemClient:  workItemClient,
+		CoreClient:      coreClient,
+		LocationClient:  locationClient,
+		PolicyClient:    policyClient,
+		IdentityClient:  identityClient,
+		PipelinesClient: pipelinesClient,
+		TestClient:      testClient,
+		TaskAgentClient: taskAgentClient,
 	}, nil
 }
 
@@ -107,3 +152,17 @@ func (c *Client) GetOrganization() string {
 func (c *Client) GetProject() string {
 	return c.config.Project
 }
+
+// IsBadRequest reports whether err is an HTTP 400 response from Azure DevOps,
+// which it returns when it rejects the content of a request, e.g. invalid YAML
+func IsBadRequest(err error) bool {
+	var wrapped azuredevops.WrappedError
+	if errors.As(err, &wrapped) {
+		return wrapped.StatusCode != nil && *wrapped.StatusCode == http.StatusBadRequest
+	}
+	var wrappedPtr *azuredevops.WrappedError
+	if errors.As(err, &wrappedPtr) && wrappedPtr != nil {
+		return wrappedPtr.StatusCode != nil && *wrappedPtr.StatusCode == http.StatusBadRequest
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+)
+
+func TestIsBadRequest(t *testing.T) {
+	status := func(code int) *int { return &code }
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"plain error", errors.New("connection refused"), false},
+		{"bad request value", azuredevops.WrappedError{StatusCode: status(http.StatusBadRequest)}, true},
+		{"bad request pointer", &azuredevops.WrappedError{StatusCode: status(http.StatusBadRequest)}, true},
+		{"wrapped bad request", fmt.Errorf("failed to preview pipeline 1: %w", azuredevops.WrappedError{StatusCode: status(http.StatusBadRequest)}), true},
+		{"unauthorized", azuredevops.WrappedError{StatusCode: status(http.StatusUnauthorized)}, false},
+		{"no status", azuredevops.WrappedError{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsBadRequest(tt.err); got != tt.want {
+				t.Errorf("IsBadRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
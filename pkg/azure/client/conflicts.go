@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+)
+
+// pullRequestConflictsLocationID is the REST location of the pull request conflicts
+// resource, which the generated git client does not expose.
+var pullRequestConflictsLocationID = uuid.MustParse("d840fb74-bbef-42d3-b250-564604c054a4")
+
+// GetPullRequestConflicts returns the unresolved merge conflicts of a pull request.
+// Only the common conflict fields are decoded; type-specific blobs are ignored.
+func (c *Client) GetPullRequestConflicts(ctx context.Context, repositoryID string, pullRequestID int) ([]git.GitConflict, error) {
+	gitClient, err := c.Connection.GetClientByResourceAreaId(ctx, git.ResourceAreaId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git client: %w", err)
+	}
+
+	routeValues := map[string]string{
+		"project":       c.GetProject(),
+		"repositoryId":  repositoryID,
+		"pullRequestId": strconv.Itoa(pullRequestID),
+	}
+	queryParams := url.Values{}
+	queryParams.Add("excludeResolved", "true")
+
+	resp, err := gitClient.Send(ctx, http.MethodGet, pullRequestConflictsLocationID, "7.1-preview.1", routeValues, queryParams, nil, "", "application/json", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conflicts for PR %d: %w", pullRequestID, err)
+	}
+
+	var conflicts []git.GitConflict
+	if err := gitClient.UnmarshalCollectionBody(resp, &conflicts); err != nil {
+		return nil, fmt.Errorf("failed to parse conflicts for PR %d: %w", pullRequestID, err)
+	}
+
+	return conflicts, nil
+}
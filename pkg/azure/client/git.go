@@ -3,9 +3,11 @@ package client
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"adoctl/pkg/utils"
 
+	"github.com/google/uuid"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/webapi"
 )
@@ -72,6 +74,77 @@ func (c *Client) GetPullRequestsByProject(ctx context.Context, criteria *git.Git
 	return *result, nil
 }
 
+// completedPullRequestLookback bounds how long before since a pull request may have
+// been created and still count as closed since then
+const completedPullRequestLookback = 90 * 24 * time.Hour
+
+// GetCompletedPullRequestsSince returns the completed pull requests of the project
+// closed since the given time. The API cannot filter by close time and lists pull
+// requests newest created first, so pages are read until one ends with a pull
+// request created more than completedPullRequestLookback before since. Pull requests
+// that stayed open longer than that are not returned.
+func (c *Client) GetCompletedPullRequestsSince(ctx context.Context, since time.Time) ([]git.GitPullRequest, error) {
+	oldest := since.Add(-completedPullRequestLookback)
+
+	prs := []git.GitPullRequest{}
+	err := c.pagePullRequests(ctx, git.PullRequestStatusValues.Completed, func(page []git.GitPullRequest) bool {
+		for _, pr := range page {
+			if pr.ClosedDate != nil && !pr.ClosedDate.Time.Before(since) {
+				prs = append(prs, pr)
+			}
+		}
+		last := page[len(page)-1]
+		return last.CreationDate == nil || !last.CreationDate.Time.Before(oldest)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get completed pull requests: %w", err)
+	}
+
+	return prs, nil
+}
+
+// GetActivePullRequests returns every active pull request of the project
+func (c *Client) GetActivePullRequests(ctx context.Context) ([]git.GitPullRequest, error) {
+	prs := []git.GitPullRequest{}
+	err := c.pagePullRequests(ctx, git.PullRequestStatusValues.Active, func(page []git.GitPullRequest) bool {
+		prs = append(prs, page...)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active pull requests: %w", err)
+	}
+
+	return prs, nil
+}
+
+// pagePullRequests reads the pull requests of the project with the given status,
+// newest created first, 100 at a time. Each non-empty page is passed to visit,
+// which returns false to stop; reading also stops after a short page.
+func (c *Client) pagePullRequests(ctx context.Context, status git.PullRequestStatus, visit func(page []git.GitPullRequest) bool) error {
+	const pageSize = 100
+
+	project := c.GetProject()
+	top := pageSize
+
+	for skip := 0; ; skip += pageSize {
+		result, err := c.GitClient.GetPullRequestsByProject(ctx, git.GetPullRequestsByProjectArgs{
+			Project:        &project,
+			SearchCriteria: &git.GitPullRequestSearchCriteria{Status: &status},
+			Skip:           &skip,
+			Top:            &top,
+		})
+		if err != nil {
+			return err
+		}
+		if result == nil || len(*result) == 0 {
+			return nil
+		}
+		if !visit(*result) || len(*result) < pageSize {
+			return nil
+		}
+	}
+}
+
 func (c *Client) UpdatePullRequest(ctx context.Context, repositoryID string, pullRequestID int, pullRequest *git.GitPullRequest) (*git.GitPullRequest, error) {
 	args := git.UpdatePullRequestArgs{
 		RepositoryId:           &repositoryID,
@@ -236,28 +309,29 @@ func (c *Client) MergePullRequest(ctx context.Context, repositoryID string, pull
 	}
 
 	if completionOptions != nil {
-		sdkCompletionOptions := &git.GitPullRequestCompletionOptions{}
+		updatedPR.CompletionOptions = toSDKCompletionOptions(completionOptions)
+	}
 
-		if completionOptions.DeleteSourceBranch != nil {
-			sdkCompletionOptions.DeleteSourceBranch = completionOptions.DeleteSourceBranch
-		}
-		if completionOptions.SquashMerge != nil {
-			sdkCompletionOptions.SquashMerge = completionOptions.SquashMerge
-		}
-		if completionOptions.MergeCommitMessage != nil {
-			sdkCompletionOptions.MergeCommitMessage = completionOptions.MergeCommitMessage
-		}
-		if completionOptions.BypassPolicy != nil {
-			sdkCompletionOptions.BypassPolicy = completionOptions.BypassPolicy
-		}
-		if completionOptions.BypassReason != nil {
-			sdkCompletionOptions.BypassReason = completionOptions.BypassReason
-		}
-		if completionOptions.TransitionWorkItems != nil {
-			sdkCompletionOptions.TransitionWorkItems = completionOptions.TransitionWorkItems
-		}
+	args := git.UpdatePullRequestArgs{
+		RepositoryId:           &repositoryID,
+		PullRequestId:          &pullRequestID,
+		GitPullRequestToUpdate: updatedPR,
+	}
+
+	return c.GitClient.UpdatePullRequest(ctx, args)
+}
+
+// SetPullRequestAutoComplete arms auto-complete on a pull request on behalf of
+// setByID. The PR completes as soon as all policies pass.
+func (c *Client) SetPullRequestAutoComplete(ctx context.Context, repositoryID string, pullRequestID int, setByID string, completionOptions *GitPullRequestCompletionOptions) (*git.GitPullRequest, error) {
+	updatedPR := &git.GitPullRequest{
+		AutoCompleteSetBy: &webapi.IdentityRef{
+			Id: &setByID,
+		},
+	}
 
-		updatedPR.CompletionOptions = sdkCompletionOptions
+	if completionOptions != nil {
+		updatedPR.CompletionOptions = toSDKCompletionOptions(completionOptions)
 	}
 
 	args := git.UpdatePullRequestArgs{
@@ -266,7 +340,36 @@ func (c *Client) MergePullRequest(ctx context.Context, repositoryID string, pull
 		GitPullRequestToUpdate: updatedPR,
 	}
 
-	return c.GitClient.UpdatePullRequest(ctx, args)
+	result, err := c.GitClient.UpdatePullRequest(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set auto-complete: %w", err)
+	}
+
+	return result, nil
+}
+
+// CancelPullRequestAutoComplete clears auto-complete on a pull request.
+// Azure DevOps clears it when autoCompleteSetBy is set to the empty GUID.
+func (c *Client) CancelPullRequestAutoComplete(ctx context.Context, repositoryID string, pullRequestID int) (*git.GitPullRequest, error) {
+	emptyID := uuid.Nil.String()
+	updatedPR := &git.GitPullRequest{
+		AutoCompleteSetBy: &webapi.IdentityRef{
+			Id: &emptyID,
+		},
+	}
+
+	args := git.UpdatePullRequestArgs{
+		RepositoryId:           &repositoryID,
+		PullRequestId:          &pullRequestID,
+		GitPullRequestToUpdate: updatedPR,
+	}
+
+	result, err := c.GitClient.UpdatePullRequest(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel auto-complete: %w", err)
+	}
+
+	return result, nil
 }
 
 func (c *Client) AbandonPullRequest(ctx context.Context, repositoryID string, pullRequestID int, abortMessage string) (*git.GitPullRequest, error) {
@@ -341,6 +444,183 @@ func (c *Client) RemovePullRequestReviewer(ctx context.Context, repositoryID str
 	return c.GitClient.DeletePullRequestReviewer(ctx, args)
 }
 
+func (c *Client) GetPullRequestThreads(ctx context.Context, repositoryID string, pullRequestID int) ([]git.GitPullRequestCommentThread, error) {
+	args := git.GetThreadsArgs{
+		RepositoryId:  &repositoryID,
+		PullRequestId: &pullRequestID,
+	}
+
+	result, err := c.GitClient.GetThreads(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request threads: %w", err)
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+
+	return *result, nil
+}
+
+// CreatePullRequestComment starts a new active comment thread on a pull request.
+func (c *Client) CreatePullRequestComment(ctx context.Context, repositoryID string, pullRequestID int, content string) (*git.GitPullRequestCommentThread, error) {
+	status := git.CommentThreadStatusValues.Active
+	args := git.CreateThreadArgs{
+		RepositoryId:  &repositoryID,
+		PullRequestId: &pullRequestID,
+		CommentThread: &git.GitPullRequestCommentThread{
+			Comments: &[]git.Comment{
+				{
+					Content:     &content,
+					CommentType: &git.CommentTypeValues.Text,
+				},
+			},
+			Status: &status,
+		},
+	}
+
+	result, err := c.GitClient.CreateThread(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request comment: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetPullRequestCommits returns the first page of commits of a pull request, newest first.
+func (c *Client) GetPullRequestCommits(ctx context.Context, repositoryID string, pullRequestID int) ([]git.GitCommitRef, error) {
+	args := git.GetPullRequestCommitsArgs{
+		RepositoryId:  &repositoryID,
+		PullRequestId: &pullRequestID,
+	}
+
+	result, err := c.GitClient.GetPullRequestCommits(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request commits: %w", err)
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+
+	return result.Value, nil
+}
+
+// GetPullRequestsByCommit returns the pull requests that created the commit as their
+// merge commit, followed by the pull requests that merged the commit from their source
+// branch. The commit must be a full SHA.
+func (c *Client) GetPullRequestsByCommit(ctx context.Context, repositoryID, commitID string) ([]git.GitPullRequest, error) {
+	project := c.GetProject()
+	items := []string{commitID}
+	queries := []git.GitPullRequestQueryInput{
+		{Type: &git.GitPullRequestQueryTypeValues.LastMergeCommit, Items: &items},
+		{Type: &git.GitPullRequestQueryTypeValues.Commit, Items: &items},
+	}
+
+	result, err := c.GitClient.GetPullRequestQuery(ctx, git.GetPullRequestQueryArgs{
+		Project:      &project,
+		RepositoryId: &repositoryID,
+		Queries:      &git.GitPullRequestQuery{Queries: &queries},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pull requests for commit %s: %w", commitID, err)
+	}
+
+	prs := []git.GitPullRequest{}
+	if result == nil || result.Results == nil {
+		return prs, nil
+	}
+
+	seen := map[int]bool{}
+	for _, byCommit := range *result.Results {
+		for _, pr := range byCommit[commitID] {
+			if pr.PullRequestId == nil || seen[*pr.PullRequestId] {
+				continue
+			}
+			seen[*pr.PullRequestId] = true
+			prs = append(prs, pr)
+		}
+	}
+
+	return prs, nil
+}
+
+// GetPullRequestChangedFilesCount returns the number of files changed in the latest iteration of a PR.
+func (c *Client) GetPullRequestChangedFilesCount(ctx context.Context, repositoryID string, pullRequestID int) (int, error) {
+	iterations, err := c.GitClient.GetPullRequestIterations(ctx, git.GetPullRequestIterationsArgs{
+		RepositoryId:  &repositoryID,
+		PullRequestId: &pullRequestID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pull request iterations: %w", err)
+	}
+
+	if iterations == nil || len(*iterations) == 0 {
+		return 0, nil
+	}
+
+	latest := (*iterations)[len(*iterations)-1]
+	if latest.Id == nil {
+		return 0, nil
+	}
+
+	count := 0
+	skip := 0
+	for {
+		changes, err := c.GitClient.GetPullRequestIterationChanges(ctx, git.GetPullRequestIterationChangesArgs{
+			RepositoryId:  &repositoryID,
+			PullRequestId: &pullRequestID,
+			IterationId:   latest.Id,
+			Top:           utils.Ptr(2000),
+			Skip:          &skip,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to get pull request changes: %w", err)
+		}
+
+		if changes == nil || changes.ChangeEntries == nil {
+			break
+		}
+		count += len(*changes.ChangeEntries)
+
+		if changes.NextSkip == nil || *changes.NextSkip == 0 {
+			break
+		}
+		skip = *changes.NextSkip
+	}
+
+	return count, nil
+}
+
+func toSDKCompletionOptions(completionOptions *GitPullRequestCompletionOptions) *git.GitPullRequestCompletionOptions {
+	sdkCompletionOptions := &git.GitPullRequestCompletionOptions{}
+
+	if completionOptions.DeleteSourceBranch != nil {
+		sdkCompletionOptions.DeleteSourceBranch = completionOptions.DeleteSourceBranch
+	}
+	if completionOptions.SquashMerge != nil {
+		sdkCompletionOptions.SquashMerge = completionOptions.SquashMerge
+	}
+	if completionOptions.MergeCommitMessage != nil {
+		sdkCompletionOptions.MergeCommitMessage = completionOptions.MergeCommitMessage
+	}
+	if completionOptions.MergeStrategy != nil {
+		strategy := git.GitPullRequestMergeStrategy(*completionOptions.MergeStrategy)
+		sdkCompletionOptions.MergeStrategy = &strategy
+	}
+	if completionOptions.BypassPolicy != nil {
+		sdkCompletionOptions.BypassPolicy = completionOptions.BypassPolicy
+	}
+	if completionOptions.BypassReason != nil {
+		sdkCompletionOptions.BypassReason = completionOptions.BypassReason
+	}
+	if completionOptions.TransitionWorkItems != nil {
+		sdkCompletionOptions.TransitionWorkItems = completionOptions.TransitionWorkItems
+	}
+
+	return sdkCompletionOptions
+}
+
 func normalizeBranchName(branchName string) string {
 	if len(branchName) > 0 && branchName[0] == '/' {
 		branchName = branchName[1:]
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/identity"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/location"
+)
+
+// GetAuthenticatedUser returns the identity the personal access token belongs to.
+func (c *Client) GetAuthenticatedUser(ctx context.Context) (*identity.Identity, error) {
+	data, err := c.LocationClient.GetConnectionData(ctx, location.GetConnectionDataArgs{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection data: %w", err)
+	}
+
+	if data == nil || data.AuthenticatedUser == nil || data.AuthenticatedUser.Id == nil {
+		return nil, fmt.Errorf("authenticated user not found in connection data")
+	}
+
+	return data.AuthenticatedUser, nil
+}
+
+// GetIdentityGroupIDs returns the IDs of every group (including teams) the identity
+// is a member of, directly or through nested groups.
+func (c *Client) GetIdentityGroupIDs(ctx context.Context, identityID string) ([]string, error) {
+	membership := identity.QueryMembership(identity.QueryMembershipValues.ExpandedUp)
+	identities, err := c.IdentityClient.ReadIdentities(ctx, identity.ReadIdentitiesArgs{
+		IdentityIds:     &identityID,
+		QueryMembership: &membership,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read identity memberships: %w", err)
+	}
+
+	if identities == nil || len(*identities) == 0 || (*identities)[0].MemberOf == nil {
+		return []string{}, nil
+	}
+
+	descriptors := *(*identities)[0].MemberOf
+	if len(descriptors) == 0 {
+		return []string{}, nil
+	}
+
+	joined := strings.Join(descriptors, ",")
+	groups, err := c.IdentityClient.ReadIdentities(ctx, identity.ReadIdentitiesArgs{
+		Descriptors: &joined,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve group identities: %w", err)
+	}
+
+	if groups == nil {
+		return []string{}, nil
+	}
+
+	ids := make([]string, 0, len(*groups))
+	for _, group := range *groups {
+		if group.Id != nil {
+			ids = append(ids, group.Id.String())
+		}
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/pipelines"
+)
+
+// RunPipeline queues a run of a pipeline with the given run parameters
+func (c *Client) RunPipeline(ctx context.Context, pipelineID int, params *pipelines.RunPipelineParameters) (*pipelines.Run, error) {
+	project := c.GetProject()
+	args := pipelines.RunPipelineArgs{
+		Project:       &project,
+		PipelineId:    &pipelineID,
+		RunParameters: params,
+	}
+
+	run, err := c.PipelinesClient.RunPipeline(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run pipeline %d: %w", pipelineID, err)
+	}
+
+	return run, nil
+}
+
+// PreviewPipeline dry-runs a pipeline and returns its fully expanded YAML
+func (c *Client) PreviewPipeline(ctx context.Context, pipelineID int, params *pipelines.RunPipelineParameters) (string, error) {
+	project := c.GetProject()
+	args := pipelines.PreviewArgs{
+		Project:       &project,
+		PipelineId:    &pipelineID,
+		RunParameters: params,
+	}
+
+	preview, err := c.PipelinesClient.Preview(ctx, args)
+	if err != nil {
+		return "", fmt.Errorf("failed to preview pipeline %d: %w", pipelineID, err)
+	}
+	if preview == nil || preview.FinalYaml == nil {
+		return "", nil
+	}
+
+	return *preview.FinalYaml, nil
+}
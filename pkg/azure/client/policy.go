@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/policy"
+)
+
+// GetPullRequestPolicyEvaluations returns the branch policy evaluations for a pull request.
+// The projectID must be the project GUID, as it is part of the policy artifact ID.
+func (c *Client) GetPullRequestPolicyEvaluations(ctx context.Context, projectID string, pullRequestID int) ([]policy.PolicyEvaluationRecord, error) {
+	project := c.GetProject()
+	artifactID := fmt.Sprintf("vstfs:///CodeReview/CodeReviewId/%s/%d", projectID, pullRequestID)
+
+	args := policy.GetPolicyEvaluationsArgs{
+		Project:    &project,
+		ArtifactId: &artifactID,
+	}
+
+	result, err := c.PolicyClient.GetPolicyEvaluations(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy evaluations for PR %d: %w", pullRequestID, err)
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+
+	return *result, nil
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+)
+
+func newRefOperationParameters(pullRequestID int, ontoRefName, generatedRefName string) *git.GitAsyncRefOperationParameters {
+	return &git.GitAsyncRefOperationParameters{
+		OntoRefName:      &ontoRefName,
+		GeneratedRefName: &generatedRefName,
+		Source: &git.GitAsyncRefOperationSource{
+			PullRequestId: &pullRequestID,
+		},
+	}
+}
+
+// CreateCherryPick starts a server-side cherry-pick of a pull request's changes onto ontoRefName.
+// The result is written to generatedRefName, which must not exist yet.
+func (c *Client) CreateCherryPick(ctx context.Context, repositoryID string, pullRequestID int, ontoRefName, generatedRefName string) (*git.GitCherryPick, error) {
+	project := c.GetProject()
+	args := git.CreateCherryPickArgs{
+		Project:            &project,
+		RepositoryId:       &repositoryID,
+		CherryPickToCreate: newRefOperationParameters(pullRequestID, ontoRefName, generatedRefName),
+	}
+
+	result, err := c.GitClient.CreateCherryPick(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cherry-pick: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c *Client) GetCherryPick(ctx context.Context, repositoryID string, cherryPickID int) (*git.GitCherryPick, error) {
+	project := c.GetProject()
+	args := git.GetCherryPickArgs{
+		Project:      &project,
+		RepositoryId: &repositoryID,
+		CherryPickId: &cherryPickID,
+	}
+
+	result, err := c.GitClient.GetCherryPick(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cherry-pick %d: %w", cherryPickID, err)
+	}
+
+	return result, nil
+}
+
+// CreateRevert starts a server-side revert of a completed pull request onto ontoRefName.
+// The result is written to generatedRefName, which must not exist yet.
+func (c *Client) CreateRevert(ctx context.Context, repositoryID string, pullRequestID int, ontoRefName, generatedRefName string) (*git.GitRevert, error) {
+	project := c.GetProject()
+	args := git.CreateRevertArgs{
+		Project:        &project,
+		RepositoryId:   &repositoryID,
+		RevertToCreate: newRefOperationParameters(pullRequestID, ontoRefName, generatedRefName),
+	}
+
+	result, err := c.GitClient.CreateRevert(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create revert: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c *Client) GetRevert(ctx context.Context, repositoryID string, revertID int) (*git.GitRevert, error) {
+	project := c.GetProject()
+	args := git.GetRevertArgs{
+		Project:      &project,
+		RepositoryId: &repositoryID,
+		RevertId:     &revertID,
+	}
+
+	result, err := c.GitClient.GetRevert(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revert %d: %w", revertID, err)
+	}
+
+	return result, nil
+}
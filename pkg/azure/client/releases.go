@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/release"
+)
+
+// FindReleaseDefinitions returns the release definitions whose name matches name exactly
+func (c *Client) FindReleaseDefinitions(ctx context.Context, name string) ([]release.ReleaseDefinition, error) {
+	project := c.GetProject()
+	exact := true
+	args := release.GetReleaseDefinitionsArgs{
+		Project:          &project,
+		SearchText:       &name,
+		IsExactNameMatch: &exact,
+	}
+
+	result, err := c.ReleaseClient.GetReleaseDefinitions(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release definitions: %w", err)
+	}
+
+	return result.Value, nil
+}
+
+// GetReleaseDefinition returns a release definition with its artifacts and environments
+func (c *Client) GetReleaseDefinition(ctx context.Context, definitionID int) (*release.ReleaseDefinition, error) {
+	project := c.GetProject()
+	args := release.GetReleaseDefinitionArgs{
+		Project:      &project,
+		DefinitionId: &definitionID,
+	}
+
+	result, err := c.ReleaseClient.GetReleaseDefinition(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release definition %d: %w", definitionID, err)
+	}
+
+	return result, nil
+}
+
+// CreateRelease creates a release of a definition
+func (c *Client) CreateRelease(ctx context.Context, metadata *release.ReleaseStartMetadata) (*release.Release, error) {
+	project := c.GetProject()
+	args := release.CreateReleaseArgs{
+		Project:              &project,
+		ReleaseStartMetadata: metadata,
+	}
+
+	result, err := c.ReleaseClient.CreateRelease(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create release: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetRelease returns a release with its environments
+func (c *Client) GetRelease(ctx context.Context, releaseID int) (*release.Release, error) {
+	project := c.GetProject()
+	args := release.GetReleaseArgs{
+		Project:   &project,
+		ReleaseId: &releaseID,
+	}
+
+	result, err := c.ReleaseClient.GetRelease(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release %d: %w", releaseID, err)
+	}
+
+	return result, nil
+}
+
+// DeployReleaseEnvironment starts, or restarts, the deployment of a release environment
+func (c *Client) DeployReleaseEnvironment(ctx context.Context, releaseID, environmentID int, comment string) (*release.ReleaseEnvironment, error) {
+	project := c.GetProject()
+	status := release.EnvironmentStatusValues.InProgress
+	args := release.UpdateReleaseEnvironmentArgs{
+		Project:       &project,
+		ReleaseId:     &releaseID,
+		EnvironmentId: &environmentID,
+		EnvironmentUpdateData: &release.ReleaseEnvironmentUpdateMetadata{
+			Status:  &status,
+			Comment: &comment,
+		},
+	}
+
+	result, err := c.ReleaseClient.UpdateReleaseEnvironment(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy environment %d of release %d: %w", environmentID, releaseID, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"adoctl/pkg/utils"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/test"
+)
+
+const testResultsPageSize = 1000
+
+// GetTestRunsForBuild returns the test runs published by a build, with their statistics
+func (c *Client) GetTestRunsForBuild(ctx context.Context, buildID int) ([]test.TestRun, error) {
+	project := c.GetProject()
+	buildURI := fmt.Sprintf("vstfs:///Build/Build/%d", buildID)
+	args := test.GetTestRunsArgs{
+		Project:           &project,
+		BuildUri:          &buildURI,
+		IncludeRunDetails: utils.Ptr(true),
+	}
+
+	result, err := c.TestClient.GetTestRuns(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test runs: %w", err)
+	}
+	if result == nil {
+		return []test.TestRun{}, nil
+	}
+
+	return *result, nil
+}
+
+// GetTestResults returns the results of a test run with one of the given outcomes
+func (c *Client) GetTestResults(ctx context.Context, runID int, outcomes []test.TestOutcome) ([]test.TestCaseResult, error) {
+	project := c.GetProject()
+	results := []test.TestCaseResult{}
+
+	for skip := 0; ; skip += testResultsPageSize {
+		args := test.GetTestResultsArgs{
+			Project:  &project,
+			RunId:    &runID,
+			Outcomes: &outcomes,
+			Top:      utils.Ptr(testResultsPageSize),
+			Skip:     utils.Ptr(skip),
+		}
+
+		page, err := c.TestClient.GetTestResults(ctx, args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get results of test run %d: %w", runID, err)
+		}
+		if page == nil {
+			break
+		}
+
+		results = append(results, *page...)
+		if len(*page) < testResultsPageSize {
+			break
+		}
+	}
+
+	return results, nil
+}
@@ -469,51 +469,9 @@ type AzureDeployment struct {
 	LastModifiedBy          *IdentityRef                        `json:"lastModifiedBy,omitempty"`
 	Conditions              []Condition                         `json:"conditions,omitempty"`
 	ScheduledDeploymentTime string                              `json:"scheduledDeploymentTime,omitempty"`
-	PreDeployApprovals      []ReleaseApproval                   `json:"preDeployApprovals,omitempty"`
-	PostDeployApprovals     []ReleaseApproval                   `json:"postDeployApprovals,omitempty"`
 	Links                   *ReferenceLinks                     `json:"_links,omitempty"`
 }
 
-type ReleaseApproval struct {
-	Id                 int32                               `json:"id,omitempty"`
-	ApprovalType       ApprovalType                        `json:"approvalType,omitempty"`
-	Approver           *IdentityRef                        `json:"approver,omitempty"`
-	ApprovedBy         *IdentityRef                        `json:"approvedBy,omitempty"`
-	Attempt            int32                               `json:"attempt,omitempty"`
-	Comments           string                              `json:"comments,omitempty"`
-	CreatedOn          string                              `json:"createdOn,omitempty"`
-	ModifiedOn         string                              `json:"modifiedOn,omitempty"`
-	Rank               int32                               `json:"rank,omitempty"`
-	Revision           int32                               `json:"revision,omitempty"`
-	Status             ApprovalStatus                      `json:"status,omitempty"`
-	IsAutomated        bool                                `json:"isAutomated,omitempty"`
-	Release            *ReleaseShallowReference            `json:"release,omitempty"`
-	ReleaseDefinition  *ReleaseDefinitionShallowReference  `json:"releaseDefinition,omitempty"`
-	ReleaseEnvironment *ReleaseEnvironmentShallowReference `json:"releaseEnvironment,omitempty"`
-	Url                string                              `json:"url,omitempty"`
-}
-
-type ApprovalType string
-
-const (
-	ApprovalTypeUndefined  ApprovalType = "undefined"
-	ApprovalTypePreDeploy  ApprovalType = "preDeploy"
-	ApprovalTypePostDeploy ApprovalType = "postDeploy"
-	ApprovalTypeAll        ApprovalType = "all"
-)
-
-type ApprovalStatus string
-
-const (
-	ApprovalStatusUndefined  ApprovalStatus = "undefined"
-	ApprovalStatusPending    ApprovalStatus = "pending"
-	ApprovalStatusApproved   ApprovalStatus = "approved"
-	ApprovalStatusRejected   ApprovalStatus = "rejected"
-	ApprovalStatusReassigned ApprovalStatus = "reassigned"
-	ApprovalStatusCanceled   ApprovalStatus = "canceled"
-	ApprovalStatusSkipped    ApprovalStatus = "skipped"
-)
-
 type Condition struct {
 	ConditionType ConditionType `json:"conditionType,omitempty"`
 	Name          string        `json:"name,omitempty"`
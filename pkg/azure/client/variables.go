@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
+)
+
+// GetVariableGroups returns the variable groups of the project
+func (c *Client) GetVariableGroups(ctx context.Context) ([]taskagent.VariableGroup, error) {
+	project := c.GetProject()
+	order := taskagent.VariableGroupQueryOrderValues.IdAscending
+	args := taskagent.GetVariableGroupsArgs{
+		Project:    &project,
+		QueryOrder: &order,
+	}
+
+	groups, err := c.TaskAgentClient.GetVariableGroups(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variable groups: %w", err)
+	}
+	if groups == nil {
+		return []taskagent.VariableGroup{}, nil
+	}
+
+	return *groups, nil
+}
+
+// GetVariableGroup returns a variable group with its variables. Secret values
+// are never returned.
+func (c *Client) GetVariableGroup(ctx context.Context, groupID int) (*taskagent.VariableGroup, error) {
+	project := c.GetProject()
+	args := taskagent.GetVariableGroupArgs{
+		Project: &project,
+		GroupId: &groupID,
+	}
+
+	group, err := c.TaskAgentClient.GetVariableGroup(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variable group %d: %w", groupID, err)
+	}
+	if group == nil {
+		return nil, fmt.Errorf("variable group %d not found", groupID)
+	}
+
+	return group, nil
+}
+
+// UpdateVariableGroup replaces a variable group. Secret variables sent without a
+// value keep their stored value.
+func (c *Client) UpdateVariableGroup(ctx context.Context, groupID int, params *taskagent.VariableGroupParameters) (*taskagent.VariableGroup, error) {
+	args := taskagent.UpdateVariableGroupArgs{
+		GroupId:                 &groupID,
+		VariableGroupParameters: params,
+	}
+
+	group, err := c.TaskAgentClient.UpdateVariableGroup(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update variable group %d: %w", groupID, err)
+	}
+
+	return group, nil
+}
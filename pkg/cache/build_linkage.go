@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// buildLinkageJSON holds the fields of an Azure DevOps build used for linkage
+type buildLinkageJSON struct {
+	SourceBranch string `json:"sourceBranch"`
+	Reason       string `json:"reason"`
+	Repository   *struct {
+		ID string `json:"id"`
+	} `json:"repository"`
+	Definition *struct {
+		ID int `json:"id"`
+	} `json:"definition"`
+	RequestedFor *struct {
+		ID          string `json:"id"`
+		DisplayName string `json:"displayName"`
+	} `json:"requestedFor"`
+	TriggerInfo map[string]string `json:"triggerInfo"`
+}
+
+// FillLinkageFromJSON sets the repository, definition, reason, requester and
+// pull request fields of the build from its FullJSON.
+func (build *Build) FillLinkageFromJSON() error {
+	var data buildLinkageJSON
+	if err := json.Unmarshal([]byte(build.FullJSON), &data); err != nil {
+		return fmt.Errorf("failed to parse build %d: %w", build.BuildID, err)
+	}
+
+	build.Reason = data.Reason
+	if data.Repository != nil {
+		build.RepositoryID = data.Repository.ID
+	}
+	if data.Definition != nil {
+		build.DefinitionID = data.Definition.ID
+	}
+	if data.RequestedFor != nil {
+		build.RequestedForID = data.RequestedFor.ID
+		build.RequestedFor = data.RequestedFor.DisplayName
+	}
+
+	build.PullRequestID = 0
+	if id, err := strconv.Atoi(data.TriggerInfo["pr.number"]); err == nil {
+		build.PullRequestID = id
+	} else {
+		build.PullRequestID = pullRequestIDFromRef(data.SourceBranch)
+	}
+
+	return nil
+}
+
+// pullRequestIDFromRef extracts N from refs/pull/N/merge, or returns 0
+func pullRequestIDFromRef(ref string) int {
+	rest, ok := strings.CutPrefix(ref, "refs/pull/")
+	if !ok {
+		return 0
+	}
+	id, err := strconv.Atoi(strings.SplitN(rest, "/", 2)[0])
+	if err != nil {
+		return 0
+	}
+	return id
+}
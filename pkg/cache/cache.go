@@ -22,7 +22,12 @@ const SELECT_DEPLOYMENT_WHERE = `SELECT
 		source_version,
 		build_id,
 		full_json,
-		updated_at
+		updated_at,
+		deployment_id,
+		definition_id,
+		definition_name,
+		environment_id,
+		environment
 	FROM deployments WHERE 1=1 
 	`
 const SELECT_BUILDS_WHERE = `SELECT 
@@ -35,7 +40,13 @@ const SELECT_BUILDS_WHERE = `SELECT
 		status, 
 		result, 
 		full_json, 
-		updated_at
+		updated_at,
+		repository_id,
+		definition_id,
+		reason,
+		requested_for_id,
+		requested_for,
+		pr_id
 	FROM builds WHERE 1=1 
 	`
 
@@ -44,6 +55,7 @@ type CacheConfig struct {
 	UsersTTL        time.Duration
 	BuildsTTL       time.Duration
 	DeploymentsTTL  time.Duration
+	DefinitionsTTL  time.Duration
 }
 
 var DefaultCacheConfig = CacheConfig{
@@ -51,6 +63,7 @@ var DefaultCacheConfig = CacheConfig{
 	UsersTTL:        24 * time.Hour,
 	BuildsTTL:       1 * time.Hour,
 	DeploymentsTTL:  30 * time.Minute,
+	DefinitionsTTL:  24 * time.Hour,
 }
 
 type Manager struct {
@@ -74,8 +87,9 @@ type User struct {
 }
 
 type Build struct {
-	BuildID       int
-	Branch        string
+	BuildID int
+	Branch  string
+	// Repository is the pipeline definition name
 	Repository    string
 	SourceVersion string
 	StartTime     time.Time
@@ -84,6 +98,14 @@ type Build struct {
 	Result        string
 	FullJSON      string
 	UpdatedAt     time.Time
+	// RepositoryID is the ID of the repository the build ran on
+	RepositoryID   string
+	DefinitionID   int
+	Reason         string
+	RequestedForID string
+	RequestedFor   string
+	// PullRequestID is the PR that triggered the build, or 0
+	PullRequestID int
 }
 
 type Deployment struct {
@@ -98,6 +120,13 @@ type Deployment struct {
 	BuildID       int
 	FullJSON      string
 	UpdatedAt     time.Time
+	DeploymentID  int
+	// DefinitionID and DefinitionName identify the release definition
+	DefinitionID   int
+	DefinitionName string
+	// EnvironmentID is the definition environment, shared by all releases of the definition
+	EnvironmentID int
+	Environment   string
 }
 
 func NewManager(dbPath string) (*Manager, error) {
@@ -150,17 +179,11 @@ func (cm *Manager) init() error {
 			full_json TEXT NOT NULL,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 		)`,
-		`CREATE TABLE IF NOT EXISTS deployments (
-			release_id INTEGER PRIMARY KEY,
-			release_name TEXT NOT NULL,
-			status TEXT NOT NULL,
-			start_time DATETIME NOT NULL,
-			end_time DATETIME,
-			repository TEXT,
-			branch TEXT,
-			source_version TEXT,
-			build_id INTEGER,
-			full_json TEXT NOT NULL,
+		`CREATE TABLE IF NOT EXISTS deployments (` + deploymentsColumns + `)`,
+		`CREATE TABLE IF NOT EXISTS definitions (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			path TEXT NOT NULL,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 		)`,
 		`CREATE TABLE IF NOT EXISTS sync_metadata (
@@ -175,18 +198,250 @@ func (cm *Manager) init() error {
 		`CREATE INDEX IF NOT EXISTS idx_builds_repository ON builds(repository)`,
 		`CREATE INDEX IF NOT EXISTS idx_builds_source_version ON builds(source_version)`,
 		`CREATE INDEX IF NOT EXISTS idx_builds_start_time ON builds(start_time)`,
+		`CREATE INDEX IF NOT EXISTS idx_definitions_name ON definitions(name)`,
+	}
+
+	for _, query := range queries {
+		if _, err := cm.db.Exec(query); err != nil {
+			return fmt.Errorf("failed to create table: %w", err)
+		}
+	}
+
+	if err := cm.migrateBuilds(); err != nil {
+		return err
+	}
+	return cm.migrateDeployments()
+}
+
+// buildLinkageColumns are the builds columns added after the first release,
+// with the definition used to add them to existing databases
+var buildLinkageColumns = []struct {
+	name       string
+	definition string
+}{
+	{"repository_id", "TEXT NOT NULL DEFAULT ''"},
+	{"definition_id", "INTEGER NOT NULL DEFAULT 0"},
+	{"reason", "TEXT NOT NULL DEFAULT ''"},
+	{"requested_for_id", "TEXT NOT NULL DEFAULT ''"},
+	{"requested_for", "TEXT NOT NULL DEFAULT ''"},
+	{"pr_id", "INTEGER NOT NULL DEFAULT 0"},
+}
+
+// migrateBuilds adds the linkage columns to an existing builds table and fills
+// them for cached builds from their stored JSON.
+func (cm *Manager) migrateBuilds() error {
+	existing, err := cm.tableColumns("builds")
+	if err != nil {
+		return err
+	}
+
+	added := false
+	for _, column := range buildLinkageColumns {
+		if existing[column.name] {
+			continue
+		}
+		if _, err := cm.db.Exec(fmt.Sprintf("ALTER TABLE builds ADD COLUMN %s %s", column.name, column.definition)); err != nil {
+			return fmt.Errorf("failed to add builds.%s: %w", column.name, err)
+		}
+		added = true
+	}
+
+	indexes := []string{
+		`CREATE INDEX IF NOT EXISTS idx_builds_repository_id ON builds(repository_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_builds_definition_id ON builds(definition_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_builds_reason ON builds(reason)`,
+		`CREATE INDEX IF NOT EXISTS idx_builds_requested_for_id ON builds(requested_for_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_builds_pr_id ON builds(pr_id)`,
+	}
+	for _, query := range indexes {
+		if _, err := cm.db.Exec(query); err != nil {
+			return fmt.Errorf("failed to create index: %w", err)
+		}
+	}
+
+	if added {
+		return cm.backfillBuildLinkage()
+	}
+	return nil
+}
+
+// deploymentsColumns is the deployments schema: one row per deployment of a
+// release to an environment
+const deploymentsColumns = `
+			deployment_id INTEGER PRIMARY KEY,
+			release_id INTEGER NOT NULL,
+			release_name TEXT NOT NULL,
+			status TEXT NOT NULL,
+			start_time DATETIME NOT NULL,
+			end_time DATETIME,
+			repository TEXT,
+			branch TEXT,
+			source_version TEXT,
+			build_id INTEGER,
+			full_json TEXT NOT NULL,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			definition_id INTEGER NOT NULL DEFAULT 0,
+			definition_name TEXT NOT NULL DEFAULT '',
+			environment_id INTEGER NOT NULL DEFAULT 0,
+			environment TEXT NOT NULL DEFAULT ''
+		`
+
+// migrateDeployments rebuilds a deployments table keyed by release, which kept a
+// single environment per release, into one keyed by deployment. Cached rows are
+// converted from their stored JSON and the next sync refetches the lost ones.
+func (cm *Manager) migrateDeployments() error {
+	existing, err := cm.tableColumns("deployments")
+	if err != nil {
+		return err
+	}
+
+	if !existing["deployment_id"] {
+		if err := cm.rebuildDeployments(); err != nil {
+			return err
+		}
+	}
+
+	indexes := []string{
 		`CREATE INDEX IF NOT EXISTS idx_deployments_status ON deployments(status)`,
 		`CREATE INDEX IF NOT EXISTS idx_deployments_start_time ON deployments(start_time)`,
 		`CREATE INDEX IF NOT EXISTS idx_deployments_repository ON deployments(repository)`,
 		`CREATE INDEX IF NOT EXISTS idx_deployments_branch ON deployments(branch)`,
+		`CREATE INDEX IF NOT EXISTS idx_deployments_release_id ON deployments(release_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_deployments_build_id ON deployments(build_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_deployments_definition_id ON deployments(definition_id)`,
 	}
-
-	for _, query := range queries {
+	for _, query := range indexes {
 		if _, err := cm.db.Exec(query); err != nil {
-			return fmt.Errorf("failed to create table: %w", err)
+			return fmt.Errorf("failed to create index: %w", err)
+		}
+	}
+	return nil
+}
+
+func (cm *Manager) rebuildDeployments() error {
+	tx, err := cm.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`SELECT release_id, release_name, status, start_time, end_time, repository, branch, source_version, build_id, full_json FROM deployments`)
+	if err != nil {
+		return fmt.Errorf("failed to read cached deployments: %w", err)
+	}
+
+	deployments := []Deployment{}
+	for rows.Next() {
+		var d Deployment
+		var repository, branch, sourceVersion sql.NullString
+		var buildID sql.NullInt64
+		if err := rows.Scan(&d.ReleaseID, &d.ReleaseName, &d.Status, &d.StartTime, &d.EndTime, &repository, &branch, &sourceVersion, &buildID, &d.FullJSON); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan deployment: %w", err)
+		}
+		d.Repository, d.Branch, d.SourceVersion, d.BuildID = repository.String, branch.String, sourceVersion.String, int(buildID.Int64)
+		if d.FillLinkageFromJSON() == nil && d.DeploymentID != 0 {
+			deployments = append(deployments, d)
+		}
+	}
+	rows.Close()
+	// A partial read must not drop the table
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read cached deployments: %w", err)
+	}
+
+	statements := []string{
+		`DROP TABLE deployments`,
+		`CREATE TABLE deployments (` + deploymentsColumns + `)`,
+		`DELETE FROM sync_metadata WHERE key = 'deployments_sync'`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement); err != nil {
+			return fmt.Errorf("failed to rebuild deployments table: %w", err)
+		}
+	}
+
+	stmt, err := tx.Prepare(insertDeploymentQuery)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, d := range deployments {
+		if _, err := stmt.Exec(deploymentValues(d)...); err != nil {
+			return fmt.Errorf("failed to copy deployment %d: %w", d.DeploymentID, err)
 		}
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (cm *Manager) tableColumns(table string) (map[string]bool, error) {
+	rows, err := cm.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s schema: %w", table, err)
+	}
+	defer rows.Close()
+
+	columns := map[string]bool{}
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, columnType string
+		var defaultValue sql.NullString
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultValue, &pk); err != nil {
+			return nil, fmt.Errorf("failed to read %s schema: %w", table, err)
+		}
+		columns[name] = true
+	}
+	return columns, rows.Err()
+}
+
+func (cm *Manager) backfillBuildLinkage() error {
+	rows, err := cm.db.Query(`SELECT build_id, full_json FROM builds`)
+	if err != nil {
+		return fmt.Errorf("failed to read cached builds: %w", err)
+	}
+
+	builds := []Build{}
+	for rows.Next() {
+		var build Build
+		if err := rows.Scan(&build.BuildID, &build.FullJSON); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan build: %w", err)
+		}
+		if build.FillLinkageFromJSON() == nil {
+			builds = append(builds, build)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read cached builds: %w", err)
+	}
+
+	tx, err := cm.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`UPDATE builds SET repository_id = ?, definition_id = ?, reason = ?, requested_for_id = ?, requested_for = ?, pr_id = ? WHERE build_id = ?`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, b := range builds {
+		if _, err := stmt.Exec(b.RepositoryID, b.DefinitionID, b.Reason, b.RequestedForID, b.RequestedFor, b.PullRequestID, b.BuildID); err != nil {
+			return fmt.Errorf("failed to update build %d: %w", b.BuildID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
 	return nil
 }
 
@@ -411,14 +666,21 @@ func LoadCacheConfig() CacheConfig {
 		}
 	}
 
+	if ttlStr := os.Getenv("ADOCTL_CACHE_DEFINITIONS_TTL"); ttlStr != "" {
+		if d, err := time.ParseDuration(ttlStr); err == nil {
+			config.DefinitionsTTL = d
+		}
+	}
+
 	return config
 }
 
 func (cm *Manager) SaveBuild(build Build) error {
 	query := `
 		INSERT OR REPLACE INTO builds 
-		(build_id, branch, repository, source_version, start_time, end_time, status, result, full_json, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		(build_id, branch, repository, source_version, start_time, end_time, status, result, full_json, updated_at,
+		 repository_id, definition_id, reason, requested_for_id, requested_for, pr_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, ?, ?, ?, ?, ?, ?)
 	`
 
 	var endTime any
@@ -428,7 +690,8 @@ func (cm *Manager) SaveBuild(build Build) error {
 		endTime = nil
 	}
 
-	_, err := cm.db.Exec(query, build.BuildID, build.Branch, build.Repository, build.SourceVersion, build.StartTime, endTime, build.Status, build.Result, build.FullJSON)
+	_, err := cm.db.Exec(query, build.BuildID, build.Branch, build.Repository, build.SourceVersion, build.StartTime, endTime, build.Status, build.Result, build.FullJSON,
+		build.RepositoryID, build.DefinitionID, build.Reason, build.RequestedForID, build.RequestedFor, build.PullRequestID)
 	if err != nil {
 		return fmt.Errorf("failed to save build: %w", err)
 	}
@@ -437,14 +700,10 @@ func (cm *Manager) SaveBuild(build Build) error {
 }
 
 func (cm *Manager) GetBuildByID(buildID int) (*Build, error) {
-	query := `SELECT build_id, branch, repository, source_version, start_time, end_time, status, full_json, updated_at
-	          FROM builds WHERE build_id = ?`
-
-	row := cm.db.QueryRow(query, buildID)
+	row := cm.db.QueryRow(SELECT_BUILDS_WHERE+" AND build_id = ?", buildID)
 
 	var build Build
-	var endTime sql.NullTime
-	err := row.Scan(&build.BuildID, &build.Branch, &build.Repository, &build.SourceVersion, &build.StartTime, &endTime, &build.Status, &build.FullJSON, &build.UpdatedAt)
+	err := build.Scan(row)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -452,7 +711,6 @@ func (cm *Manager) GetBuildByID(buildID int) (*Build, error) {
 		return nil, fmt.Errorf("failed to get build: %w", err)
 	}
 
-	build.EndTime = endTime
 	return &build, nil
 }
 
@@ -485,6 +743,38 @@ func (cm *Manager) SearchBuilds(filters map[string]any) ([]Build, error) {
 		args = append(args, status)
 	}
 
+	if repositoryID, ok := filters["repository_id"].(string); ok && repositoryID != "" {
+		query += " AND repository_id = ?"
+		args = append(args, repositoryID)
+	}
+
+	if definitionID, ok := filters["definition_id"].(int); ok {
+		query += " AND definition_id = ?"
+		args = append(args, definitionID)
+	}
+
+	// definition_name matches the pipeline name stored in the build JSON
+	if definitionName, ok := filters["definition_name"].(string); ok && definitionName != "" {
+		query += " AND json_extract(full_json, '$.definition.name') = ? COLLATE NOCASE"
+		args = append(args, definitionName)
+	}
+
+	if reason, ok := filters["reason"].(string); ok && reason != "" {
+		query += " AND reason = ?"
+		args = append(args, reason)
+	}
+
+	// requested_for matches the requester's ID or display name
+	if requestedFor, ok := filters["requested_for"].(string); ok && requestedFor != "" {
+		query += " AND (requested_for_id = ? OR requested_for LIKE ?)"
+		args = append(args, requestedFor, "%"+requestedFor+"%")
+	}
+
+	if prID, ok := filters["pr_id"].(int); ok {
+		query += " AND pr_id = ?"
+		args = append(args, prID)
+	}
+
 	if startTimeFrom, ok := filters["start_time_from"].(time.Time); ok {
 		query += " AND start_time >= ?"
 		args = append(args, startTimeFrom)
@@ -519,6 +809,43 @@ func (cm *Manager) SearchBuilds(filters map[string]any) ([]Build, error) {
 		query += fmt.Sprintf(" LIMIT %d", limit)
 	}
 
+	return cm.queryBuilds(query, args...)
+}
+
+// GetBuildsForPullRequest returns the builds triggered by a PR, and the builds of
+// its merge commit, most recent first. repositoryID restricts the builds to that
+// repository when set.
+func (cm *Manager) GetBuildsForPullRequest(prID int, mergeCommit, repositoryID string) ([]Build, error) {
+	query := SELECT_BUILDS_WHERE + " AND (pr_id = ? OR (source_version <> '' AND source_version = ?))"
+	args := []any{prID, mergeCommit}
+
+	if repositoryID != "" {
+		query += " AND (repository_id = ? OR repository_id = '')"
+		args = append(args, repositoryID)
+	}
+
+	query += " ORDER BY start_time DESC"
+
+	return cm.queryBuilds(query, args...)
+}
+
+// GetPreviousBuild returns the latest completed build of a definition on a branch
+// before the given build, or nil when the cache has none
+func (cm *Manager) GetPreviousBuild(definitionID int, branch string, beforeBuildID int) (*Build, error) {
+	query := SELECT_BUILDS_WHERE + ` AND definition_id = ? AND branch = ? AND build_id < ? AND status = 'completed'
+		ORDER BY build_id DESC LIMIT 1`
+
+	builds, err := cm.queryBuilds(query, definitionID, branch, beforeBuildID)
+	if err != nil {
+		return nil, err
+	}
+	if len(builds) == 0 {
+		return nil, nil
+	}
+	return &builds[0], nil
+}
+
+func (cm *Manager) queryBuilds(query string, args ...any) ([]Build, error) {
 	rows, err := cm.db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search builds: %w", err)
@@ -572,42 +899,23 @@ func (cm *Manager) GetAllBuilds() ([]Build, error) {
 
 	query += `ORDER BY start_time DESC`
 
-	rows, err := cm.db.Query(query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query all builds: %w", err)
-	}
-	defer rows.Close()
-
-	builds := []Build{}
-	for rows.Next() {
-		var build Build
-		var endTime sql.NullTime
-
-		err := rows.Scan(&build.BuildID, &build.Branch, &build.Repository, &build.SourceVersion, &build.StartTime, &endTime, &build.Status, &build.FullJSON, &build.UpdatedAt)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan build: %w", err)
-		}
-
-		build.EndTime = endTime
-		builds = append(builds, build)
-	}
-
-	return builds, nil
+	return cm.queryBuilds(query)
 }
 
-func (cm *Manager) SaveDeployment(deployment Deployment) error {
-	query := `
+const insertDeploymentQuery = `
 		INSERT OR REPLACE INTO deployments
-		(release_id, release_name, status, start_time, end_time, repository, branch, source_version, build_id, full_json, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		(deployment_id, release_id, release_name, status, start_time, end_time, repository, branch, source_version, build_id, full_json, definition_id, definition_name, environment_id, environment, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
 	`
 
+func deploymentValues(deployment Deployment) []any {
 	var endTime any
 	if deployment.EndTime.Valid {
 		endTime = deployment.EndTime.Time
 	}
 
-	_, err := cm.db.Exec(query,
+	return []any{
+		deployment.DeploymentID,
 		deployment.ReleaseID,
 		deployment.ReleaseName,
 		deployment.Status,
@@ -617,7 +925,20 @@ func (cm *Manager) SaveDeployment(deployment Deployment) error {
 		deployment.Branch,
 		deployment.SourceVersion,
 		deployment.BuildID,
-		deployment.FullJSON)
+		deployment.FullJSON,
+		deployment.DefinitionID,
+		deployment.DefinitionName,
+		deployment.EnvironmentID,
+		deployment.Environment,
+	}
+}
+
+func (cm *Manager) SaveDeployment(deployment Deployment) error {
+	if deployment.DeploymentID == 0 {
+		return fmt.Errorf("deployment of release %d has no ID", deployment.ReleaseID)
+	}
+
+	_, err := cm.db.Exec(insertDeploymentQuery, deploymentValues(deployment)...)
 	if err != nil {
 		return fmt.Errorf("failed to save deployment: %w", err)
 	}
@@ -724,7 +1045,12 @@ func ScanRow(row *sql.Row, deployment *Deployment) error {
 		&deployment.SourceVersion,
 		&deployment.BuildID,
 		&deployment.FullJSON,
-		&deployment.UpdatedAt)
+		&deployment.UpdatedAt,
+		&deployment.DeploymentID,
+		&deployment.DefinitionID,
+		&deployment.DefinitionName,
+		&deployment.EnvironmentID,
+		&deployment.Environment)
 }
 
 func ScanRows(rows *sql.Rows, deployment *Deployment) error {
@@ -738,10 +1064,21 @@ func ScanRows(rows *sql.Rows, deployment *Deployment) error {
 		&deployment.SourceVersion,
 		&deployment.BuildID,
 		&deployment.FullJSON,
-		&deployment.UpdatedAt)
+		&deployment.UpdatedAt,
+		&deployment.DeploymentID,
+		&deployment.DefinitionID,
+		&deployment.DefinitionName,
+		&deployment.EnvironmentID,
+		&deployment.Environment)
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
 }
 
-func (build *Build) Scan(rows *sql.Rows) error {
+// Scan reads a build selected with SELECT_BUILDS_WHERE
+func (build *Build) Scan(rows rowScanner) error {
 	return rows.Scan(&build.BuildID,
 		&build.Branch,
 		&build.Repository,
@@ -751,7 +1088,13 @@ func (build *Build) Scan(rows *sql.Rows) error {
 		&build.Status,
 		&build.Result,
 		&build.FullJSON,
-		&build.UpdatedAt)
+		&build.UpdatedAt,
+		&build.RepositoryID,
+		&build.DefinitionID,
+		&build.Reason,
+		&build.RequestedForID,
+		&build.RequestedFor,
+		&build.PullRequestID)
 }
 
 func (cm *Manager) GetAllDeployments() ([]Deployment, error) {
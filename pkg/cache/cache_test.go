@@ -0,0 +1,212 @@
+package cache
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newTestManager opens an in-memory cache after running the seed statements,
+// so migrations can be tested against an older schema
+func newTestManager(t *testing.T, seed ...string) *Manager {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	// Every connection to :memory: is a separate database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	for _, query := range seed {
+		if _, err := db.Exec(query); err != nil {
+			t.Fatalf("failed to seed database: %v", err)
+		}
+	}
+
+	cm := &Manager{db: db, config: DefaultCacheConfig}
+	if err := cm.init(); err != nil {
+		t.Fatalf("init() error = %v", err)
+	}
+	return cm
+}
+
+const oldBuildsSchema = `CREATE TABLE builds (
+	build_id INTEGER PRIMARY KEY,
+	branch TEXT NOT NULL,
+	repository TEXT NOT NULL,
+	source_version TEXT,
+	start_time DATETIME NOT NULL,
+	end_time DATETIME,
+	status TEXT NOT NULL,
+	result TEXT NOT NULL,
+	full_json TEXT NOT NULL,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+)`
+
+func TestMigrateBuildsBackfillsLinkage(t *testing.T) {
+	cm := newTestManager(t,
+		oldBuildsSchema,
+		`INSERT INTO builds (build_id, branch, repository, source_version, start_time, status, result, full_json) VALUES
+		(1, 'refs/pull/42/merge', 'ci', 'abc', '2024-01-01T00:00:00Z', 'completed', 'succeeded',
+		 '{"sourceBranch":"refs/pull/42/merge","reason":"pullRequest","repository":{"id":"repo-1"},"definition":{"id":7,"name":"CI"},"requestedFor":{"id":"user-1","displayName":"Ada Lovelace"},"triggerInfo":{"pr.number":"42"}}'),
+		(2, 'refs/pull/43/merge', 'ci', 'def', '2024-01-02T00:00:00Z', 'completed', 'failed',
+		 '{"sourceBranch":"refs/pull/43/merge","reason":"pullRequest","definition":{"id":7,"name":"CI"}}'),
+		(3, 'refs/heads/main', 'deploy', 'ghi', '2024-01-03T00:00:00Z', 'inProgress', '',
+		 'not json')`,
+	)
+
+	type linkage struct {
+		RepositoryID   string
+		DefinitionID   int
+		Reason         string
+		RequestedForID string
+		RequestedFor   string
+		PullRequestID  int
+	}
+
+	tests := []struct {
+		name string
+		id   int
+		want linkage
+	}{
+		{
+			name: "all linkage fields",
+			id:   1,
+			want: linkage{RepositoryID: "repo-1", DefinitionID: 7, Reason: "pullRequest", RequestedForID: "user-1", RequestedFor: "Ada Lovelace", PullRequestID: 42},
+		},
+		{
+			name: "pull request from the source branch",
+			id:   2,
+			want: linkage{DefinitionID: 7, Reason: "pullRequest", PullRequestID: 43},
+		},
+		{
+			name: "unparsable JSON keeps the defaults",
+			id:   3,
+			want: linkage{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cm.GetBuildByID(tt.id)
+			if err != nil {
+				t.Fatalf("GetBuildByID() error = %v", err)
+			}
+			linked := linkage{got.RepositoryID, got.DefinitionID, got.Reason, got.RequestedForID, got.RequestedFor, got.PullRequestID}
+			if linked != tt.want {
+				t.Errorf("build %d linkage = %+v, want %+v", tt.id, linked, tt.want)
+			}
+		})
+	}
+
+	// A second init on the migrated table must be a no-op
+	if err := cm.init(); err != nil {
+		t.Fatalf("second init() error = %v", err)
+	}
+}
+
+func TestSearchBuildsFilters(t *testing.T) {
+	cm := newTestManager(t,
+		oldBuildsSchema,
+		`INSERT INTO builds (build_id, branch, repository, source_version, start_time, status, result, full_json) VALUES
+		(1, 'refs/heads/main', 'ci', 'abc', '2024-01-01T00:00:00Z', 'completed', 'succeeded',
+		 '{"definition":{"id":7,"name":"CI"},"requestedFor":{"id":"user-1","displayName":"Ada Lovelace"}}'),
+		(2, 'refs/heads/main', 'deploy', 'def', '2024-01-02T00:00:00Z', 'completed', 'failed',
+		 '{"definition":{"id":8,"name":"Deploy"},"requestedFor":{"id":"user-2","displayName":"Grace Hopper"}}')`,
+	)
+
+	tests := []struct {
+		name    string
+		filters map[string]any
+		want    []int
+	}{
+		{name: "definition name is case-insensitive", filters: map[string]any{"definition_name": "deploy"}, want: []int{2}},
+		{name: "definition ID", filters: map[string]any{"definition_id": 7}, want: []int{1}},
+		{name: "definition name with repository", filters: map[string]any{"definition_name": "CI", "repository": "deploy"}, want: []int{}},
+		{name: "requester ID", filters: map[string]any{"requested_for": "user-2"}, want: []int{2}},
+		{name: "requester name", filters: map[string]any{"requested_for": "Ada"}, want: []int{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builds, err := cm.SearchBuilds(tt.filters)
+			if err != nil {
+				t.Fatalf("SearchBuilds() error = %v", err)
+			}
+			got := []int{}
+			for _, b := range builds {
+				got = append(got, b.BuildID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchBuilds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMigrateDeploymentsRebuildsTable(t *testing.T) {
+	cm := newTestManager(t,
+		`CREATE TABLE deployments (
+			release_id INTEGER PRIMARY KEY,
+			release_name TEXT NOT NULL,
+			status TEXT NOT NULL,
+			start_time DATETIME NOT NULL,
+			end_time DATETIME,
+			repository TEXT,
+			branch TEXT,
+			source_version TEXT,
+			build_id INTEGER,
+			full_json TEXT NOT NULL,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE sync_metadata (key TEXT PRIMARY KEY, value DATETIME NOT NULL)`,
+		`INSERT INTO sync_metadata (key, value) VALUES ('deployments_sync', '2024-01-01T00:00:00Z'), ('builds_sync', '2024-01-01T00:00:00Z')`,
+		`INSERT INTO deployments (release_id, release_name, status, start_time, repository, branch, source_version, build_id, full_json) VALUES
+		(10, 'Release-10', 'succeeded', '2024-01-01T00:00:00Z', 'app', 'refs/heads/main', 'abc', 100,
+		 '{"id":501,"definitionEnvironmentId":3,"releaseDefinition":{"id":7,"name":"app"},"releaseEnvironment":{"name":"staging"}}'),
+		(11, 'Release-11', 'failed', '2024-01-02T00:00:00Z', NULL, NULL, NULL, NULL,
+		 '{"releaseDefinition":{"id":7,"name":"app"}}'),
+		(12, 'Release-12', 'succeeded', '2024-01-03T00:00:00Z', 'app', 'refs/heads/main', 'def', 101, 'not json')`,
+	)
+
+	columns, err := cm.tableColumns("deployments")
+	if err != nil {
+		t.Fatalf("tableColumns() error = %v", err)
+	}
+	if !columns["deployment_id"] {
+		t.Fatalf("deployments columns = %v, want deployment_id", columns)
+	}
+
+	deployments, err := cm.GetAllDeployments()
+	if err != nil {
+		t.Fatalf("GetAllDeployments() error = %v", err)
+	}
+	// Rows without a deployment ID in their JSON cannot be keyed and are dropped
+	if len(deployments) != 1 {
+		t.Fatalf("GetAllDeployments() returned %d deployments, want 1", len(deployments))
+	}
+	d := deployments[0]
+	if d.DeploymentID != 501 || d.ReleaseID != 10 || d.DefinitionID != 7 || d.DefinitionName != "app" ||
+		d.EnvironmentID != 3 || d.Environment != "staging" || d.BuildID != 100 || d.SourceVersion != "abc" {
+		t.Errorf("migrated deployment = %+v", d)
+	}
+
+	// The deployments sync restarts to refetch the dropped rows; other syncs are kept
+	if synced, err := cm.GetLastSyncTime("deployments_sync"); err != nil || synced != nil {
+		t.Errorf("deployments_sync = %v, %v, want none", synced, err)
+	}
+	if synced, err := cm.GetLastSyncTime("builds_sync"); err != nil || synced == nil {
+		t.Errorf("builds_sync = %v, %v, want kept", synced, err)
+	}
+
+	if err := cm.init(); err != nil {
+		t.Fatalf("second init() error = %v", err)
+	}
+	if deployments, _ := cm.GetAllDeployments(); len(deployments) != 1 {
+		t.Errorf("second init() left %d deployments, want 1", len(deployments))
+	}
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+)
+
+// Definition is a cached build pipeline definition
+type Definition struct {
+	ID        int
+	Name      string
+	Path      string
+	UpdatedAt time.Time
+}
+
+// GetDefinitions returns the cached pipeline definitions still within their TTL,
+// or nil when the cache is empty or expired
+func (cm *Manager) GetDefinitions() ([]Definition, error) {
+	query := fmt.Sprintf(`SELECT id, name, path, updated_at
+	          FROM definitions
+	          WHERE datetime(updated_at) > datetime('now', '-%d seconds')
+	          ORDER BY path, name`, int(cm.config.DefinitionsTTL.Seconds()))
+
+	rows, err := cm.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query definitions: %w", err)
+	}
+	defer rows.Close()
+
+	definitions := []Definition{}
+	for rows.Next() {
+		var definition Definition
+		if err := rows.Scan(&definition.ID, &definition.Name, &definition.Path, &definition.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan definition: %w", err)
+		}
+		definitions = append(definitions, definition)
+	}
+
+	if len(definitions) > 0 {
+		return definitions, nil
+	}
+
+	return nil, nil
+}
+
+// SetDefinitions replaces the cached pipeline definitions
+func (cm *Manager) SetDefinitions(definitions []Definition) error {
+	tx, err := cm.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM definitions"); err != nil {
+		return fmt.Errorf("failed to clear definitions: %w", err)
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO definitions (id, name, path)
+		VALUES (?, ?, ?)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, definition := range definitions {
+		if _, err := stmt.Exec(definition.ID, definition.Name, definition.Path); err != nil {
+			return fmt.Errorf("failed to insert definition: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
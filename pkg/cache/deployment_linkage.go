@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// deploymentLinkageJSON holds the fields of an Azure DevOps deployment that
+// identify it and place it in a release definition
+type deploymentLinkageJSON struct {
+	ID                      int `json:"id"`
+	DefinitionEnvironmentID int `json:"definitionEnvironmentId"`
+	ReleaseDefinition       *struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"releaseDefinition"`
+	ReleaseEnvironment *struct {
+		Name string `json:"name"`
+	} `json:"releaseEnvironment"`
+}
+
+// FillLinkageFromJSON sets the deployment ID, release definition and environment
+// fields of the deployment from its FullJSON.
+func (deployment *Deployment) FillLinkageFromJSON() error {
+	var data deploymentLinkageJSON
+	if err := json.Unmarshal([]byte(deployment.FullJSON), &data); err != nil {
+		return fmt.Errorf("failed to parse deployment of release %d: %w", deployment.ReleaseID, err)
+	}
+
+	deployment.DeploymentID = data.ID
+	deployment.EnvironmentID = data.DefinitionEnvironmentID
+	if data.ReleaseDefinition != nil {
+		deployment.DefinitionID = data.ReleaseDefinition.ID
+		deployment.DefinitionName = data.ReleaseDefinition.Name
+	}
+	if data.ReleaseEnvironment != nil {
+		deployment.Environment = data.ReleaseEnvironment.Name
+	}
+
+	return nil
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 
 	"adoctl/pkg/errors"
 
@@ -26,6 +28,10 @@ type Config struct {
 	ThreadPool    ThreadPoolConfig `yaml:"threadpool"`
 	Profiles      []Profile        `yaml:"profiles,omitempty"`
 	ActiveProfile string           `yaml:"active_profile,omitempty"`
+	// Queries maps saved PR query names to their expressions
+	Queries map[string]string `yaml:"queries,omitempty"`
+	// Teams maps team names to the repositories they own, for per-team reports
+	Teams map[string][]string `yaml:"teams,omitempty"`
 }
 
 type ThreadPoolConfig struct {
@@ -164,6 +170,70 @@ func (c *Config) IsProfileActive(name string) bool {
 	return c.ActiveProfile == name
 }
 
+// SetQuery saves a named PR query, replacing any existing query with the same name
+func (c *Config) SetQuery(name, expr string) error {
+	if name == "" {
+		return fmt.Errorf("query name is required")
+	}
+	if expr == "" {
+		return fmt.Errorf("query expression is required")
+	}
+
+	if c.Queries == nil {
+		c.Queries = map[string]string{}
+	}
+	c.Queries[name] = expr
+	return nil
+}
+
+// RemoveQuery removes a saved PR query
+func (c *Config) RemoveQuery(name string) error {
+	if _, ok := c.Queries[name]; !ok {
+		return fmt.Errorf("query '%s' not found", name)
+	}
+
+	delete(c.Queries, name)
+	return nil
+}
+
+// ListQueries returns the saved PR query names in sorted order
+func (c *Config) ListQueries() []string {
+	names := make([]string, 0, len(c.Queries))
+	for name := range c.Queries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetSavedQueries returns the saved PR queries from the config file, or an empty map
+func GetSavedQueries() map[string]string {
+	cfg, err := Load()
+	if err != nil || cfg.Queries == nil {
+		return map[string]string{}
+	}
+	return cfg.Queries
+}
+
+// TeamOfRepository returns the team owning a repository, compared
+// case-insensitively, or "" when no team lists it
+func (c *Config) TeamOfRepository(repository string) string {
+	teams := make([]string, 0, len(c.Teams))
+	for team := range c.Teams {
+		teams = append(teams, team)
+	}
+	sort.Strings(teams)
+
+	for _, team := range teams {
+		for _, repo := range c.Teams[team] {
+			if strings.EqualFold(repo, repository) {
+				return team
+			}
+		}
+	}
+	return ""
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
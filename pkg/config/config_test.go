@@ -468,6 +468,70 @@ func TestConfig_ProfileManagement(t *testing.T) {
 	}
 }
 
+func TestConfig_QueryManagement(t *testing.T) {
+	cfg := &Config{}
+
+	if err := cfg.SetQuery("mine", "author:me status:active"); err != nil {
+		t.Fatalf("SetQuery() failed: %v", err)
+	}
+	if err := cfg.SetQuery("stale", "age>14d"); err != nil {
+		t.Fatalf("SetQuery() failed: %v", err)
+	}
+
+	// Setting an existing query replaces it
+	if err := cfg.SetQuery("mine", "author:me"); err != nil {
+		t.Fatalf("SetQuery() failed: %v", err)
+	}
+	if cfg.Queries["mine"] != "author:me" {
+		t.Errorf("Expected query 'author:me', got '%s'", cfg.Queries["mine"])
+	}
+
+	names := cfg.ListQueries()
+	if len(names) != 2 || names[0] != "mine" || names[1] != "stale" {
+		t.Errorf("Expected queries [mine stale], got %v", names)
+	}
+
+	if err := cfg.SetQuery("", "age>1d"); err == nil {
+		t.Error("SetQuery() expected error for empty name")
+	}
+	if err := cfg.SetQuery("empty", ""); err == nil {
+		t.Error("SetQuery() expected error for empty expression")
+	}
+
+	if err := cfg.RemoveQuery("stale"); err != nil {
+		t.Fatalf("RemoveQuery() failed: %v", err)
+	}
+	if err := cfg.RemoveQuery("stale"); err == nil {
+		t.Error("RemoveQuery() expected error for non-existent query")
+	}
+}
+
+func TestConfig_TeamOfRepository(t *testing.T) {
+	cfg := &Config{Teams: map[string][]string{
+		"payments": {"payments-api", "Checkout-Web"},
+		"platform": {"infra", "checkout-web"},
+	}}
+
+	tests := []struct {
+		name       string
+		repository string
+		want       string
+	}{
+		{name: "listed repository", repository: "payments-api", want: "payments"},
+		{name: "case-insensitive", repository: "INFRA", want: "platform"},
+		{name: "listed by two teams picks the first by name", repository: "checkout-web", want: "payments"},
+		{name: "unlisted repository", repository: "docs", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cfg.TeamOfRepository(tt.repository); got != tt.want {
+				t.Errorf("TeamOfRepository(%q) = %q, want %q", tt.repository, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestConfig_SaveAndLoad(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "config.yaml")
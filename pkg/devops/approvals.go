@@ -0,0 +1,124 @@
+package devops
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/release"
+)
+
+// PendingApproval is a release approval waiting on the current user or one of
+// their groups
+type PendingApproval struct {
+	ID int `json:"id"`
+	// Type is preDeploy or postDeploy
+	Type        string `json:"type"`
+	ReleaseID   int    `json:"releaseId"`
+	Release     string `json:"release"`
+	Definition  string `json:"definition"`
+	Environment string `json:"environment"`
+	Approver    string `json:"approver"`
+	// ViaGroup is set when the approval is assigned to a group rather than the user
+	ViaGroup  bool      `json:"viaGroup"`
+	Attempt   int       `json:"attempt"`
+	CreatedOn time.Time `json:"createdOn"`
+	URL       string    `json:"url"`
+}
+
+// Age returns how long the approval has been pending
+func (a PendingApproval) Age() time.Duration {
+	if a.CreatedOn.IsZero() {
+		return 0
+	}
+	return time.Since(a.CreatedOn)
+}
+
+// ListPendingApprovals returns the pending release approvals assigned to the
+// current user and, with includeGroups, to their groups, oldest first
+func (s *DevOpsService) ListPendingApprovals(ctx context.Context, includeGroups bool) ([]PendingApproval, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	approvals, err := s.client.GetPendingApprovals(ctx, user.ID, includeGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]PendingApproval, 0, len(approvals))
+	for _, a := range approvals {
+		if a.Id == nil {
+			continue
+		}
+		result = append(result, s.toPendingApproval(a))
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedOn.Before(result[j].CreatedOn)
+	})
+	return result, nil
+}
+
+func (s *DevOpsService) toPendingApproval(a release.ReleaseApproval) PendingApproval {
+	approval := PendingApproval{ID: *a.Id}
+	if a.ApprovalType != nil {
+		approval.Type = string(*a.ApprovalType)
+	}
+	if a.Release != nil {
+		if a.Release.Id != nil {
+			approval.ReleaseID = *a.Release.Id
+			approval.URL = s.ReleaseWebURL(*a.Release.Id)
+		}
+		if a.Release.Name != nil {
+			approval.Release = *a.Release.Name
+		}
+	}
+	if a.ReleaseDefinition != nil && a.ReleaseDefinition.Name != nil {
+		approval.Definition = *a.ReleaseDefinition.Name
+	}
+	if a.ReleaseEnvironment != nil && a.ReleaseEnvironment.Name != nil {
+		approval.Environment = *a.ReleaseEnvironment.Name
+	}
+	if a.Approver != nil {
+		if a.Approver.DisplayName != nil {
+			approval.Approver = *a.Approver.DisplayName
+		}
+		if a.Approver.IsContainer != nil {
+			approval.ViaGroup = *a.Approver.IsContainer
+		}
+	}
+	if a.Attempt != nil {
+		approval.Attempt = *a.Attempt
+	}
+	if a.CreatedOn != nil {
+		approval.CreatedOn = a.CreatedOn.Time
+	}
+	return approval
+}
+
+// FilterApprovalsByRelease keeps the approvals of the release with the given
+// name, compared case-insensitively
+func FilterApprovalsByRelease(approvals []PendingApproval, releaseName string) []PendingApproval {
+	filtered := []PendingApproval{}
+	for _, a := range approvals {
+		if strings.EqualFold(a.Release, releaseName) {
+			filtered = append(filtered, a)
+		}
+	}
+	return filtered
+}
+
+// ApproveRelease approves a release approval
+func (s *DevOpsService) ApproveRelease(ctx context.Context, approvalID int, comment string) error {
+	_, err := s.client.UpdateApproval(ctx, approvalID, release.ApprovalStatusValues.Approved, comment)
+	return err
+}
+
+// RejectRelease rejects a release approval
+func (s *DevOpsService) RejectRelease(ctx context.Context, approvalID int, comment string) error {
+	_, err := s.client.UpdateApproval(ctx, approvalID, release.ApprovalStatusValues.Rejected, comment)
+	return err
+}
@@ -0,0 +1,189 @@
+package devops
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ArtifactInfo is an artifact published by a build
+type ArtifactInfo struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	// Type is the artifact resource type, e.g. PipelineArtifact, Container or FilePath
+	Type string `json:"type"`
+	// Size is the content size in bytes, or 0 when Azure DevOps does not report it
+	Size        int64  `json:"size"`
+	DownloadURL string `json:"downloadUrl,omitempty"`
+}
+
+// ListBuildArtifacts returns the artifacts published by a build
+func (s *DevOpsService) ListBuildArtifacts(ctx context.Context, buildID int) ([]ArtifactInfo, error) {
+	artifacts, err := s.client.GetBuildArtifacts(ctx, buildID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ArtifactInfo, 0, len(artifacts))
+	for _, a := range artifacts {
+		info := ArtifactInfo{}
+		if a.Id != nil {
+			info.ID = *a.Id
+		}
+		if a.Name != nil {
+			info.Name = *a.Name
+		}
+		if r := a.Resource; r != nil {
+			if r.Type != nil {
+				info.Type = *r.Type
+			}
+			if r.DownloadUrl != nil {
+				info.DownloadURL = *r.DownloadUrl
+			}
+			if r.Properties != nil {
+				if size, err := strconv.ParseInt((*r.Properties)["artifactsize"], 10, 64); err == nil {
+					info.Size = size
+				}
+			}
+		}
+		result = append(result, info)
+	}
+
+	return result, nil
+}
+
+// DownloadArtifact streams an artifact as a zip into destDir and verifies it.
+// With extract, the zip is also unpacked into destDir/<name>. It returns the
+// path of the zip; a failed or canceled download leaves no zip behind.
+func (s *DevOpsService) DownloadArtifact(ctx context.Context, artifact ArtifactInfo, destDir string, extract bool) (string, error) {
+	if !strings.HasPrefix(artifact.DownloadURL, "http") {
+		return "", fmt.Errorf("artifact %s (%s) has no HTTP download", artifact.Name, artifact.Type)
+	}
+
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory %s: %w", destDir, err)
+	}
+
+	zipPath := filepath.Join(destDir, artifact.Name+".zip")
+	file, err := os.Create(zipPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", zipPath, err)
+	}
+
+	_, err = s.client.DownloadArtifact(ctx, zipDownloadURL(artifact.DownloadURL), file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(zipPath)
+		return "", err
+	}
+
+	if err := VerifyZip(zipPath); err != nil {
+		return zipPath, err
+	}
+
+	if extract {
+		if err := ExtractZip(zipPath, filepath.Join(destDir, artifact.Name)); err != nil {
+			return zipPath, err
+		}
+	}
+
+	return zipPath, nil
+}
+
+// zipDownloadURL makes sure the download URL asks for the zip format
+func zipDownloadURL(downloadURL string) string {
+	if strings.Contains(downloadURL, "format=") {
+		return downloadURL
+	}
+	if strings.Contains(downloadURL, "?") {
+		return downloadURL + "&format=zip"
+	}
+	return downloadURL + "?format=zip"
+}
+
+// VerifyZip reads every entry of a zip, which checks their CRC32 checksums
+func VerifyZip(path string) error {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("downloaded artifact %s is not a valid zip: %w", filepath.Base(path), err)
+	}
+	defer reader.Close()
+
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open %s in %s: %w", f.Name, filepath.Base(path), err)
+		}
+		_, err = io.Copy(io.Discard, rc)
+		rc.Close()
+		if err != nil {
+			return fmt.Errorf("corrupt entry %s in %s: %w", f.Name, filepath.Base(path), err)
+		}
+	}
+
+	return nil
+}
+
+// ExtractZip unpacks a zip into destDir, refusing entries that would escape it
+func ExtractZip(path, destDir string) error {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer reader.Close()
+
+	root, err := filepath.Abs(destDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", destDir, err)
+	}
+
+	for _, f := range reader.File {
+		target := filepath.Join(root, f.Name)
+		if target != root && !strings.HasPrefix(target, root+string(os.PathSeparator)) {
+			return fmt.Errorf("zip entry %s escapes the destination directory", f.Name)
+		}
+
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return fmt.Errorf("failed to create %s: %w", target, err)
+			}
+			continue
+		}
+
+		if err := extractZipFile(f, target); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func extractZipFile(f *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", target, err)
+	}
+
+	if _, err := io.Copy(out, rc); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to extract %s: %w", f.Name, err)
+	}
+	return out.Close()
+}
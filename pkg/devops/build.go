@@ -10,6 +10,8 @@ import (
 
 	"adoctl/pkg/cache"
 	"adoctl/pkg/logger"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
 )
 
 func (s *DevOpsService) SyncBuilds(force bool) (int, error) {
@@ -93,6 +95,9 @@ func (s *DevOpsService) SyncBuilds(force bool) (int, error) {
 			Result:        result,
 			FullJSON:      FullJSON,
 		}
+		if err := buildData.FillLinkageFromJSON(); err != nil {
+			logger.Debug().Err(err).Int("build_id", buildID).Msg("Failed to read build linkage")
+		}
 
 		err := s.cache.SaveBuild(buildData)
 		if err != nil {
@@ -126,14 +131,42 @@ func (s *DevOpsService) getBuildSyncTime(force bool) *time.Time {
 }
 
 func (s *DevOpsService) SearchBuildsCached(filters map[string]any) ([]cache.Build, error) {
+	s.syncBuildsOnce()
+	return s.cache.SearchBuilds(filters)
+}
+
+// syncBuildsOnce refreshes the builds cache on the first cached lookup of the service
+func (s *DevOpsService) syncBuildsOnce() {
 	if s.syncOptions.SkipSync || s.buildsSync {
-		return s.cache.SearchBuilds(filters)
+		return
 	}
 
 	s.SyncBuilds(false)
 	s.buildsSync = true
+}
 
-	return s.cache.SearchBuilds(filters)
+// GetBuildsForPullRequest returns the builds triggered by the PR and the builds of
+// its last merge commit, using the PR linkage recorded in the cache.
+func (s *DevOpsService) GetBuildsForPullRequest(pr *git.GitPullRequest) ([]cache.Build, error) {
+	if s.cache == nil {
+		return nil, fmt.Errorf("cache not initialized")
+	}
+
+	prID := 0
+	if pr.PullRequestId != nil {
+		prID = *pr.PullRequestId
+	}
+	mergeCommit := ""
+	if pr.LastMergeCommit != nil && pr.LastMergeCommit.CommitId != nil {
+		mergeCommit = *pr.LastMergeCommit.CommitId
+	}
+	repoID := ""
+	if pr.Repository != nil && pr.Repository.Id != nil {
+		repoID = pr.Repository.Id.String()
+	}
+
+	s.syncBuildsOnce()
+	return s.cache.GetBuildsForPullRequest(prID, mergeCommit, repoID)
 }
 
 func (s *DevOpsService) GetBuildsForCommit(commitHash, repoID, branch string) ([]cache.Build, error) {
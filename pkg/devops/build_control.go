@@ -0,0 +1,67 @@
+package devops
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/build"
+)
+
+// CancelBuild requests cancellation of a queued or running build
+func (s *DevOpsService) CancelBuild(ctx context.Context, buildID int) (*build.Build, error) {
+	return s.client.CancelBuild(ctx, buildID)
+}
+
+// RetryFailedJobs reruns the failed and canceled stages and jobs of a build in place
+func (s *DevOpsService) RetryFailedJobs(ctx context.Context, buildID int) (*build.Build, error) {
+	return s.client.RetryBuild(ctx, buildID)
+}
+
+// RequeueBuild queues a new run of a build's definition on the same branch and
+// commit, with the same parameters
+func (s *DevOpsService) RequeueBuild(ctx context.Context, b *build.Build) (*build.Build, error) {
+	if b.Definition == nil || b.Definition.Id == nil {
+		return nil, fmt.Errorf("build %d has no definition", *b.Id)
+	}
+
+	return s.client.QueueBuild(ctx, &build.Build{
+		Definition:         &build.DefinitionReference{Id: b.Definition.Id},
+		SourceBranch:       b.SourceBranch,
+		SourceVersion:      b.SourceVersion,
+		Parameters:         b.Parameters,
+		TemplateParameters: b.TemplateParameters,
+	})
+}
+
+// RetainBuild adds a retention lease keeping the build for the given number of
+// days, owned by the current user
+func (s *DevOpsService) RetainBuild(ctx context.Context, b *build.Build, days int) error {
+	if b.Definition == nil || b.Definition.Id == nil {
+		return fmt.Errorf("build %d has no definition", *b.Id)
+	}
+
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.client.AddRetentionLease(ctx, *b.Id, *b.Definition.Id, days, "User:"+user.ID)
+}
+
+// AddBuildTags adds tags to a build and returns its resulting tags
+func (s *DevOpsService) AddBuildTags(ctx context.Context, buildID int, tags []string) ([]string, error) {
+	return s.client.AddBuildTags(ctx, buildID, tags)
+}
+
+// RemoveBuildTags removes tags from a build and returns its remaining tags
+func (s *DevOpsService) RemoveBuildTags(ctx context.Context, buildID int, tags []string) ([]string, error) {
+	var remaining []string
+	for _, tag := range tags {
+		result, err := s.client.DeleteBuildTag(ctx, buildID, tag)
+		if err != nil {
+			return nil, err
+		}
+		remaining = result
+	}
+	return remaining, nil
+}
@@ -0,0 +1,323 @@
+package devops
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"adoctl/pkg/cache"
+)
+
+// BuildStats summarizes completed builds from the builds cache
+type BuildStats struct {
+	Since   time.Time    `json:"since"`
+	Summary StatsSummary `json:"summary"`
+	// Pipelines breaks the summary down by build definition
+	Pipelines     []StatsSummary `json:"pipelines"`
+	FailuresByDay []DayStats     `json:"failuresByDay"`
+	LongestRed    *RedStreak     `json:"longestRedStreak,omitempty"`
+	Flaky         []FlakyCommit  `json:"flaky"`
+}
+
+// StatsSummary holds the pass rate and duration percentiles of a set of builds
+type StatsSummary struct {
+	Pipeline           string `json:"pipeline,omitempty"`
+	Builds             int    `json:"builds"`
+	Succeeded          int    `json:"succeeded"`
+	Failed             int    `json:"failed"`
+	PartiallySucceeded int    `json:"partiallySucceeded"`
+	Canceled           int    `json:"canceled"`
+	// SuccessRate is the share of succeeded builds, ignoring canceled ones, from 0 to 1
+	SuccessRate float64 `json:"successRate"`
+	// Durations are in whole seconds
+	QueueP50Seconds int `json:"queueP50Seconds"`
+	QueueP90Seconds int `json:"queueP90Seconds"`
+	RunP50Seconds   int `json:"runP50Seconds"`
+	RunP90Seconds   int `json:"runP90Seconds"`
+	// LongestRedStreak is the most consecutive failed builds on one branch
+	LongestRedStreak int `json:"longestRedStreak"`
+	// Recoveries counts failures followed by a succeeded build on the same branch
+	Recoveries            int `json:"recoveries"`
+	TimeToGreenP50Seconds int `json:"timeToGreenP50Seconds"`
+	TimeToGreenMaxSeconds int `json:"timeToGreenMaxSeconds"`
+	FlakyCommits          int `json:"flakyCommits"`
+}
+
+// DayStats counts the completed and failed builds of a day
+type DayStats struct {
+	Date   string `json:"date"`
+	Builds int    `json:"builds"`
+	Failed int    `json:"failed"`
+}
+
+// RedStreak is a run of consecutive failed builds of a pipeline on a branch
+type RedStreak struct {
+	Pipeline     string    `json:"pipeline"`
+	Branch       string    `json:"branch"`
+	Builds       int       `json:"builds"`
+	FirstBuildID int       `json:"firstBuildId"`
+	LastBuildID  int       `json:"lastBuildId"`
+	Since        time.Time `json:"since"`
+	// GreenAt is when the next build succeeded, unset while the branch is still red
+	GreenAt *time.Time `json:"greenAt,omitempty"`
+}
+
+// FlakyCommit is a commit whose builds of one pipeline both failed and succeeded
+type FlakyCommit struct {
+	Pipeline  string `json:"pipeline"`
+	Branch    string `json:"branch"`
+	Commit    string `json:"commit"`
+	Failed    int    `json:"failed"`
+	Succeeded int    `json:"succeeded"`
+	BuildIDs  []int  `json:"buildIds"`
+}
+
+// GetBuildStats computes build statistics from the builds cache. pipeline is a
+// definition name or ID and branch a branch name; both are optional.
+func (s *DevOpsService) GetBuildStats(pipeline, branch string, since time.Time) (*BuildStats, error) {
+	if s.cache == nil {
+		return nil, fmt.Errorf("cache not initialized")
+	}
+
+	filters := map[string]any{
+		"status":          "completed",
+		"start_time_from": since,
+	}
+	if branch != "" {
+		filters["branch"] = branch
+	}
+	if id, err := strconv.Atoi(pipeline); err == nil {
+		filters["definition_id"] = id
+	} else if pipeline != "" {
+		filters["repository"] = pipeline
+	}
+
+	builds, err := s.SearchBuildsCached(filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search builds in cache: %w", err)
+	}
+
+	stats := ComputeBuildStats(builds)
+	stats.Since = since
+	return stats, nil
+}
+
+// ComputeBuildStats computes statistics of completed builds. Streaks and
+// time-to-green follow each pipeline and branch in build order.
+func ComputeBuildStats(builds []cache.Build) *BuildStats {
+	sorted := make([]cache.Build, 0, len(builds))
+	for _, b := range builds {
+		if b.Status == "completed" && b.EndTime.Valid {
+			sorted = append(sorted, b)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].BuildID < sorted[j].BuildID })
+
+	stats := &BuildStats{
+		Pipelines:     []StatsSummary{},
+		FailuresByDay: []DayStats{},
+		Flaky:         []FlakyCommit{},
+	}
+
+	byPipeline := map[string][]cache.Build{}
+	pipelines := []string{}
+	days := map[string]*DayStats{}
+	for _, b := range sorted {
+		if _, ok := byPipeline[b.Repository]; !ok {
+			pipelines = append(pipelines, b.Repository)
+		}
+		byPipeline[b.Repository] = append(byPipeline[b.Repository], b)
+
+		date := b.StartTime.Local().Format(time.DateOnly)
+		if days[date] == nil {
+			days[date] = &DayStats{Date: date}
+		}
+		days[date].Builds++
+		if b.Result == "failed" {
+			days[date].Failed++
+		}
+	}
+
+	sort.Strings(pipelines)
+	for _, pipeline := range pipelines {
+		summary, streak, flaky := summarizeBuilds(byPipeline[pipeline])
+		summary.Pipeline = pipeline
+		stats.Pipelines = append(stats.Pipelines, summary)
+		stats.Flaky = append(stats.Flaky, flaky...)
+		if streak != nil && (stats.LongestRed == nil || streak.Builds > stats.LongestRed.Builds) {
+			stats.LongestRed = streak
+		}
+	}
+	stats.Summary, _, _ = summarizeBuilds(sorted)
+
+	for _, day := range days {
+		stats.FailuresByDay = append(stats.FailuresByDay, *day)
+	}
+	sort.Slice(stats.FailuresByDay, func(i, j int) bool {
+		return stats.FailuresByDay[i].Date < stats.FailuresByDay[j].Date
+	})
+
+	return stats
+}
+
+// summarizeBuilds computes the summary of builds sorted by ID, with the longest
+// red streak and the flaky commits among them
+func summarizeBuilds(builds []cache.Build) (StatsSummary, *RedStreak, []FlakyCommit) {
+	summary := StatsSummary{Builds: len(builds)}
+	queue := []time.Duration{}
+	run := []time.Duration{}
+
+	for _, b := range builds {
+		switch b.Result {
+		case "succeeded":
+			summary.Succeeded++
+		case "failed":
+			summary.Failed++
+		case "partiallySucceeded":
+			summary.PartiallySucceeded++
+		case "canceled":
+			summary.Canceled++
+		}
+
+		if b.Result == "canceled" {
+			continue
+		}
+		run = append(run, b.EndTime.Time.Sub(b.StartTime))
+		if queued, ok := buildQueueTime(b); ok && !b.StartTime.Before(queued) {
+			queue = append(queue, b.StartTime.Sub(queued))
+		}
+	}
+
+	if counted := summary.Builds - summary.Canceled; counted > 0 {
+		summary.SuccessRate = float64(summary.Succeeded) / float64(counted)
+	}
+	summary.QueueP50Seconds = seconds(percentile(queue, 50))
+	summary.QueueP90Seconds = seconds(percentile(queue, 90))
+	summary.RunP50Seconds = seconds(percentile(run, 50))
+	summary.RunP90Seconds = seconds(percentile(run, 90))
+
+	longest, recoveries := redStreaks(builds)
+	summary.Recoveries = len(recoveries)
+	summary.TimeToGreenP50Seconds = seconds(percentile(recoveries, 50))
+	summary.TimeToGreenMaxSeconds = seconds(percentile(recoveries, 100))
+	if longest != nil {
+		summary.LongestRedStreak = longest.Builds
+	}
+
+	flaky := flakyCommits(builds)
+	summary.FlakyCommits = len(flaky)
+
+	return summary, longest, flaky
+}
+
+// redStreaks walks each pipeline and branch in build order and returns the longest
+// run of failed builds, and for every run that ended, the time from the end of its
+// first failed build to the end of the next succeeded build. Canceled and
+// partially succeeded builds neither extend nor end a streak.
+func redStreaks(builds []cache.Build) (*RedStreak, []time.Duration) {
+	type series struct {
+		definition int
+		pipeline   string
+		branch     string
+	}
+
+	var longest *RedStreak
+	current := map[series]*RedStreak{}
+	recoveries := []time.Duration{}
+
+	for _, b := range builds {
+		key := series{b.DefinitionID, b.Repository, b.Branch}
+		switch b.Result {
+		case "failed":
+			streak := current[key]
+			if streak == nil {
+				streak = &RedStreak{Pipeline: b.Repository, Branch: b.Branch, FirstBuildID: b.BuildID, Since: b.EndTime.Time}
+				current[key] = streak
+			}
+			streak.Builds++
+			streak.LastBuildID = b.BuildID
+			if longest == nil || streak.Builds > longest.Builds {
+				longest = streak
+			}
+		case "succeeded":
+			if streak := current[key]; streak != nil {
+				green := b.EndTime.Time
+				streak.GreenAt = &green
+				recoveries = append(recoveries, green.Sub(streak.Since))
+				delete(current, key)
+			}
+		}
+	}
+
+	return longest, recoveries
+}
+
+// flakyCommits finds commits with both failed and succeeded builds of the same pipeline
+func flakyCommits(builds []cache.Build) []FlakyCommit {
+	type commitKey struct {
+		definition int
+		pipeline   string
+		commit     string
+	}
+
+	index := map[commitKey]*FlakyCommit{}
+	keys := []commitKey{}
+	for _, b := range builds {
+		if b.SourceVersion == "" || (b.Result != "failed" && b.Result != "succeeded") {
+			continue
+		}
+
+		key := commitKey{b.DefinitionID, b.Repository, b.SourceVersion}
+		commit := index[key]
+		if commit == nil {
+			commit = &FlakyCommit{Pipeline: b.Repository, Branch: b.Branch, Commit: b.SourceVersion}
+			index[key] = commit
+			keys = append(keys, key)
+		}
+		commit.BuildIDs = append(commit.BuildIDs, b.BuildID)
+		if b.Result == "failed" {
+			commit.Failed++
+		} else {
+			commit.Succeeded++
+		}
+	}
+
+	flaky := []FlakyCommit{}
+	for _, key := range keys {
+		if c := index[key]; c.Failed > 0 && c.Succeeded > 0 {
+			flaky = append(flaky, *c)
+		}
+	}
+	return flaky
+}
+
+// buildQueueTime reads when the build was queued from its FullJSON
+func buildQueueTime(b cache.Build) (time.Time, bool) {
+	var data struct {
+		QueueTime *time.Time `json:"queueTime"`
+	}
+	if err := json.Unmarshal([]byte(b.FullJSON), &data); err != nil || data.QueueTime == nil {
+		return time.Time{}, false
+	}
+	return *data.QueueTime, true
+}
+
+// percentile returns the nearest-rank percentile of the durations, or 0 when empty
+func percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+func seconds(d time.Duration) int {
+	return int(d.Round(time.Second).Seconds())
+}
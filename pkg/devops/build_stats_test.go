@@ -0,0 +1,286 @@
+package devops
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"adoctl/pkg/cache"
+)
+
+var statsStart = time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+// statsBuild is a completed build of the ci pipeline started minute minutes after
+// statsStart, queued a minute earlier and running for ten minutes
+func statsBuild(id int, branch, commit, result string, minute int) cache.Build {
+	start := statsStart.Add(time.Duration(minute) * time.Minute)
+	return cache.Build{
+		BuildID:       id,
+		Repository:    "ci",
+		DefinitionID:  1,
+		Branch:        branch,
+		SourceVersion: commit,
+		Status:        "completed",
+		Result:        result,
+		StartTime:     start,
+		EndTime:       sql.NullTime{Time: start.Add(10 * time.Minute), Valid: true},
+		FullJSON:      fmt.Sprintf(`{"queueTime":%q}`, start.Add(-time.Minute).Format(time.RFC3339)),
+	}
+}
+
+func TestComputeBuildStats(t *testing.T) {
+	greenAt := statsStart.Add(40 * time.Minute)
+
+	tests := []struct {
+		name        string
+		builds      []cache.Build
+		want        StatsSummary
+		wantStreak  *RedStreak
+		wantFlaky   []string
+		wantByDay   []DayStats
+		wantPerPipe int
+	}{
+		{
+			name:      "no builds",
+			want:      StatsSummary{},
+			wantFlaky: []string{},
+			wantByDay: []DayStats{},
+		},
+		{
+			name:   "single build",
+			builds: []cache.Build{statsBuild(1, "main", "a", "succeeded", 0)},
+			want: StatsSummary{
+				Builds: 1, Succeeded: 1, SuccessRate: 1,
+				QueueP50Seconds: 60, QueueP90Seconds: 60, RunP50Seconds: 600, RunP90Seconds: 600,
+			},
+			wantFlaky:   []string{},
+			wantByDay:   []DayStats{{Date: statsStart.Local().Format(time.DateOnly), Builds: 1}},
+			wantPerPipe: 1,
+		},
+		{
+			name: "all failed is one open streak",
+			builds: []cache.Build{
+				statsBuild(3, "main", "c", "failed", 40),
+				statsBuild(1, "main", "a", "failed", 0),
+				statsBuild(2, "main", "b", "failed", 20),
+			},
+			want: StatsSummary{
+				Builds: 3, Failed: 3,
+				QueueP50Seconds: 60, QueueP90Seconds: 60, RunP50Seconds: 600, RunP90Seconds: 600,
+				LongestRedStreak: 3,
+			},
+			wantStreak:  &RedStreak{Pipeline: "ci", Branch: "main", Builds: 3, FirstBuildID: 1, LastBuildID: 3, Since: statsStart.Add(10 * time.Minute)},
+			wantFlaky:   []string{},
+			wantByDay:   []DayStats{{Date: statsStart.Local().Format(time.DateOnly), Builds: 3, Failed: 3}},
+			wantPerPipe: 1,
+		},
+		{
+			name: "commit that fails then succeeds is flaky",
+			builds: []cache.Build{
+				statsBuild(1, "main", "a", "failed", 0),
+				statsBuild(2, "main", "a", "succeeded", 30),
+				statsBuild(3, "main", "b", "canceled", 60),
+			},
+			want: StatsSummary{
+				Builds: 3, Succeeded: 1, Failed: 1, Canceled: 1, SuccessRate: 0.5,
+				QueueP50Seconds: 60, QueueP90Seconds: 60, RunP50Seconds: 600, RunP90Seconds: 600,
+				LongestRedStreak: 1, Recoveries: 1, TimeToGreenP50Seconds: 1800, TimeToGreenMaxSeconds: 1800,
+				FlakyCommits: 1,
+			},
+			wantStreak:  &RedStreak{Pipeline: "ci", Branch: "main", Builds: 1, FirstBuildID: 1, LastBuildID: 1, Since: statsStart.Add(10 * time.Minute), GreenAt: &greenAt},
+			wantFlaky:   []string{"a"},
+			wantByDay:   []DayStats{{Date: statsStart.Local().Format(time.DateOnly), Builds: 3, Failed: 1}},
+			wantPerPipe: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := ComputeBuildStats(tt.builds)
+
+			if stats.Summary != tt.want {
+				t.Errorf("Summary = %+v\nwant      %+v", stats.Summary, tt.want)
+			}
+			if len(stats.Pipelines) != tt.wantPerPipe {
+				t.Errorf("Pipelines = %d, want %d", len(stats.Pipelines), tt.wantPerPipe)
+			}
+			if !reflect.DeepEqual(stats.LongestRed, tt.wantStreak) {
+				t.Errorf("LongestRed = %+v, want %+v", stats.LongestRed, tt.wantStreak)
+			}
+
+			flaky := []string{}
+			for _, f := range stats.Flaky {
+				flaky = append(flaky, f.Commit)
+			}
+			if !reflect.DeepEqual(flaky, tt.wantFlaky) {
+				t.Errorf("Flaky = %v, want %v", flaky, tt.wantFlaky)
+			}
+			if !reflect.DeepEqual(stats.FailuresByDay, tt.wantByDay) {
+				t.Errorf("FailuresByDay = %+v, want %+v", stats.FailuresByDay, tt.wantByDay)
+			}
+		})
+	}
+}
+
+func TestRedStreaks(t *testing.T) {
+	tests := []struct {
+		name        string
+		builds      []cache.Build
+		wantBuilds  int
+		wantGreen   bool
+		wantRecover []time.Duration
+	}{
+		{
+			name:        "no builds",
+			wantRecover: []time.Duration{},
+		},
+		{
+			name:        "single success",
+			builds:      []cache.Build{statsBuild(1, "main", "a", "succeeded", 0)},
+			wantRecover: []time.Duration{},
+		},
+		{
+			name: "all failed",
+			builds: []cache.Build{
+				statsBuild(1, "main", "a", "failed", 0),
+				statsBuild(2, "main", "b", "failed", 20),
+			},
+			wantBuilds:  2,
+			wantRecover: []time.Duration{},
+		},
+		{
+			name: "recovery runs from the first failure to the next success",
+			builds: []cache.Build{
+				statsBuild(1, "main", "a", "failed", 0),
+				statsBuild(2, "main", "b", "canceled", 10),
+				statsBuild(3, "main", "c", "partiallySucceeded", 20),
+				statsBuild(4, "main", "d", "failed", 30),
+				statsBuild(5, "main", "e", "succeeded", 60),
+			},
+			wantBuilds:  2,
+			wantGreen:   true,
+			wantRecover: []time.Duration{time.Hour},
+		},
+		{
+			name: "branches are separate streaks",
+			builds: []cache.Build{
+				statsBuild(1, "main", "a", "failed", 0),
+				statsBuild(2, "feature", "b", "succeeded", 10),
+				statsBuild(3, "main", "c", "failed", 20),
+			},
+			wantBuilds:  2,
+			wantRecover: []time.Duration{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			longest, recoveries := redStreaks(tt.builds)
+
+			builds := 0
+			if longest != nil {
+				builds = longest.Builds
+				if (longest.GreenAt != nil) != tt.wantGreen {
+					t.Errorf("GreenAt = %v, want green %v", longest.GreenAt, tt.wantGreen)
+				}
+			}
+			if builds != tt.wantBuilds {
+				t.Errorf("longest streak = %d builds, want %d", builds, tt.wantBuilds)
+			}
+			if !reflect.DeepEqual(recoveries, tt.wantRecover) {
+				t.Errorf("recoveries = %v, want %v", recoveries, tt.wantRecover)
+			}
+		})
+	}
+}
+
+func TestFlakyCommits(t *testing.T) {
+	otherPipeline := statsBuild(2, "main", "a", "succeeded", 10)
+	otherPipeline.Repository, otherPipeline.DefinitionID = "deploy", 2
+
+	tests := []struct {
+		name   string
+		builds []cache.Build
+		want   []FlakyCommit
+	}{
+		{
+			name: "no builds",
+			want: []FlakyCommit{},
+		},
+		{
+			name:   "single build",
+			builds: []cache.Build{statsBuild(1, "main", "a", "failed", 0)},
+			want:   []FlakyCommit{},
+		},
+		{
+			name: "fails then succeeds on the same commit",
+			builds: []cache.Build{
+				statsBuild(1, "main", "a", "failed", 0),
+				statsBuild(2, "main", "a", "failed", 10),
+				statsBuild(3, "main", "a", "succeeded", 20),
+			},
+			want: []FlakyCommit{{Pipeline: "ci", Branch: "main", Commit: "a", Failed: 2, Succeeded: 1, BuildIDs: []int{1, 2, 3}}},
+		},
+		{
+			name: "same commit in another pipeline",
+			builds: []cache.Build{
+				statsBuild(1, "main", "a", "failed", 0),
+				otherPipeline,
+			},
+			want: []FlakyCommit{},
+		},
+		{
+			name: "canceled and partial results are ignored",
+			builds: []cache.Build{
+				statsBuild(1, "main", "a", "canceled", 0),
+				statsBuild(2, "main", "a", "partiallySucceeded", 10),
+				statsBuild(3, "main", "a", "succeeded", 20),
+			},
+			want: []FlakyCommit{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := flakyCommits(tt.builds)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("flakyCommits() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	minutes := func(values ...int) []time.Duration {
+		durations := make([]time.Duration, 0, len(values))
+		for _, v := range values {
+			durations = append(durations, time.Duration(v)*time.Minute)
+		}
+		return durations
+	}
+
+	tests := []struct {
+		name      string
+		durations []time.Duration
+		p         float64
+		want      time.Duration
+	}{
+		{name: "empty", durations: nil, p: 50, want: 0},
+		{name: "single value", durations: minutes(7), p: 90, want: 7 * time.Minute},
+		{name: "median of odd count", durations: minutes(9, 1, 5), p: 50, want: 5 * time.Minute},
+		{name: "median of even count is the lower middle", durations: minutes(4, 1, 3, 2), p: 50, want: 2 * time.Minute},
+		{name: "p90 of ten", durations: minutes(1, 2, 3, 4, 5, 6, 7, 8, 9, 10), p: 90, want: 9 * time.Minute},
+		{name: "p100 is the maximum", durations: minutes(3, 8, 1), p: 100, want: 8 * time.Minute},
+		{name: "p0 is the minimum", durations: minutes(3, 8, 1), p: 0, want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentile(tt.durations, tt.p); got != tt.want {
+				t.Errorf("percentile(%v, %v) = %v, want %v", tt.durations, tt.p, got, tt.want)
+			}
+		})
+	}
+}
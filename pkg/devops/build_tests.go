@@ -0,0 +1,279 @@
+package devops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/test"
+)
+
+// failedTestOutcomes are the result outcomes counted as test failures
+var failedTestOutcomes = []test.TestOutcome{
+	test.TestOutcomeValues.Failed,
+	test.TestOutcomeValues.Error,
+	test.TestOutcomeValues.Timeout,
+	test.TestOutcomeValues.Aborted,
+}
+
+// Failure comparison states against the previous build
+const (
+	FailureNew      = "new"
+	FailureExisting = "existing"
+	FailureUnknown  = "unknown"
+)
+
+// TestRunSummary is the outcome counts of one test run
+type TestRunSummary struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	State   string `json:"state"`
+	Total   int    `json:"total"`
+	Passed  int    `json:"passed"`
+	Failed  int    `json:"failed"`
+	Skipped int    `json:"skipped"`
+	Other   int    `json:"other"`
+	URL     string `json:"url,omitempty"`
+}
+
+// FailedTest is a failing test result of a build
+type FailedTest struct {
+	RunID      int     `json:"runId"`
+	RunName    string  `json:"runName"`
+	Name       string  `json:"name"`
+	Outcome    string  `json:"outcome"`
+	Error      string  `json:"error,omitempty"`
+	StackTrace string  `json:"stackTrace,omitempty"`
+	DurationMs float64 `json:"durationMs"`
+	// Comparison is new, existing (also failed in the previous build) or unknown
+	Comparison string `json:"comparison"`
+}
+
+// BuildTestReport is the test outcome of a build
+type BuildTestReport struct {
+	BuildID int `json:"buildId"`
+	// PreviousBuildID is the build failures were compared against, or 0
+	PreviousBuildID int `json:"previousBuildId,omitempty"`
+	// NoBaseline is set when the previous build has no test runs, so failures
+	// cannot be compared and stay unknown
+	NoBaseline bool             `json:"noBaseline,omitempty"`
+	Runs       []TestRunSummary `json:"runs"`
+	Failed     []FailedTest     `json:"failed"`
+}
+
+// NewFailures returns the number of failures not seen in the previous build
+func (r *BuildTestReport) NewFailures() int {
+	count := 0
+	for _, f := range r.Failed {
+		if f.Comparison == FailureNew {
+			count++
+		}
+	}
+	return count
+}
+
+// GetBuildTestRuns summarises the test runs of a build
+func (s *DevOpsService) GetBuildTestRuns(ctx context.Context, buildID int) ([]TestRunSummary, error) {
+	runs, err := s.client.GetTestRunsForBuild(ctx, buildID)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]TestRunSummary, 0, len(runs))
+	for _, run := range runs {
+		summaries = append(summaries, summarizeTestRun(run))
+	}
+	return summaries, nil
+}
+
+func summarizeTestRun(run test.TestRun) TestRunSummary {
+	summary := TestRunSummary{}
+	if run.Id != nil {
+		summary.ID = *run.Id
+	}
+	if run.Name != nil {
+		summary.Name = *run.Name
+	}
+	if run.State != nil {
+		summary.State = *run.State
+	}
+	if run.TotalTests != nil {
+		summary.Total = *run.TotalTests
+	}
+	if run.WebAccessUrl != nil {
+		summary.URL = *run.WebAccessUrl
+	}
+
+	if run.RunStatistics == nil {
+		if run.PassedTests != nil {
+			summary.Passed = *run.PassedTests
+		}
+		if run.UnanalyzedTests != nil {
+			summary.Failed = *run.UnanalyzedTests
+		}
+		summary.Other = summary.Total - summary.Passed - summary.Failed
+		return summary
+	}
+
+	for _, stat := range *run.RunStatistics {
+		if stat.Count == nil || stat.Outcome == nil {
+			continue
+		}
+		switch {
+		case strings.EqualFold(*stat.Outcome, string(test.TestOutcomeValues.Passed)):
+			summary.Passed += *stat.Count
+		case isFailedOutcome(*stat.Outcome):
+			summary.Failed += *stat.Count
+		case strings.EqualFold(*stat.Outcome, string(test.TestOutcomeValues.NotExecuted)),
+			strings.EqualFold(*stat.Outcome, string(test.TestOutcomeValues.NotApplicable)):
+			summary.Skipped += *stat.Count
+		default:
+			summary.Other += *stat.Count
+		}
+	}
+	return summary
+}
+
+func isFailedOutcome(outcome string) bool {
+	for _, failed := range failedTestOutcomes {
+		if strings.EqualFold(outcome, string(failed)) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetFailedTestCount returns the number of failed tests of a build
+func (s *DevOpsService) GetFailedTestCount(ctx context.Context, buildID int) (int, error) {
+	runs, err := s.GetBuildTestRuns(ctx, buildID)
+	if err != nil {
+		return 0, err
+	}
+
+	failed := 0
+	for _, run := range runs {
+		failed += run.Failed
+	}
+	return failed, nil
+}
+
+// GetFailedTests returns the failing test results of the given runs
+func (s *DevOpsService) GetFailedTests(ctx context.Context, runs []TestRunSummary) ([]FailedTest, error) {
+	failed := []FailedTest{}
+	for _, run := range runs {
+		if run.Failed == 0 {
+			continue
+		}
+
+		results, err := s.client.GetTestResults(ctx, run.ID, failedTestOutcomes)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range results {
+			f := FailedTest{RunID: run.ID, RunName: run.Name, Comparison: FailureUnknown}
+			switch {
+			case result.AutomatedTestName != nil && *result.AutomatedTestName != "":
+				f.Name = *result.AutomatedTestName
+			case result.TestCaseTitle != nil:
+				f.Name = *result.TestCaseTitle
+			}
+			if result.Outcome != nil {
+				f.Outcome = *result.Outcome
+			}
+			if result.ErrorMessage != nil {
+				f.Error = strings.TrimSpace(*result.ErrorMessage)
+			}
+			if result.StackTrace != nil {
+				f.StackTrace = strings.TrimSpace(*result.StackTrace)
+			}
+			if result.DurationInMs != nil {
+				f.DurationMs = *result.DurationInMs
+			}
+			failed = append(failed, f)
+		}
+	}
+	return failed, nil
+}
+
+// GetBuildTestReport fetches the test runs and failed tests of a build. With
+// compare, failures are checked against the previous completed build of the same
+// pipeline and branch, found in the builds cache.
+func (s *DevOpsService) GetBuildTestReport(ctx context.Context, b *build.Build, compare bool) (*BuildTestReport, error) {
+	report := &BuildTestReport{BuildID: *b.Id}
+
+	runs, err := s.GetBuildTestRuns(ctx, *b.Id)
+	if err != nil {
+		return nil, err
+	}
+	report.Runs = runs
+
+	report.Failed, err = s.GetFailedTests(ctx, runs)
+	if err != nil {
+		return nil, err
+	}
+
+	if !compare || len(report.Failed) == 0 {
+		return report, nil
+	}
+
+	previous, err := s.findPreviousBuild(b)
+	if err != nil || previous == 0 {
+		return report, err
+	}
+	report.PreviousBuildID = previous
+
+	previousRuns, err := s.GetBuildTestRuns(ctx, previous)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tests of previous build %d: %w", previous, err)
+	}
+	// Without test runs, e.g. when the previous build failed before its tests ran,
+	// every failure would look new
+	if len(previousRuns) == 0 {
+		report.NoBaseline = true
+		return report, nil
+	}
+	previousFailed, err := s.GetFailedTests(ctx, previousRuns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tests of previous build %d: %w", previous, err)
+	}
+
+	CompareFailures(report.Failed, previousFailed)
+	return report, nil
+}
+
+// CompareFailures marks each failure as new or existing depending on whether a
+// test of the same name failed in previous
+func CompareFailures(current, previous []FailedTest) {
+	seen := make(map[string]bool, len(previous))
+	for _, f := range previous {
+		seen[f.Name] = true
+	}
+	for i := range current {
+		if seen[current[i].Name] {
+			current[i].Comparison = FailureExisting
+		} else {
+			current[i].Comparison = FailureNew
+		}
+	}
+}
+
+// findPreviousBuild returns the ID of the previous completed build of the same
+// pipeline and branch from the cache, or 0
+func (s *DevOpsService) findPreviousBuild(b *build.Build) (int, error) {
+	if s.cache == nil || b.Definition == nil || b.Definition.Id == nil || b.SourceBranch == nil {
+		return 0, nil
+	}
+
+	s.syncBuildsOnce()
+	branch := strings.Replace(*b.SourceBranch, "refs/heads/", "", 1)
+	previous, err := s.cache.GetPreviousBuild(*b.Definition.Id, branch, *b.Id)
+	if err != nil {
+		return 0, err
+	}
+	if previous == nil {
+		return 0, nil
+	}
+	return previous.BuildID, nil
+}
@@ -0,0 +1,115 @@
+package devops
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/test"
+)
+
+func TestCompareFailures(t *testing.T) {
+	failed := func(names ...string) []FailedTest {
+		tests := []FailedTest{}
+		for _, name := range names {
+			tests = append(tests, FailedTest{Name: name, Comparison: FailureUnknown})
+		}
+		return tests
+	}
+
+	tests := []struct {
+		name     string
+		current  []FailedTest
+		previous []FailedTest
+		want     []string
+	}{
+		{
+			name:     "previous build passed",
+			current:  failed("A", "B"),
+			previous: nil,
+			want:     []string{FailureNew, FailureNew},
+		},
+		{
+			name:     "mix of new and pre-existing",
+			current:  failed("A", "B", "C"),
+			previous: failed("B", "D"),
+			want:     []string{FailureNew, FailureExisting, FailureNew},
+		},
+		{
+			name:     "names are compared exactly",
+			current:  failed("Suite.Test"),
+			previous: failed("suite.test"),
+			want:     []string{FailureNew},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			CompareFailures(tt.current, tt.previous)
+
+			got := []string{}
+			for _, f := range tt.current {
+				got = append(got, f.Comparison)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompareFailures() comparisons = %v, want %v", got, tt.want)
+			}
+
+			report := BuildTestReport{Failed: tt.current}
+			wantNew := 0
+			for _, c := range tt.want {
+				if c == FailureNew {
+					wantNew++
+				}
+			}
+			if report.NewFailures() != wantNew {
+				t.Errorf("NewFailures() = %d, want %d", report.NewFailures(), wantNew)
+			}
+		})
+	}
+}
+
+func TestSummarizeTestRun(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	stat := func(outcome string, count int) test.RunStatistic {
+		return test.RunStatistic{Outcome: &outcome, Count: intPtr(count)}
+	}
+
+	tests := []struct {
+		name string
+		run  test.TestRun
+		want TestRunSummary
+	}{
+		{
+			name: "outcome statistics",
+			run: test.TestRun{
+				Id:         intPtr(3),
+				TotalTests: intPtr(20),
+				RunStatistics: &[]test.RunStatistic{
+					stat("Passed", 12),
+					stat("Failed", 2),
+					stat("Timeout", 1),
+					stat("NotExecuted", 3),
+					stat("Inconclusive", 2),
+				},
+			},
+			want: TestRunSummary{ID: 3, Total: 20, Passed: 12, Failed: 3, Skipped: 3, Other: 2},
+		},
+		{
+			name: "totals without statistics",
+			run: test.TestRun{
+				TotalTests:      intPtr(10),
+				PassedTests:     intPtr(7),
+				UnanalyzedTests: intPtr(2),
+			},
+			want: TestRunSummary{Total: 10, Passed: 7, Failed: 2, Other: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summarizeTestRun(tt.run); got != tt.want {
+				t.Errorf("summarizeTestRun() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package devops
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/build"
+)
+
+// WatchOptions controls how often WatchBuild polls. The interval starts at
+// MinInterval, grows by half after each poll without progress up to MaxInterval,
+// and drops back to MinInterval when a stage starts or finishes.
+type WatchOptions struct {
+	MinInterval time.Duration
+	MaxInterval time.Duration
+}
+
+// BuildProgress is a build with its top-level timeline records: stages, or jobs
+// for pipelines without stages
+type BuildProgress struct {
+	Build  *build.Build
+	Stages []*TimelineNode
+}
+
+// CompletedStages counts the stages that have finished
+func (p BuildProgress) CompletedStages() int {
+	completed := 0
+	for _, stage := range p.Stages {
+		if stage.State == string(build.TimelineRecordStateValues.Completed) {
+			completed++
+		}
+	}
+	return completed
+}
+
+// CurrentStage returns the first stage in progress, or nil
+func (p BuildProgress) CurrentStage() *TimelineNode {
+	for _, stage := range p.Stages {
+		if stage.State == string(build.TimelineRecordStateValues.InProgress) {
+			return stage
+		}
+	}
+	return nil
+}
+
+// FindLatestBuild returns the most recently queued build of a branch, optionally
+// of one pipeline definition (0 for any)
+func (s *DevOpsService) FindLatestBuild(ctx context.Context, branch string, definitionID int) (*build.Build, error) {
+	params := map[string]string{
+		"branchName": "refs/heads/" + branch,
+		"queryOrder": string(build.BuildQueryOrderValues.QueueTimeDescending),
+		"$top":       "1",
+	}
+	if definitionID != 0 {
+		params["definitions"] = strconv.Itoa(definitionID)
+	}
+
+	builds, err := s.client.GetBuilds(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if len(builds) == 0 || builds[0].Id == nil {
+		return nil, fmt.Errorf("no builds found on branch '%s'", branch)
+	}
+	return &builds[0], nil
+}
+
+// WatchBuild polls a build with backoff until it completes or watchCtx is done,
+// in which case the error is watchCtx.Err(). onUpdate is called after each poll
+// of a build that is still running. Each request gets its own timeout from newCtx.
+func (s *DevOpsService) WatchBuild(watchCtx context.Context, newCtx func() (context.Context, context.CancelFunc), buildID int, opts WatchOptions, onUpdate func(BuildProgress)) (*build.Build, error) {
+	interval := opts.MinInterval
+	lastCompleted := -1
+	lastStage := ""
+
+	for {
+		ctx, cancel := newCtx()
+		b, err := s.client.GetBuildByID(ctx, buildID)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		if b.Status != nil && *b.Status == build.BuildStatusValues.Completed {
+			return b, nil
+		}
+
+		progress := BuildProgress{Build: b}
+		ctx, cancel = newCtx()
+		stages, err := s.GetBuildTimeline(ctx, buildID)
+		cancel()
+		// A queued build has no timeline yet; keep polling without stage progress
+		if err == nil {
+			progress.Stages = stages
+		}
+
+		current := ""
+		if stage := progress.CurrentStage(); stage != nil {
+			current = stage.ID
+		}
+		if completed := progress.CompletedStages(); completed != lastCompleted || current != lastStage {
+			interval = opts.MinInterval
+			lastCompleted, lastStage = completed, current
+		} else {
+			interval = min(interval+interval/2, opts.MaxInterval)
+		}
+
+		if onUpdate != nil {
+			onUpdate(progress)
+		}
+
+		select {
+		case <-watchCtx.Done():
+			return nil, watchCtx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package devops
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+)
+
+// PRConflict is a file that cannot be merged automatically
+type PRConflict struct {
+	ID   int    `json:"id"`
+	Path string `json:"path"`
+	// Type is the short conflict kind, e.g. edit/edit or delete/edit (source/target)
+	Type string `json:"type"`
+	// Side describes which side changed what
+	Side string `json:"side"`
+}
+
+type conflictKind struct {
+	label string
+	side  string
+}
+
+// conflictKinds describes each conflict type as source/target
+var conflictKinds = map[git.GitConflictType]conflictKind{
+	git.GitConflictTypeValues.EditEdit:       {"edit/edit", "edited on both sides"},
+	git.GitConflictTypeValues.AddAdd:         {"add/add", "added on both sides with different content"},
+	git.GitConflictTypeValues.DeleteEdit:     {"delete/edit", "deleted on source, edited on target"},
+	git.GitConflictTypeValues.EditDelete:     {"edit/delete", "edited on source, deleted on target"},
+	git.GitConflictTypeValues.Rename1to2:     {"rename", "renamed to different paths on each side"},
+	git.GitConflictTypeValues.Rename2to1:     {"rename", "different files renamed to this path on each side"},
+	git.GitConflictTypeValues.RenameAdd:      {"rename/add", "renamed to this path on source, added on target"},
+	git.GitConflictTypeValues.AddRename:      {"add/rename", "added on source, renamed to this path on target"},
+	git.GitConflictTypeValues.RenameDelete:   {"rename/delete", "renamed on source, deleted on target"},
+	git.GitConflictTypeValues.DeleteRename:   {"delete/rename", "deleted on source, renamed on target"},
+	git.GitConflictTypeValues.DirectoryFile:  {"directory/file", "directory on source, file on target"},
+	git.GitConflictTypeValues.FileDirectory:  {"file/directory", "file on source, directory on target"},
+	git.GitConflictTypeValues.DirectoryChild: {"directory child", "inside a directory/file conflict"},
+}
+
+// DescribeConflictType returns the short label and side description of a conflict type
+func DescribeConflictType(conflictType git.GitConflictType) (string, string) {
+	if kind, ok := conflictKinds[conflictType]; ok {
+		return kind.label, kind.side
+	}
+	return string(conflictType), ""
+}
+
+// GetPullRequestConflicts returns the unresolved conflicts of a PR, sorted by path
+func (s *DevOpsService) GetPullRequestConflicts(ctx context.Context, repositoryID string, prID int) ([]PRConflict, error) {
+	conflicts, err := s.client.GetPullRequestConflicts(ctx, repositoryID, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]PRConflict, 0, len(conflicts))
+	for _, c := range conflicts {
+		conflict := PRConflict{}
+		if c.ConflictId != nil {
+			conflict.ID = *c.ConflictId
+		}
+		if c.ConflictPath != nil {
+			conflict.Path = *c.ConflictPath
+		}
+		if c.ConflictType != nil {
+			conflict.Type, conflict.Side = DescribeConflictType(*c.ConflictType)
+		}
+		result = append(result, conflict)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+
+	return result, nil
+}
+
+// conflictsWarning formats the merge conflict warning, with the file count when known
+func conflictsWarning(files int) string {
+	if files <= 0 {
+		return "Has merge conflicts"
+	}
+	if files == 1 {
+		return "Has merge conflicts (1 file)"
+	}
+	return fmt.Sprintf("Has merge conflicts (%d files)", files)
+}
@@ -140,6 +140,9 @@ func (s *DevOpsService) SyncDeployment(azureDeployment release.Deployment) error
 		BuildID:       buildId,
 		FullJSON:      string(FullJSON),
 	}
+	if err := deploymentData.FillLinkageFromJSON(); err != nil {
+		return err
+	}
 
 	err = s.cache.SaveDeployment(deploymentData)
 	if err != nil {
@@ -0,0 +1,235 @@
+package devops
+
+import (
+	"fmt"
+	"html"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"adoctl/pkg/cache"
+)
+
+// MatrixCell is the latest successful deployment of a release definition to an
+// environment
+type MatrixCell struct {
+	Environment string `json:"environment"`
+	// ReleaseID is 0 when the environment has no successful deployment
+	ReleaseID   int        `json:"releaseId,omitempty"`
+	ReleaseName string     `json:"releaseName,omitempty"`
+	BuildID     int        `json:"buildId,omitempty"`
+	Branch      string     `json:"branch,omitempty"`
+	Commit      string     `json:"commit,omitempty"`
+	DeployedAt  *time.Time `json:"deployedAt,omitempty"`
+	// Behind is set when the environment runs an older release than the one before it
+	Behind bool `json:"behind"`
+}
+
+// MatrixRow holds the environments of one release definition, in pipeline order
+type MatrixRow struct {
+	DefinitionID int          `json:"definitionId"`
+	Definition   string       `json:"definition"`
+	Cells        []MatrixCell `json:"environments"`
+}
+
+// Cell returns the cell of an environment, or nil when the definition has no such environment
+func (r MatrixRow) Cell(environment string) *MatrixCell {
+	for i := range r.Cells {
+		if strings.EqualFold(r.Cells[i].Environment, environment) {
+			return &r.Cells[i]
+		}
+	}
+	return nil
+}
+
+// DeploymentMatrix is what is deployed where: release definitions by environments
+type DeploymentMatrix struct {
+	// Environments are the environment names of all rows, in pipeline order
+	Environments []string    `json:"environmentNames"`
+	Rows         []MatrixRow `json:"definitions"`
+}
+
+// GetDeploymentMatrix builds the deployment matrix from the deployments cache,
+// optionally limited to the release definition with the given name or ID
+func (s *DevOpsService) GetDeploymentMatrix(definition string) (*DeploymentMatrix, error) {
+	if s.cache == nil {
+		return nil, fmt.Errorf("cache not initialized")
+	}
+
+	deployments, err := s.SearchDeploymentsCached(map[string]any{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search deployments in cache: %w", err)
+	}
+
+	return BuildDeploymentMatrix(deployments, definition), nil
+}
+
+// BuildDeploymentMatrix groups deployments by release definition and environment,
+// keeping the latest successful deployment of each. Environments are ordered by
+// their definition environment ID, which follows the order they were added in.
+func BuildDeploymentMatrix(deployments []cache.Deployment, definition string) *DeploymentMatrix {
+	type envKey struct {
+		definitionID  int
+		environmentID int
+	}
+
+	rows := map[int]*MatrixRow{}
+	environments := map[int]map[int]string{}
+	latest := map[envKey]cache.Deployment{}
+
+	for _, d := range deployments {
+		if d.DefinitionID == 0 || !matchesDefinition(d, definition) {
+			continue
+		}
+
+		if _, ok := rows[d.DefinitionID]; !ok {
+			rows[d.DefinitionID] = &MatrixRow{DefinitionID: d.DefinitionID, Definition: d.DefinitionName}
+			environments[d.DefinitionID] = map[int]string{}
+		}
+		environments[d.DefinitionID][d.EnvironmentID] = d.Environment
+
+		if d.Status != "succeeded" {
+			continue
+		}
+		key := envKey{d.DefinitionID, d.EnvironmentID}
+		if current, ok := latest[key]; !ok || deployedAt(d).After(deployedAt(current)) {
+			latest[key] = d
+		}
+	}
+
+	matrix := &DeploymentMatrix{Environments: []string{}, Rows: []MatrixRow{}}
+	for definitionID, row := range rows {
+		envIDs := make([]int, 0, len(environments[definitionID]))
+		for id := range environments[definitionID] {
+			envIDs = append(envIDs, id)
+		}
+		sort.Ints(envIDs)
+
+		for i, envID := range envIDs {
+			cell := MatrixCell{Environment: environments[definitionID][envID]}
+			if d, ok := latest[envKey{definitionID, envID}]; ok {
+				cell.ReleaseID = d.ReleaseID
+				cell.ReleaseName = d.ReleaseName
+				cell.BuildID = d.BuildID
+				cell.Branch = d.Branch
+				cell.Commit = d.SourceVersion
+				at := deployedAt(d)
+				cell.DeployedAt = &at
+			}
+			// Release IDs grow over time, so a lower ID is an older release
+			if i > 0 && row.Cells[i-1].ReleaseID > cell.ReleaseID {
+				cell.Behind = true
+			}
+			row.Cells = append(row.Cells, cell)
+		}
+		matrix.Rows = append(matrix.Rows, *row)
+	}
+
+	sort.Slice(matrix.Rows, func(i, j int) bool {
+		return strings.ToLower(matrix.Rows[i].Definition) < strings.ToLower(matrix.Rows[j].Definition)
+	})
+	for _, row := range matrix.Rows {
+		matrix.Environments = mergeEnvironmentOrder(matrix.Environments, row.Cells)
+	}
+
+	return matrix
+}
+
+func matchesDefinition(d cache.Deployment, definition string) bool {
+	if definition == "" {
+		return true
+	}
+	if id, err := strconv.Atoi(definition); err == nil {
+		return d.DefinitionID == id
+	}
+	return strings.EqualFold(d.DefinitionName, definition)
+}
+
+func deployedAt(d cache.Deployment) time.Time {
+	if d.EndTime.Valid {
+		return d.EndTime.Time
+	}
+	return d.StartTime
+}
+
+// mergeEnvironmentOrder adds the environments of a row to the combined column
+// order, placing each new name after the environment preceding it in the row
+func mergeEnvironmentOrder(order []string, cells []MatrixCell) []string {
+	position := -1
+	for _, cell := range cells {
+		found := -1
+		for i, name := range order {
+			if strings.EqualFold(name, cell.Environment) {
+				found = i
+				break
+			}
+		}
+		if found >= 0 {
+			position = found
+			continue
+		}
+
+		position++
+		order = slices.Insert(order, position, cell.Environment)
+	}
+	return order
+}
+
+// ShortCommit shortens a commit SHA for display
+func ShortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
+
+// GenerateHTMLDeploymentMatrix renders the matrix as an HTML table fragment for
+// clipboard rich text, with environments that are behind highlighted.
+func (s *DevOpsService) GenerateHTMLDeploymentMatrix(matrix *DeploymentMatrix) string {
+	var b strings.Builder
+
+	b.WriteString(`<table border="1" cellpadding="4" cellspacing="0"><tr><th>Definition</th>`)
+	for _, env := range matrix.Environments {
+		fmt.Fprintf(&b, "<th>%s</th>", html.EscapeString(env))
+	}
+	b.WriteString("</tr>")
+
+	for _, row := range matrix.Rows {
+		fmt.Fprintf(&b, "<tr><td><b>%s</b></td>", html.EscapeString(row.Definition))
+		for _, env := range matrix.Environments {
+			cell := row.Cell(env)
+			switch {
+			case cell == nil:
+				b.WriteString("<td></td>")
+				continue
+			case cell.Behind:
+				b.WriteString(`<td style="background-color:#fff4ce">`)
+			default:
+				b.WriteString("<td>")
+			}
+
+			if cell.ReleaseID == 0 {
+				b.WriteString("not deployed")
+			} else {
+				fmt.Fprintf(&b, `<a href="%s">%s</a>`, s.ReleaseWebURL(cell.ReleaseID), html.EscapeString(cell.ReleaseName))
+				if cell.BuildID != 0 {
+					fmt.Fprintf(&b, "<br>Build %d", cell.BuildID)
+				}
+				if cell.Branch != "" || cell.Commit != "" {
+					fmt.Fprintf(&b, "<br>%s@%s", html.EscapeString(cell.Branch), html.EscapeString(ShortCommit(cell.Commit)))
+				}
+				fmt.Fprintf(&b, "<br>%s", cell.DeployedAt.Local().Format("2006-01-02 15:04"))
+			}
+			if cell.Behind {
+				b.WriteString("<br>⚠ behind")
+			}
+			b.WriteString("</td>")
+		}
+		b.WriteString("</tr>")
+	}
+	b.WriteString("</table>")
+
+	return b.String()
+}
@@ -0,0 +1,144 @@
+package devops
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"adoctl/pkg/cache"
+)
+
+func TestBuildDeploymentMatrix(t *testing.T) {
+	day := func(n int) time.Time { return time.Date(2024, 1, n, 12, 0, 0, 0, time.UTC) }
+	deployment := func(definitionID int, definition string, envID int, env string, releaseID int, status string, at time.Time) cache.Deployment {
+		return cache.Deployment{
+			DefinitionID:   definitionID,
+			DefinitionName: definition,
+			EnvironmentID:  envID,
+			Environment:    env,
+			ReleaseID:      releaseID,
+			Status:         status,
+			StartTime:      at,
+		}
+	}
+
+	// cellRelease summarizes a matrix as definition -> environment -> release ID
+	type cellRelease map[string]map[string]int
+
+	tests := []struct {
+		name         string
+		deployments  []cache.Deployment
+		definition   string
+		environments []string
+		releases     cellRelease
+		behind       []string
+	}{
+		{
+			name:         "no deployments",
+			environments: []string{},
+			releases:     cellRelease{},
+		},
+		{
+			name: "latest successful deployment wins",
+			deployments: []cache.Deployment{
+				deployment(1, "app", 1, "dev", 1, "succeeded", day(1)),
+				deployment(1, "app", 1, "dev", 2, "succeeded", day(2)),
+				deployment(1, "app", 1, "dev", 3, "failed", day(3)),
+			},
+			environments: []string{"dev"},
+			releases:     cellRelease{"app": {"dev": 2}},
+		},
+		{
+			name: "environment without a success is empty",
+			deployments: []cache.Deployment{
+				deployment(1, "app", 1, "dev", 2, "succeeded", day(2)),
+				deployment(1, "app", 2, "prod", 2, "rejected", day(3)),
+			},
+			environments: []string{"dev", "prod"},
+			releases:     cellRelease{"app": {"dev": 2, "prod": 0}},
+			behind:       []string{"app/prod"},
+		},
+		{
+			name: "older release after a newer one is behind",
+			deployments: []cache.Deployment{
+				deployment(1, "app", 3, "prod", 4, "succeeded", day(1)),
+				deployment(1, "app", 2, "staging", 5, "succeeded", day(2)),
+				deployment(1, "app", 1, "dev", 6, "succeeded", day(3)),
+			},
+			environments: []string{"dev", "staging", "prod"},
+			releases:     cellRelease{"app": {"dev": 6, "staging": 5, "prod": 4}},
+			behind:       []string{"app/staging", "app/prod"},
+		},
+		{
+			name: "environment orders are merged across definitions",
+			deployments: []cache.Deployment{
+				deployment(1, "api", 1, "dev", 1, "succeeded", day(1)),
+				deployment(1, "api", 2, "prod", 1, "succeeded", day(1)),
+				deployment(2, "web", 1, "dev", 2, "succeeded", day(1)),
+				deployment(2, "web", 2, "qa", 2, "succeeded", day(1)),
+				deployment(2, "web", 3, "prod", 2, "succeeded", day(1)),
+			},
+			environments: []string{"dev", "qa", "prod"},
+			releases:     cellRelease{"api": {"dev": 1, "prod": 1}, "web": {"dev": 2, "qa": 2, "prod": 2}},
+		},
+		{
+			name: "definition by name",
+			deployments: []cache.Deployment{
+				deployment(1, "api", 1, "dev", 1, "succeeded", day(1)),
+				deployment(2, "web", 1, "dev", 2, "succeeded", day(1)),
+			},
+			definition:   "WEB",
+			environments: []string{"dev"},
+			releases:     cellRelease{"web": {"dev": 2}},
+		},
+		{
+			name: "definition by ID",
+			deployments: []cache.Deployment{
+				deployment(1, "api", 1, "dev", 1, "succeeded", day(1)),
+				deployment(2, "web", 1, "dev", 2, "succeeded", day(1)),
+			},
+			definition:   "1",
+			environments: []string{"dev"},
+			releases:     cellRelease{"api": {"dev": 1}},
+		},
+		{
+			name: "deployments without a definition are skipped",
+			deployments: []cache.Deployment{
+				deployment(0, "", 0, "", 1, "succeeded", day(1)),
+			},
+			environments: []string{},
+			releases:     cellRelease{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matrix := BuildDeploymentMatrix(tt.deployments, tt.definition)
+
+			if !reflect.DeepEqual(matrix.Environments, tt.environments) {
+				t.Errorf("Environments = %v, want %v", matrix.Environments, tt.environments)
+			}
+
+			releases := cellRelease{}
+			behind := []string{}
+			for _, row := range matrix.Rows {
+				releases[row.Definition] = map[string]int{}
+				for _, cell := range row.Cells {
+					releases[row.Definition][cell.Environment] = cell.ReleaseID
+					if cell.Behind {
+						behind = append(behind, row.Definition+"/"+cell.Environment)
+					}
+				}
+			}
+			if !reflect.DeepEqual(releases, tt.releases) {
+				t.Errorf("releases = %v, want %v", releases, tt.releases)
+			}
+			if tt.behind == nil {
+				tt.behind = []string{}
+			}
+			if !reflect.DeepEqual(behind, tt.behind) {
+				t.Errorf("behind = %v, want %v", behind, tt.behind)
+			}
+		})
+	}
+}
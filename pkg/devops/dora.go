@@ -0,0 +1,267 @@
+package devops
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"adoctl/pkg/cache"
+	"adoctl/pkg/logger"
+)
+
+// DoraReport holds the four DORA metrics of deployments to one environment
+type DoraReport struct {
+	Environment string    `json:"environment"`
+	Since       time.Time `json:"since"`
+	Until       time.Time `json:"until"`
+	// DataFrom is the start of the oldest cached deployment to the environment;
+	// metrics before it are missing from the cache
+	DataFrom *time.Time    `json:"dataFrom,omitempty"`
+	Summary  DoraMetrics   `json:"summary"`
+	Groups   []DoraMetrics `json:"groups"`
+}
+
+// DoraMetrics are the DORA metrics of a repository, a team or all deployments
+type DoraMetrics struct {
+	Group string `json:"group,omitempty"`
+	// Deployments counts the succeeded deployments
+	Deployments        int     `json:"deployments"`
+	DeploymentsPerWeek float64 `json:"deploymentsPerWeek"`
+	// LeadTimeChanges counts the merged pull requests that reached the environment
+	LeadTimeChanges    int `json:"leadTimeChanges"`
+	LeadTimeP50Seconds int `json:"leadTimeP50Seconds"`
+	LeadTimeP90Seconds int `json:"leadTimeP90Seconds"`
+	// Attempts counts the succeeded, failed and partially succeeded deployments
+	Attempts int `json:"attempts"`
+	Failed   int `json:"failed"`
+	// RolledBack counts succeeded deployments replaced by an older release
+	RolledBack              int     `json:"rolledBack"`
+	ChangeFailureRate       float64 `json:"changeFailureRate"`
+	Restores                int     `json:"restores"`
+	TimeToRestoreP50Seconds int     `json:"timeToRestoreP50Seconds"`
+	TimeToRestoreMaxSeconds int     `json:"timeToRestoreMaxSeconds"`
+}
+
+// DoraChange is a merged pull request, the unit of change for lead time
+type DoraChange struct {
+	PullRequestID int
+	Repository    string
+	TargetBranch  string
+	CreatedAt     time.Time
+	MergedAt      time.Time
+}
+
+// GetDoraReport computes the DORA metrics of deployments to an environment since
+// the given time from the deployments and builds caches, and the pull requests
+// completed in the period. groupOf maps a repository to its group in the report.
+func (s *DevOpsService) GetDoraReport(ctx context.Context, environment string, since time.Time, groupOf func(repository string) string) (*DoraReport, error) {
+	if s.cache == nil {
+		return nil, fmt.Errorf("cache not initialized")
+	}
+
+	s.syncBuildsOnce()
+	cached, err := s.SearchDeploymentsCached(map[string]any{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search deployments in cache: %w", err)
+	}
+
+	deployments := []cache.Deployment{}
+	buildStarts := map[int]time.Time{}
+	for _, d := range cached {
+		if !strings.EqualFold(d.Environment, environment) {
+			continue
+		}
+		deployments = append(deployments, d)
+
+		if d.BuildID == 0 || d.Status != "succeeded" {
+			continue
+		}
+		if _, ok := buildStarts[d.BuildID]; ok {
+			continue
+		}
+		if b, err := s.cache.GetBuildByID(d.BuildID); err == nil && b != nil {
+			buildStarts[d.BuildID] = b.StartTime
+		}
+	}
+
+	prs, err := s.client.GetCompletedPullRequestsSince(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]DoraChange, 0, len(prs))
+	for _, pr := range prs {
+		if pr.PullRequestId == nil || pr.CreationDate == nil || pr.ClosedDate == nil || pr.Repository == nil || pr.Repository.Name == nil {
+			continue
+		}
+		change := DoraChange{
+			PullRequestID: *pr.PullRequestId,
+			Repository:    *pr.Repository.Name,
+			CreatedAt:     pr.CreationDate.Time,
+			MergedAt:      pr.ClosedDate.Time,
+		}
+		if pr.TargetRefName != nil {
+			change.TargetBranch = *pr.TargetRefName
+		}
+		changes = append(changes, change)
+	}
+	logger.Debug().Int("deployments", len(deployments)).Int("changes", len(changes)).Msg("Computing DORA metrics")
+
+	return ComputeDoraReport(deployments, changes, buildStarts, environment, since, time.Now(), groupOf), nil
+}
+
+// doraEvent is a deployment with the group it counts towards and its outcome
+type doraEvent struct {
+	deployment cache.Deployment
+	at         time.Time
+	group      string
+	failed     bool
+	rolledBack bool
+}
+
+// ComputeDoraReport computes the DORA metrics of deployments to an environment
+// between since and until.
+//
+// A failed or partially succeeded deployment, or a succeeded one later replaced by
+// an older release of the same definition (a rollback), is a failed change. Time to
+// restore runs from a failed change to the next succeeded deployment of that
+// definition. Lead time runs from PR creation to the first succeeded deployment of
+// a build of the PR's repository and target branch started after the merge;
+// buildStarts maps build IDs to their start, falling back to the deployment start.
+func ComputeDoraReport(deployments []cache.Deployment, changes []DoraChange, buildStarts map[int]time.Time, environment string, since, until time.Time, groupOf func(repository string) string) *DoraReport {
+	report := &DoraReport{Environment: environment, Since: since, Until: until, Groups: []DoraMetrics{}}
+
+	events := make([]*doraEvent, 0, len(deployments))
+	for _, d := range deployments {
+		switch d.Status {
+		case "succeeded", "failed", "partiallySucceeded":
+		default:
+			continue
+		}
+		events = append(events, &doraEvent{deployment: d, at: deployedAt(d), group: groupOf(d.Repository)})
+		if report.DataFrom == nil || d.StartTime.Before(*report.DataFrom) {
+			start := d.StartTime
+			report.DataFrom = &start
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].at.Before(events[j].at) })
+
+	type series struct {
+		definitionID  int
+		environmentID int
+	}
+	lastSucceeded := map[series]*doraEvent{}
+	openFailure := map[series]*doraEvent{}
+	restores := map[string][]time.Duration{}
+
+	for _, e := range events {
+		key := series{e.deployment.DefinitionID, e.deployment.EnvironmentID}
+		if e.deployment.Status != "succeeded" {
+			e.failed = true
+			if openFailure[key] == nil {
+				openFailure[key] = e
+			}
+			continue
+		}
+
+		// Release IDs grow over time, so redeploying a lower one rolls back the last release
+		if last := lastSucceeded[key]; last != nil && e.deployment.ReleaseID < last.deployment.ReleaseID && openFailure[key] == nil {
+			last.rolledBack = true
+			openFailure[key] = last
+		}
+		if failure := openFailure[key]; failure != nil {
+			if inPeriod(e.at, since, until) {
+				restores[e.group] = append(restores[e.group], e.at.Sub(failure.at))
+			}
+			delete(openFailure, key)
+		}
+		lastSucceeded[key] = e
+	}
+
+	leadTimes := map[string][]time.Duration{}
+	for _, change := range changes {
+		if !inPeriod(change.MergedAt, since, until) {
+			continue
+		}
+		if e := firstDeploymentOfChange(events, change, buildStarts); e != nil && inPeriod(e.at, since, until) {
+			leadTimes[e.group] = append(leadTimes[e.group], e.at.Sub(change.CreatedAt))
+		}
+	}
+
+	weeks := until.Sub(since).Hours() / (24 * 7)
+	summarize := func(group string, include func(*doraEvent) bool, leads, restored []time.Duration) DoraMetrics {
+		m := DoraMetrics{Group: group}
+		for _, e := range events {
+			if !include(e) || !inPeriod(e.at, since, until) {
+				continue
+			}
+			m.Attempts++
+			switch {
+			case e.failed:
+				m.Failed++
+			case e.rolledBack:
+				m.RolledBack++
+				m.Deployments++
+			default:
+				m.Deployments++
+			}
+		}
+		if weeks > 0 {
+			m.DeploymentsPerWeek = float64(m.Deployments) / weeks
+		}
+		if m.Attempts > 0 {
+			m.ChangeFailureRate = float64(m.Failed+m.RolledBack) / float64(m.Attempts)
+		}
+		m.LeadTimeChanges = len(leads)
+		m.LeadTimeP50Seconds = seconds(percentile(leads, 50))
+		m.LeadTimeP90Seconds = seconds(percentile(leads, 90))
+		m.Restores = len(restored)
+		m.TimeToRestoreP50Seconds = seconds(percentile(restored, 50))
+		m.TimeToRestoreMaxSeconds = seconds(percentile(restored, 100))
+		return m
+	}
+
+	groups := []string{}
+	allLeads, allRestores := []time.Duration{}, []time.Duration{}
+	for _, e := range events {
+		if inPeriod(e.at, since, until) && !slices.Contains(groups, e.group) {
+			groups = append(groups, e.group)
+		}
+	}
+	sort.Strings(groups)
+	for _, group := range groups {
+		report.Groups = append(report.Groups, summarize(group, func(e *doraEvent) bool { return e.group == group }, leadTimes[group], restores[group]))
+		allLeads = append(allLeads, leadTimes[group]...)
+		allRestores = append(allRestores, restores[group]...)
+	}
+	report.Summary = summarize("", func(*doraEvent) bool { return true }, allLeads, allRestores)
+
+	return report
+}
+
+// firstDeploymentOfChange returns the first succeeded deployment of a build of the
+// change's repository and target branch that started after the change was merged
+func firstDeploymentOfChange(events []*doraEvent, change DoraChange, buildStarts map[int]time.Time) *doraEvent {
+	branch := strings.TrimPrefix(change.TargetBranch, "refs/heads/")
+	for _, e := range events {
+		d := e.deployment
+		if e.failed || !strings.EqualFold(d.Repository, change.Repository) || strings.TrimPrefix(d.Branch, "refs/heads/") != branch {
+			continue
+		}
+
+		built, ok := buildStarts[d.BuildID]
+		if !ok {
+			built = d.StartTime
+		}
+		if !built.Before(change.MergedAt) {
+			return e
+		}
+	}
+	return nil
+}
+
+func inPeriod(t, since, until time.Time) bool {
+	return !t.Before(since) && !t.After(until)
+}
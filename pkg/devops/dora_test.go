@@ -0,0 +1,123 @@
+package devops
+
+import (
+	"testing"
+	"time"
+
+	"adoctl/pkg/cache"
+)
+
+func TestComputeDoraReport(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 0, 7)
+	at := func(day, hour int) time.Time {
+		return since.Add(time.Duration(day)*24*time.Hour + time.Duration(hour)*time.Hour)
+	}
+	deployment := func(releaseID int, status string, start time.Time, buildID int) cache.Deployment {
+		return cache.Deployment{
+			DefinitionID:  1,
+			EnvironmentID: 1,
+			Environment:   "prod",
+			ReleaseID:     releaseID,
+			Status:        status,
+			StartTime:     start,
+			Repository:    "api",
+			Branch:        "refs/heads/main",
+			BuildID:       buildID,
+		}
+	}
+	change := func(id int, branch string, created, merged time.Time) DoraChange {
+		return DoraChange{PullRequestID: id, Repository: "api", TargetBranch: branch, CreatedAt: created, MergedAt: merged}
+	}
+	day := int((24 * time.Hour).Seconds())
+	hour := int(time.Hour.Seconds())
+
+	tests := []struct {
+		name        string
+		deployments []cache.Deployment
+		changes     []DoraChange
+		buildStarts map[int]time.Time
+		want        DoraMetrics
+	}{
+		{
+			name: "no deployments",
+			want: DoraMetrics{},
+		},
+		{
+			name: "redeploying an older release is a rollback",
+			deployments: []cache.Deployment{
+				deployment(1, "succeeded", at(0, 12), 0),
+				deployment(2, "succeeded", at(1, 12), 0),
+				deployment(1, "succeeded", at(2, 12), 0),
+			},
+			want: DoraMetrics{
+				Deployments:             3,
+				DeploymentsPerWeek:      3,
+				Attempts:                3,
+				RolledBack:              1,
+				ChangeFailureRate:       1.0 / 3,
+				Restores:                1,
+				TimeToRestoreP50Seconds: day,
+				TimeToRestoreMaxSeconds: day,
+			},
+		},
+		{
+			name: "restore runs from the first failure to the next success",
+			deployments: []cache.Deployment{
+				deployment(1, "succeeded", at(0, 12), 0),
+				deployment(2, "failed", at(1, 12), 0),
+				deployment(2, "partiallySucceeded", at(1, 13), 0),
+				deployment(3, "succeeded", at(2, 12), 0),
+				deployment(4, "failed", at(3, 12), 0),
+				deployment(5, "succeeded", at(3, 14), 0),
+				deployment(6, "canceled", at(4, 12), 0),
+			},
+			want: DoraMetrics{
+				Deployments:             3,
+				DeploymentsPerWeek:      3,
+				Attempts:                6,
+				Failed:                  3,
+				ChangeFailureRate:       0.5,
+				Restores:                2,
+				TimeToRestoreP50Seconds: 2 * hour,
+				TimeToRestoreMaxSeconds: day,
+			},
+		},
+		{
+			name: "lead time runs to the first deployment of a later build",
+			deployments: []cache.Deployment{
+				deployment(1, "succeeded", at(0, 2), 100),
+				deployment(2, "succeeded", at(1, 12), 101),
+			},
+			buildStarts: map[int]time.Time{100: at(0, 1), 101: at(1, 0)},
+			changes: []DoraChange{
+				change(1, "refs/heads/main", at(0, 0), at(0, 6)),
+				change(2, "refs/heads/main", at(0, 12), at(0, 18)),
+				change(3, "main", at(0, 20), at(0, 22)),
+				// Not deployed: another branch, and a merge after the last build
+				change(4, "refs/heads/release", at(0, 0), at(0, 6)),
+				change(5, "refs/heads/main", at(1, 1), at(1, 2)),
+			},
+			want: DoraMetrics{
+				Deployments:        2,
+				DeploymentsPerWeek: 2,
+				Attempts:           2,
+				LeadTimeChanges:    3,
+				LeadTimeP50Seconds: 24 * hour,
+				LeadTimeP90Seconds: 36 * hour,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := ComputeDoraReport(tt.deployments, tt.changes, tt.buildStarts, "prod", since, until, func(repository string) string { return repository })
+			if report.Summary != tt.want {
+				t.Errorf("Summary = %+v\nwant      %+v", report.Summary, tt.want)
+			}
+			if len(tt.deployments) == 0 && (len(report.Groups) != 0 || report.DataFrom != nil) {
+				t.Errorf("empty report has groups %v and data from %v", report.Groups, report.DataFrom)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package devops
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"adoctl/pkg/config"
+	"adoctl/pkg/models"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+)
+
+const (
+	voteNoVote          = 0
+	voteWaitingOnAuthor = -5
+)
+
+// InboxEntry is a pull request in the reviewer inbox
+type InboxEntry struct {
+	PR           models.PullRequest
+	CreatedAt    time.Time
+	MyVote       int
+	ViaTeam      string
+	FilesChanged int
+	HasCommented bool
+	// WaitingOn lists reviewers that have not voted yet (only set for own PRs)
+	WaitingOn []string
+	IsMine    bool
+}
+
+// Age returns how long the PR has been open
+func (e InboxEntry) Age() time.Duration {
+	if e.CreatedAt.IsZero() {
+		return 0
+	}
+	return time.Since(e.CreatedAt)
+}
+
+// ReviewInbox is the list of PRs awaiting the current user's review, grouped by repository
+type ReviewInbox struct {
+	User   models.Identity
+	Groups map[string][]InboxEntry
+}
+
+// Repositories returns the inbox repository names in sorted order
+func (i *ReviewInbox) Repositories() []string {
+	repos := make([]string, 0, len(i.Groups))
+	for repo := range i.Groups {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	return repos
+}
+
+// Count returns the total number of PRs in the inbox
+func (i *ReviewInbox) Count() int {
+	count := 0
+	for _, entries := range i.Groups {
+		count += len(entries)
+	}
+	return count
+}
+
+// GetReviewInbox returns the active PRs where the current user, or one of their
+// teams, is a reviewer that has not voted or is waiting for the author.
+// When includeMine is set, the user's own PRs still waiting on reviewers are included.
+func (s *DevOpsService) GetReviewInbox(ctx context.Context, includeMine bool) (*ReviewInbox, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groupIDs, err := s.client.GetIdentityGroupIDs(ctx, user.ID)
+	if err != nil {
+		// Team membership is best effort; direct reviews still work without it
+		groupIDs = []string{}
+	}
+
+	teams := make(map[string]bool, len(groupIDs))
+	for _, id := range groupIDs {
+		teams[strings.ToLower(id)] = true
+	}
+
+	prs, err := s.client.GetActivePullRequests(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []InboxEntry{}
+	for i := range prs {
+		pr := &prs[i]
+		if pr.IsDraft != nil && *pr.IsDraft {
+			continue
+		}
+
+		modelPR := models.PullRequestFromAzure(pr)
+
+		var entry InboxEntry
+		var ok bool
+		if strings.EqualFold(modelPR.CreatedBy.ID, user.ID) {
+			if !includeMine {
+				continue
+			}
+			entry, ok = ownInboxEntry(pr)
+		} else {
+			entry, ok = reviewInboxEntry(pr, user.ID, teams)
+		}
+		if !ok {
+			continue
+		}
+
+		entry.PR = modelPR
+		if pr.CreationDate != nil {
+			entry.CreatedAt = pr.CreationDate.Time
+		}
+		entries = append(entries, entry)
+	}
+
+	s.enrichInboxEntries(ctx, entries, user.ID)
+
+	inbox := &ReviewInbox{
+		User:   *user,
+		Groups: map[string][]InboxEntry{},
+	}
+	for _, entry := range entries {
+		repo := entry.PR.Repository.Name
+		inbox.Groups[repo] = append(inbox.Groups[repo], entry)
+	}
+	for repo := range inbox.Groups {
+		group := inbox.Groups[repo]
+		sort.Slice(group, func(i, j int) bool {
+			return group[i].CreatedAt.Before(group[j].CreatedAt)
+		})
+	}
+
+	return inbox, nil
+}
+
+// reviewInboxEntry decides whether a PR created by someone else needs the user's review.
+// A personal vote takes precedence over team votes. A team review that has not been
+// voted on or is waiting for the author needs attention, like a personal one.
+func reviewInboxEntry(pr *git.GitPullRequest, userID string, teams map[string]bool) (InboxEntry, bool) {
+	if pr.Reviewers == nil {
+		return InboxEntry{}, false
+	}
+
+	var teamEntry *InboxEntry
+	for _, reviewer := range *pr.Reviewers {
+		if reviewer.Id == nil {
+			continue
+		}
+
+		vote := voteNoVote
+		if reviewer.Vote != nil {
+			vote = *reviewer.Vote
+		}
+
+		if strings.EqualFold(*reviewer.Id, userID) {
+			if vote == voteNoVote || vote == voteWaitingOnAuthor {
+				return InboxEntry{MyVote: vote}, true
+			}
+			return InboxEntry{}, false
+		}
+
+		if teamEntry == nil && teams[strings.ToLower(*reviewer.Id)] && (vote == voteNoVote || vote == voteWaitingOnAuthor) {
+			name := ""
+			if reviewer.DisplayName != nil {
+				name = *reviewer.DisplayName
+			}
+			teamEntry = &InboxEntry{MyVote: vote, ViaTeam: name}
+		}
+	}
+
+	if teamEntry != nil {
+		return *teamEntry, true
+	}
+
+	return InboxEntry{}, false
+}
+
+// ownInboxEntry returns an entry for the user's own PR if some reviewers have not voted yet.
+func ownInboxEntry(pr *git.GitPullRequest) (InboxEntry, bool) {
+	entry := InboxEntry{IsMine: true}
+	if pr.Reviewers == nil {
+		return entry, false
+	}
+
+	for _, reviewer := range *pr.Reviewers {
+		if reviewer.Vote != nil && *reviewer.Vote != voteNoVote {
+			continue
+		}
+		if reviewer.DisplayName != nil {
+			entry.WaitingOn = append(entry.WaitingOn, *reviewer.DisplayName)
+		}
+	}
+
+	return entry, len(entry.WaitingOn) > 0
+}
+
+// enrichInboxEntries fills the changed files count and whether the user has commented.
+func (s *DevOpsService) enrichInboxEntries(ctx context.Context, entries []InboxEntry, userID string) {
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, config.GetParallelProcesses())
+
+	for i := range entries {
+		wg.Add(1)
+		go func(entry *InboxEntry) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			repoID := entry.PR.Repository.ID
+
+			if count, err := s.client.GetPullRequestChangedFilesCount(ctx, repoID, entry.PR.ID); err == nil {
+				entry.FilesChanged = count
+			}
+
+			threads, err := s.client.GetPullRequestThreads(ctx, repoID, entry.PR.ID)
+			if err != nil {
+				return
+			}
+			entry.HasCommented = hasUserCommented(threads, userID)
+		}(&entries[i])
+	}
+
+	wg.Wait()
+}
+
+func hasUserCommented(threads []git.GitPullRequestCommentThread, userID string) bool {
+	for _, thread := range threads {
+		if thread.Comments == nil {
+			continue
+		}
+		for _, comment := range *thread.Comments {
+			if comment.CommentType != nil && *comment.CommentType == git.CommentTypeValues.System {
+				continue
+			}
+			if comment.IsDeleted != nil && *comment.IsDeleted {
+				continue
+			}
+			if comment.Author != nil && comment.Author.Id != nil && strings.EqualFold(*comment.Author.Id, userID) {
+				return true
+			}
+		}
+	}
+	return false
+}
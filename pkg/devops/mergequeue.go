@@ -0,0 +1,261 @@
+package devops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"adoctl/pkg/azure/client"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+)
+
+// Merge queue item states
+const (
+	QueueItemPending = "pending"
+	QueueItemMerged  = "merged"
+	QueueItemSkipped = "skipped"
+	QueueItemFailed  = "failed"
+)
+
+// Merge queue failure modes
+const (
+	QueueOnFailurePause = "pause"
+	QueueOnFailureSkip  = "skip"
+)
+
+// MergeQueueItem is one PR of a merge queue
+type MergeQueueItem struct {
+	PullRequestID int       `json:"pullRequestId"`
+	RepositoryID  string    `json:"repositoryId"`
+	Title         string    `json:"title"`
+	Status        string    `json:"status"`
+	Reason        string    `json:"reason,omitempty"`
+	StartedAt     time.Time `json:"startedAt,omitempty"`
+	FinishedAt    time.Time `json:"finishedAt,omitempty"`
+}
+
+// MergeQueue is the persisted state of a merge queue, so an interrupted run can resume
+type MergeQueue struct {
+	Strategy     string           `json:"strategy,omitempty"`
+	DeleteSource bool             `json:"deleteSource"`
+	OnFailure    string           `json:"onFailure"`
+	CreatedAt    time.Time        `json:"createdAt"`
+	UpdatedAt    time.Time        `json:"updatedAt"`
+	Items        []MergeQueueItem `json:"items"`
+}
+
+// Next returns the first pending item, or nil when the queue is done
+func (q *MergeQueue) Next() *MergeQueueItem {
+	for i := range q.Items {
+		if q.Items[i].Status == QueueItemPending {
+			return &q.Items[i]
+		}
+	}
+	return nil
+}
+
+// Requeue marks failed items as pending again, for a resumed run
+func (q *MergeQueue) Requeue() int {
+	count := 0
+	for i := range q.Items {
+		if q.Items[i].Status == QueueItemFailed {
+			q.Items[i].Status = QueueItemPending
+			q.Items[i].Reason = ""
+			count++
+		}
+	}
+	return count
+}
+
+// Count returns the number of items in the given state
+func (q *MergeQueue) Count(status string) int {
+	count := 0
+	for _, item := range q.Items {
+		if item.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// LoadMergeQueue reads a merge queue state file
+func LoadMergeQueue(path string) (*MergeQueue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read merge queue state: %w", err)
+	}
+
+	queue := &MergeQueue{}
+	if err := json.Unmarshal(data, queue); err != nil {
+		return nil, fmt.Errorf("failed to parse merge queue state %s: %w", path, err)
+	}
+	return queue, nil
+}
+
+// Save writes the merge queue state to path
+func (q *MergeQueue) Save(path string) error {
+	q.UpdatedAt = time.Now()
+
+	data, err := json.MarshalIndent(q, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode merge queue state: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write merge queue state: %w", err)
+	}
+	return nil
+}
+
+// Merge gate states
+const (
+	GateReady   = "ready"
+	GateWaiting = "waiting"
+	GateBlocked = "blocked"
+	GateMerged  = "merged"
+)
+
+// MergeGate is whether a PR can be merged now, must be waited on, or is blocked
+type MergeGate struct {
+	State  string
+	Reason string
+}
+
+// EvaluateMergeGate decides whether a PR is ready to merge from its status, merge
+// status and branch policies. Without policies, reviewer votes decide approval.
+func EvaluateMergeGate(pr *git.GitPullRequest, policies []PolicyEvaluation) MergeGate {
+	if pr.Status != nil {
+		switch *pr.Status {
+		case git.PullRequestStatusValues.Completed:
+			return MergeGate{State: GateMerged}
+		case git.PullRequestStatusValues.Abandoned:
+			return MergeGate{State: GateBlocked, Reason: "PR is abandoned"}
+		}
+	}
+
+	if pr.IsDraft != nil && *pr.IsDraft {
+		return MergeGate{State: GateBlocked, Reason: "PR is a draft"}
+	}
+
+	if pr.MergeStatus != nil {
+		switch *pr.MergeStatus {
+		case git.PullRequestAsyncStatusValues.Conflicts:
+			return MergeGate{State: GateBlocked, Reason: "merge conflicts"}
+		case git.PullRequestAsyncStatusValues.Failure, git.PullRequestAsyncStatusValues.RejectedByPolicy:
+			return MergeGate{State: GateBlocked, Reason: fmt.Sprintf("merge status %s", *pr.MergeStatus)}
+		case git.PullRequestAsyncStatusValues.Queued, git.PullRequestAsyncStatusValues.NotSet:
+			return MergeGate{State: GateWaiting, Reason: "merge check in progress"}
+		}
+	}
+
+	if len(policies) > 0 {
+		pending := []string{}
+		for _, p := range BlockingPolicies(policies) {
+			if p.IsFailing() {
+				return MergeGate{State: GateBlocked, Reason: fmt.Sprintf("policy failed: %s", p.Name)}
+			}
+			pending = append(pending, p.Name)
+		}
+		if len(pending) > 0 {
+			return MergeGate{State: GateWaiting, Reason: "waiting for " + strings.Join(pending, ", ")}
+		}
+		return MergeGate{State: GateReady}
+	}
+
+	switch approval := GetApprovalStatus(pr.Reviewers); approval.Status {
+	case "Rejected":
+		return MergeGate{State: GateBlocked, Reason: "rejected by a reviewer"}
+	case "Approved":
+		return MergeGate{State: GateReady}
+	default:
+		return MergeGate{State: GateWaiting, Reason: "waiting for approval"}
+	}
+}
+
+// GetMergeGate fetches a PR and its policies and evaluates its merge gate
+func (s *DevOpsService) GetMergeGate(ctx context.Context, prID int) (*git.GitPullRequest, MergeGate, error) {
+	pr, err := s.GetPullRequest(ctx, prID)
+	if err != nil {
+		return nil, MergeGate{}, err
+	}
+
+	var policies []PolicyEvaluation
+	if pr.Repository != nil && pr.Repository.Project != nil && pr.Repository.Project.Id != nil {
+		// Policies are optional; without them the gate falls back to reviewer votes
+		policies, _ = s.GetPolicyEvaluations(ctx, pr.Repository.Project.Id.String(), prID)
+	}
+
+	return pr, EvaluateMergeGate(pr, policies), nil
+}
+
+// WaitForMergeGate polls a PR every interval until it is ready, blocked or merged,
+// or until maxWait elapses. onUpdate is called with each intermediate gate.
+// Each poll gets its own timeout from newCtx.
+func (s *DevOpsService) WaitForMergeGate(newCtx func() (context.Context, context.CancelFunc), prID int, interval, maxWait time.Duration, onUpdate func(MergeGate)) (MergeGate, error) {
+	deadline := time.Now().Add(maxWait)
+
+	for {
+		ctx, cancel := newCtx()
+		_, gate, err := s.GetMergeGate(ctx, prID)
+		cancel()
+		if err != nil {
+			return MergeGate{}, err
+		}
+
+		if gate.State != GateWaiting {
+			return gate, nil
+		}
+		if onUpdate != nil {
+			onUpdate(gate)
+		}
+
+		if maxWait > 0 && time.Now().After(deadline) {
+			return MergeGate{State: GateBlocked, Reason: fmt.Sprintf("timed out after %s: %s", maxWait, gate.Reason)}, nil
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+// CompleteQueuedPullRequest merges a PR and waits until the completion has gone
+// through, since Azure DevOps completes PRs asynchronously.
+func (s *DevOpsService) CompleteQueuedPullRequest(newCtx func() (context.Context, context.CancelFunc), item *MergeQueueItem, queue *MergeQueue, interval, maxWait time.Duration) error {
+	var strategy *client.GitPullRequestMergeStrategy
+	if queue.Strategy != "" {
+		value := client.GitPullRequestMergeStrategy(queue.Strategy)
+		strategy = &value
+	}
+
+	ctx, cancel := newCtx()
+	_, err := s.MergePullRequest(ctx, item.RepositoryID, item.PullRequestID, strategy, queue.DeleteSource, "")
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to merge: %w", err)
+	}
+
+	deadline := time.Now().Add(maxWait)
+	for {
+		ctx, cancel := newCtx()
+		pr, err := s.GetPullRequest(ctx, item.PullRequestID)
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if pr.Status != nil && *pr.Status == git.PullRequestStatusValues.Completed {
+			return nil
+		}
+		if pr.MergeStatus != nil && (*pr.MergeStatus == git.PullRequestAsyncStatusValues.Conflicts || *pr.MergeStatus == git.PullRequestAsyncStatusValues.Failure) {
+			return fmt.Errorf("completion failed: merge status %s", *pr.MergeStatus)
+		}
+		if maxWait > 0 && time.Now().After(deadline) {
+			return fmt.Errorf("completion did not finish within %s", maxWait)
+		}
+
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,158 @@
+package devops
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+)
+
+func TestEvaluateMergeGate(t *testing.T) {
+	active := git.PullRequestStatusValues.Active
+	completed := git.PullRequestStatusValues.Completed
+	abandoned := git.PullRequestStatusValues.Abandoned
+	succeeded := git.PullRequestAsyncStatusValues.Succeeded
+	conflicts := git.PullRequestAsyncStatusValues.Conflicts
+	queued := git.PullRequestAsyncStatusValues.Queued
+	draft := true
+
+	votes := func(values ...int) *[]git.IdentityRefWithVote {
+		reviewers := []git.IdentityRefWithVote{}
+		for i := range values {
+			reviewers = append(reviewers, git.IdentityRefWithVote{Vote: &values[i]})
+		}
+		return &reviewers
+	}
+	mergeable := func(reviewers *[]git.IdentityRefWithVote) *git.GitPullRequest {
+		return &git.GitPullRequest{Status: &active, MergeStatus: &succeeded, Reviewers: reviewers}
+	}
+
+	tests := []struct {
+		name     string
+		pr       *git.GitPullRequest
+		policies []PolicyEvaluation
+		want     MergeGate
+	}{
+		{
+			name: "completed",
+			pr:   &git.GitPullRequest{Status: &completed},
+			want: MergeGate{State: GateMerged},
+		},
+		{
+			name: "abandoned",
+			pr:   &git.GitPullRequest{Status: &abandoned},
+			want: MergeGate{State: GateBlocked, Reason: "PR is abandoned"},
+		},
+		{
+			name: "draft",
+			pr:   &git.GitPullRequest{Status: &active, IsDraft: &draft},
+			want: MergeGate{State: GateBlocked, Reason: "PR is a draft"},
+		},
+		{
+			name: "conflicts",
+			pr:   &git.GitPullRequest{Status: &active, MergeStatus: &conflicts},
+			want: MergeGate{State: GateBlocked, Reason: "merge conflicts"},
+		},
+		{
+			name: "merge check queued",
+			pr:   &git.GitPullRequest{Status: &active, MergeStatus: &queued},
+			want: MergeGate{State: GateWaiting, Reason: "merge check in progress"},
+		},
+		{
+			name: "failing blocking policy",
+			pr:   mergeable(nil),
+			policies: []PolicyEvaluation{
+				{Name: "Reviewers", Status: PolicyStatusQueued, IsBlocking: true},
+				{Name: "CI", Status: PolicyStatusRejected, IsBlocking: true},
+			},
+			want: MergeGate{State: GateBlocked, Reason: "policy failed: CI"},
+		},
+		{
+			name: "pending blocking policies",
+			pr:   mergeable(nil),
+			policies: []PolicyEvaluation{
+				{Name: "CI", Status: PolicyStatusRunning, IsBlocking: true},
+				{Name: "Reviewers", Status: PolicyStatusQueued, IsBlocking: true},
+				{Name: "Comments", Status: PolicyStatusRejected, IsBlocking: false},
+			},
+			want: MergeGate{State: GateWaiting, Reason: "waiting for CI, Reviewers"},
+		},
+		{
+			name: "policies fulfilled outweigh reviewer votes",
+			pr:   mergeable(votes(-10)),
+			policies: []PolicyEvaluation{
+				{Name: "CI", Status: PolicyStatusApproved, IsBlocking: true},
+			},
+			want: MergeGate{State: GateReady},
+		},
+		{
+			name: "no policies and approved",
+			pr:   mergeable(votes(10, 10)),
+			want: MergeGate{State: GateReady},
+		},
+		{
+			name: "no policies and rejected",
+			pr:   mergeable(votes(10, -10)),
+			want: MergeGate{State: GateBlocked, Reason: "rejected by a reviewer"},
+		},
+		{
+			name: "no policies and a reviewer has not voted",
+			pr:   mergeable(votes(10, 0)),
+			want: MergeGate{State: GateWaiting, Reason: "waiting for approval"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EvaluateMergeGate(tt.pr, tt.policies); got != tt.want {
+				t.Errorf("EvaluateMergeGate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeQueue(t *testing.T) {
+	queue := &MergeQueue{
+		OnFailure: QueueOnFailurePause,
+		Items: []MergeQueueItem{
+			{PullRequestID: 1, Status: QueueItemMerged},
+			{PullRequestID: 2, Status: QueueItemFailed, Reason: "policy failed: CI"},
+			{PullRequestID: 3, Status: QueueItemSkipped},
+			{PullRequestID: 4, Status: QueueItemPending},
+		},
+	}
+
+	if next := queue.Next(); next == nil || next.PullRequestID != 4 {
+		t.Fatalf("Next() = %+v, want PR 4", next)
+	}
+
+	if got := queue.Requeue(); got != 1 {
+		t.Errorf("Requeue() = %d, want 1", got)
+	}
+	if next := queue.Next(); next == nil || next.PullRequestID != 2 || next.Reason != "" {
+		t.Errorf("Next() after Requeue() = %+v, want PR 2 without a reason", next)
+	}
+	if got := queue.Count(QueueItemPending); got != 2 {
+		t.Errorf("Count(pending) = %d, want 2", got)
+	}
+
+	path := filepath.Join(t.TempDir(), "queue.json")
+	if err := queue.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := LoadMergeQueue(path)
+	if err != nil {
+		t.Fatalf("LoadMergeQueue() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Items, queue.Items) || loaded.OnFailure != queue.OnFailure {
+		t.Errorf("LoadMergeQueue() = %+v, want %+v", loaded, queue)
+	}
+
+	for i := range queue.Items {
+		queue.Items[i].Status = QueueItemMerged
+	}
+	if next := queue.Next(); next != nil {
+		t.Errorf("Next() on a finished queue = %+v, want nil", next)
+	}
+}
@@ -0,0 +1,237 @@
+package devops
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"adoctl/pkg/models"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/pipelines"
+)
+
+// lastRunsLookback is how many recent builds of a pipeline are scanned to find the
+// last run of each branch
+const lastRunsLookback = 200
+
+// PipelineInfo describes a pipeline definition: where its YAML lives, what
+// triggers it and how it last ran on each branch
+type PipelineInfo struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	Folder        string `json:"folder"`
+	Repository    string `json:"repository"`
+	DefaultBranch string `json:"defaultBranch"`
+	// YAMLPath is empty for classic (designer) pipelines
+	YAMLPath string            `json:"yamlPath,omitempty"`
+	Triggers []PipelineTrigger `json:"triggers"`
+	// LastRuns holds the most recent run of each branch, newest first
+	LastRuns []BranchRun `json:"lastRuns"`
+	URL      string      `json:"url"`
+}
+
+// PipelineTrigger is a trigger of a pipeline definition
+type PipelineTrigger struct {
+	// Type is continuousIntegration, pullRequest, schedule or buildCompletion
+	Type string `json:"type"`
+	// FromYAML is set when the trigger is defined in the YAML file rather than
+	// overridden in the definition, in which case Branches and Paths are empty
+	FromYAML bool     `json:"fromYaml"`
+	Branches []string `json:"branches,omitempty"`
+	Paths    []string `json:"paths,omitempty"`
+	// Schedules are the start times of a classic schedule trigger
+	Schedules []string `json:"schedules,omitempty"`
+}
+
+// BranchRun is the most recent run of a pipeline on a branch
+type BranchRun struct {
+	Branch      string    `json:"branch"`
+	BuildID     int       `json:"buildId"`
+	BuildNumber string    `json:"buildNumber"`
+	Status      string    `json:"status"`
+	Result      string    `json:"result,omitempty"`
+	Reason      string    `json:"reason,omitempty"`
+	QueueTime   time.Time `json:"queueTime"`
+	FinishTime  time.Time `json:"finishTime,omitempty"`
+}
+
+// GetPipelineInfo fetches a pipeline definition and the last run of each branch
+// among its recent builds
+func (s *DevOpsService) GetPipelineInfo(ctx context.Context, definitionID int) (*PipelineInfo, error) {
+	definition, err := s.client.GetDefinition(ctx, definitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &PipelineInfo{
+		ID:       definitionID,
+		Name:     models.DereferenceString(definition.Name),
+		Folder:   models.DereferenceString(definition.Path),
+		YAMLPath: yamlFilename(definition.Process),
+		URL:      fmt.Sprintf("https://dev.azure.com/%s/%s/_build?definitionId=%d", s.client.GetOrganization(), s.client.GetProject(), definitionID),
+	}
+	if repo := definition.Repository; repo != nil {
+		info.Repository = models.DereferenceString(repo.Name)
+		info.DefaultBranch = models.NormalizeBranchName(models.DereferenceString(repo.DefaultBranch))
+	}
+	if definition.Triggers != nil {
+		for _, raw := range *definition.Triggers {
+			if trigger, ok := parseTrigger(raw); ok {
+				info.Triggers = append(info.Triggers, trigger)
+			}
+		}
+	}
+
+	builds, err := s.client.GetBuilds(ctx, map[string]string{
+		"definitions": strconv.Itoa(definitionID),
+		"queryOrder":  string(build.BuildQueryOrderValues.QueueTimeDescending),
+		"$top":        strconv.Itoa(lastRunsLookback),
+	})
+	if err != nil {
+		return nil, err
+	}
+	info.LastRuns = lastRunPerBranch(builds)
+
+	return info, nil
+}
+
+// PreviewOptions are the inputs of a pipeline preview
+type PreviewOptions struct {
+	Branch string
+	// Parameters are YAML template parameters
+	Parameters map[string]string
+	// YAMLOverride replaces the pipeline's YAML file, to preview local changes
+	YAMLOverride string
+}
+
+// PreviewPipeline dry-runs a YAML pipeline on a branch and returns the final YAML
+// with every template expanded. Template and syntax errors come back as errors.
+func (s *DevOpsService) PreviewPipeline(ctx context.Context, pipelineID int, opts PreviewOptions) (string, error) {
+	refName := opts.Branch
+	if !strings.HasPrefix(refName, "refs/") {
+		refName = "refs/heads/" + refName
+	}
+	repositories := map[string]pipelines.RepositoryResourceParameters{"self": {RefName: &refName}}
+
+	previewRun := true
+	params := &pipelines.RunPipelineParameters{
+		PreviewRun: &previewRun,
+		Resources:  &pipelines.RunResourcesParameters{Repositories: &repositories},
+	}
+	if len(opts.Parameters) > 0 {
+		params.TemplateParameters = &opts.Parameters
+	}
+	if opts.YAMLOverride != "" {
+		params.YamlOverride = &opts.YAMLOverride
+	}
+
+	return s.client.PreviewPipeline(ctx, pipelineID, params)
+}
+
+// lastRunPerBranch keeps the first build of each branch from builds ordered
+// newest first
+func lastRunPerBranch(builds []build.Build) []BranchRun {
+	seen := map[string]bool{}
+	runs := []BranchRun{}
+	for _, b := range builds {
+		if b.Id == nil || b.SourceBranch == nil || seen[*b.SourceBranch] {
+			continue
+		}
+		seen[*b.SourceBranch] = true
+
+		run := BranchRun{
+			Branch:      strings.TrimPrefix(*b.SourceBranch, "refs/heads/"),
+			BuildID:     *b.Id,
+			BuildNumber: models.DereferenceString(b.BuildNumber),
+		}
+		if b.Status != nil {
+			run.Status = string(*b.Status)
+		}
+		if b.Result != nil {
+			run.Result = string(*b.Result)
+		}
+		if b.Reason != nil {
+			run.Reason = string(*b.Reason)
+		}
+		if b.QueueTime != nil {
+			run.QueueTime = b.QueueTime.Time
+		}
+		if b.FinishTime != nil {
+			run.FinishTime = b.FinishTime.Time
+		}
+		runs = append(runs, run)
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].QueueTime.After(runs[j].QueueTime)
+	})
+	return runs
+}
+
+// yamlFilename extracts the YAML file of a definition process, which the API
+// returns untyped; classic pipelines have none
+func yamlFilename(process interface{}) string {
+	fields, ok := process.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	filename, _ := fields["yamlFilename"].(string)
+	return filename
+}
+
+// parseTrigger reads an untyped definition trigger
+func parseTrigger(raw interface{}) (PipelineTrigger, bool) {
+	fields, ok := raw.(map[string]interface{})
+	if !ok {
+		return PipelineTrigger{}, false
+	}
+	triggerType, _ := fields["triggerType"].(string)
+	if triggerType == "" {
+		return PipelineTrigger{}, false
+	}
+
+	// settingsSourceType 2 means the trigger settings come from the YAML file
+	source, _ := fields["settingsSourceType"].(float64)
+	trigger := PipelineTrigger{
+		Type:     triggerType,
+		FromYAML: source == 2,
+		Branches: stringSlice(fields["branchFilters"]),
+		Paths:    stringSlice(fields["pathFilters"]),
+	}
+
+	if schedules, ok := fields["schedules"].([]interface{}); ok {
+		for _, raw := range schedules {
+			schedule, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			hours, _ := schedule["startHours"].(float64)
+			minutes, _ := schedule["startMinutes"].(float64)
+			entry := fmt.Sprintf("%02d:%02d", int(hours), int(minutes))
+			if zone, _ := schedule["timeZoneId"].(string); zone != "" {
+				entry += " " + zone
+			}
+			trigger.Schedules = append(trigger.Schedules, entry)
+		}
+	}
+
+	return trigger, true
+}
+
+func stringSlice(raw interface{}) []string {
+	values, ok := raw.([]interface{})
+	if !ok {
+		return nil
+	}
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,154 @@
+package devops
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"adoctl/pkg/cache"
+	"adoctl/pkg/logger"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/pipelines"
+)
+
+// ListDefinitions returns the pipeline definitions of the project, from the cache
+// unless refresh is set or the cached list has expired
+func (s *DevOpsService) ListDefinitions(ctx context.Context, refresh bool) ([]cache.Definition, error) {
+	if s.cache != nil && !refresh {
+		cached, err := s.cache.GetDefinitions()
+		if err == nil && cached != nil {
+			return cached, nil
+		}
+	}
+
+	refs, err := s.client.GetDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := make([]cache.Definition, 0, len(refs))
+	for _, ref := range refs {
+		if ref.Id == nil || ref.Name == nil {
+			continue
+		}
+		definition := cache.Definition{ID: *ref.Id, Name: *ref.Name}
+		if ref.Path != nil {
+			definition.Path = *ref.Path
+		}
+		definitions = append(definitions, definition)
+	}
+
+	if s.cache != nil {
+		if err := s.cache.SetDefinitions(definitions); err != nil {
+			logger.Warn().Err(err).Msg("Failed to cache pipeline definitions")
+		}
+	}
+
+	return definitions, nil
+}
+
+// ResolveDefinition finds a pipeline by ID, name or folder path (e.g. \team\deploy).
+// Names match case-insensitively; the cached list is refreshed once on a miss.
+func (s *DevOpsService) ResolveDefinition(ctx context.Context, nameOrID string) (*cache.Definition, error) {
+	if id, err := strconv.Atoi(nameOrID); err == nil {
+		// The name is only for display, so a failed lookup still resolves the ID
+		definitions, _ := s.ListDefinitions(ctx, false)
+		for _, d := range definitions {
+			if d.ID == id {
+				return &d, nil
+			}
+		}
+		return &cache.Definition{ID: id, Name: nameOrID}, nil
+	}
+
+	for _, refresh := range []bool{false, true} {
+		definitions, err := s.ListDefinitions(ctx, refresh)
+		if err != nil {
+			return nil, err
+		}
+
+		matches := matchDefinitions(definitions, nameOrID)
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return &matches[0], nil
+		default:
+			paths := make([]string, 0, len(matches))
+			for _, m := range matches {
+				paths = append(paths, fmt.Sprintf("%s (id %d)", DefinitionFullPath(m), m.ID))
+			}
+			return nil, fmt.Errorf("pipeline '%s' is ambiguous, use the ID or full path: %s", nameOrID, strings.Join(paths, ", "))
+		}
+	}
+
+	return nil, fmt.Errorf("pipeline '%s' not found", nameOrID)
+}
+
+func matchDefinitions(definitions []cache.Definition, nameOrPath string) []cache.Definition {
+	matches := []cache.Definition{}
+	for _, d := range definitions {
+		if strings.EqualFold(d.Name, nameOrPath) || strings.EqualFold(DefinitionFullPath(d), nameOrPath) {
+			matches = append(matches, d)
+		}
+	}
+	return matches
+}
+
+// DefinitionFullPath joins the folder and name of a definition, e.g. \team\deploy
+func DefinitionFullPath(d cache.Definition) string {
+	folder := strings.ReplaceAll(d.Path, `\`, "/")
+	return strings.ReplaceAll(path.Join("/", folder, d.Name), "/", `\`)
+}
+
+// RunOptions are the inputs of a pipeline run
+type RunOptions struct {
+	Branch string
+	// Commit pins the run to a commit of the branch; empty runs the branch head
+	Commit string
+	// Parameters are YAML template parameters
+	Parameters   map[string]string
+	Variables    map[string]string
+	StagesToSkip []string
+}
+
+// RunPipeline queues a run of the pipeline on the given branch
+func (s *DevOpsService) RunPipeline(ctx context.Context, pipelineID int, opts RunOptions) (*pipelines.Run, error) {
+	refName := opts.Branch
+	if !strings.HasPrefix(refName, "refs/") {
+		refName = "refs/heads/" + refName
+	}
+
+	self := pipelines.RepositoryResourceParameters{RefName: &refName}
+	if opts.Commit != "" {
+		self.Version = &opts.Commit
+	}
+	repositories := map[string]pipelines.RepositoryResourceParameters{"self": self}
+
+	params := &pipelines.RunPipelineParameters{
+		Resources: &pipelines.RunResourcesParameters{Repositories: &repositories},
+	}
+	if len(opts.Parameters) > 0 {
+		params.TemplateParameters = &opts.Parameters
+	}
+	if len(opts.Variables) > 0 {
+		variables := make(map[string]pipelines.Variable, len(opts.Variables))
+		for name, value := range opts.Variables {
+			value := value
+			variables[name] = pipelines.Variable{Value: &value}
+		}
+		params.Variables = &variables
+	}
+	if len(opts.StagesToSkip) > 0 {
+		params.StagesToSkip = &opts.StagesToSkip
+	}
+
+	return s.client.RunPipeline(ctx, pipelineID, params)
+}
+
+// BuildWebURL returns the web page of a build run
+func (s *DevOpsService) BuildWebURL(buildID int) string {
+	return fmt.Sprintf("https://dev.azure.com/%s/%s/_build/results?buildId=%d", s.client.GetOrganization(), s.client.GetProject(), buildID)
+}
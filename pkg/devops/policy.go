@@ -0,0 +1,182 @@
+package devops
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/policy"
+)
+
+const (
+	PolicyStatusQueued        = "queued"
+	PolicyStatusRunning       = "running"
+	PolicyStatusApproved      = "approved"
+	PolicyStatusRejected      = "rejected"
+	PolicyStatusNotApplicable = "notApplicable"
+	PolicyStatusBroken        = "broken"
+)
+
+// PolicyEvaluation is a single branch policy evaluated against a pull request
+type PolicyEvaluation struct {
+	ConfigurationID int
+	Name            string
+	Type            string
+	Status          string
+	IsBlocking      bool
+	BuildID         int
+}
+
+// IsPassing returns true if the policy has been fulfilled
+func (p PolicyEvaluation) IsPassing() bool {
+	return p.Status == PolicyStatusApproved
+}
+
+// IsFailing returns true if the policy rejected the PR or could not be evaluated
+func (p PolicyEvaluation) IsFailing() bool {
+	return p.Status == PolicyStatusRejected || p.Status == PolicyStatusBroken
+}
+
+// IsPending returns true if the policy is still being evaluated
+func (p PolicyEvaluation) IsPending() bool {
+	return p.Status == PolicyStatusQueued || p.Status == PolicyStatusRunning
+}
+
+// IsBlockingMerge returns true if the policy is required and not yet fulfilled
+func (p PolicyEvaluation) IsBlockingMerge() bool {
+	return p.IsBlocking && !p.IsPassing()
+}
+
+// PolicyEvaluationFromRecord converts an Azure DevOps evaluation record to a PolicyEvaluation
+func PolicyEvaluationFromRecord(record policy.PolicyEvaluationRecord) PolicyEvaluation {
+	eval := PolicyEvaluation{}
+
+	if record.Status != nil {
+		eval.Status = string(*record.Status)
+	}
+
+	if cfg := record.Configuration; cfg != nil {
+		if cfg.Id != nil {
+			eval.ConfigurationID = *cfg.Id
+		}
+		if cfg.IsBlocking != nil {
+			eval.IsBlocking = *cfg.IsBlocking
+		}
+		if cfg.Type != nil && cfg.Type.DisplayName != nil {
+			eval.Type = *cfg.Type.DisplayName
+		}
+		if settings, ok := cfg.Settings.(map[string]any); ok {
+			if name, ok := settings["displayName"].(string); ok && name != "" {
+				eval.Name = name
+			}
+		}
+	}
+
+	if eval.Name == "" {
+		eval.Name = eval.Type
+	}
+
+	// Build validation policies keep the build they queued in the evaluation context
+	if evalContext, ok := record.Context.(map[string]any); ok {
+		if buildID, ok := evalContext["buildId"].(float64); ok {
+			eval.BuildID = int(buildID)
+		}
+	}
+
+	return eval
+}
+
+// GetPolicyEvaluations returns the applicable, enabled branch policies for a PR,
+// with blocking policies listed first.
+func (s *DevOpsService) GetPolicyEvaluations(ctx context.Context, projectID string, pullRequestID int) ([]PolicyEvaluation, error) {
+	if projectID == "" {
+		return nil, fmt.Errorf("project ID is required to get policy evaluations")
+	}
+
+	records, err := s.client.GetPullRequestPolicyEvaluations(ctx, projectID, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	return policyEvaluationsFromRecords(records), nil
+}
+
+func policyEvaluationsFromRecords(records []policy.PolicyEvaluationRecord) []PolicyEvaluation {
+	result := make([]PolicyEvaluation, 0, len(records))
+	for _, record := range records {
+		if cfg := record.Configuration; cfg != nil {
+			if cfg.IsEnabled != nil && !*cfg.IsEnabled {
+				continue
+			}
+			if cfg.IsDeleted != nil && *cfg.IsDeleted {
+				continue
+			}
+		}
+
+		eval := PolicyEvaluationFromRecord(record)
+		if eval.Status == PolicyStatusNotApplicable {
+			continue
+		}
+		result = append(result, eval)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].IsBlocking && !result[j].IsBlocking
+	})
+
+	return result
+}
+
+// BlockingPolicies returns the policies that currently prevent the PR from completing
+func BlockingPolicies(policies []PolicyEvaluation) []PolicyEvaluation {
+	blocking := []PolicyEvaluation{}
+	for _, p := range policies {
+		if p.IsBlockingMerge() {
+			blocking = append(blocking, p)
+		}
+	}
+	return blocking
+}
+
+// PolicyWarnings returns report warnings for the blocking policies that are not fulfilled
+func PolicyWarnings(policies []PolicyEvaluation) []string {
+	warnings := []string{}
+	for _, p := range BlockingPolicies(policies) {
+		warnings = append(warnings, fmt.Sprintf("Policy %s: %s", FormatPolicyStatus(p.Status), p.Name))
+	}
+	return warnings
+}
+
+func FormatPolicyStatus(status string) string {
+	switch status {
+	case PolicyStatusApproved:
+		return "Approved"
+	case PolicyStatusRejected:
+		return "Rejected"
+	case PolicyStatusRunning:
+		return "Running"
+	case PolicyStatusQueued:
+		return "Queued"
+	case PolicyStatusBroken:
+		return "Broken"
+	case PolicyStatusNotApplicable:
+		return "Not Applicable"
+	default:
+		return status
+	}
+}
+
+func PolicyStatusIcon(status string) string {
+	switch status {
+	case PolicyStatusApproved:
+		return "✔"
+	case PolicyStatusRejected, PolicyStatusBroken:
+		return "✖"
+	case PolicyStatusRunning:
+		return "⟳"
+	case PolicyStatusQueued:
+		return "○"
+	default:
+		return "◌"
+	}
+}
@@ -0,0 +1,108 @@
+package devops
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/policy"
+)
+
+func policyRecord(id int, name, status string, blocking bool) policy.PolicyEvaluationRecord {
+	evalStatus := policy.PolicyEvaluationStatus(status)
+	typeName := "Build"
+	return policy.PolicyEvaluationRecord{
+		Status: &evalStatus,
+		Configuration: &policy.PolicyConfiguration{
+			Id:         &id,
+			IsBlocking: &blocking,
+			Type:       &policy.PolicyTypeRef{DisplayName: &typeName},
+			Settings:   map[string]any{"displayName": name},
+		},
+	}
+}
+
+func TestPolicyEvaluationFromRecord(t *testing.T) {
+	typeName := "Minimum number of reviewers"
+	build := policyRecord(7, "CI", "running", true)
+	build.Context = map[string]any{"buildId": float64(1234)}
+
+	tests := []struct {
+		name   string
+		record policy.PolicyEvaluationRecord
+		want   PolicyEvaluation
+	}{
+		{
+			name:   "build policy with its build",
+			record: build,
+			want:   PolicyEvaluation{ConfigurationID: 7, Name: "CI", Type: "Build", Status: PolicyStatusRunning, IsBlocking: true, BuildID: 1234},
+		},
+		{
+			name: "name falls back to the policy type",
+			record: policy.PolicyEvaluationRecord{
+				Configuration: &policy.PolicyConfiguration{Type: &policy.PolicyTypeRef{DisplayName: &typeName}},
+			},
+			want: PolicyEvaluation{Name: typeName, Type: typeName},
+		},
+		{
+			name:   "empty record",
+			record: policy.PolicyEvaluationRecord{},
+			want:   PolicyEvaluation{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PolicyEvaluationFromRecord(tt.record); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PolicyEvaluationFromRecord() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyEvaluationsFromRecords(t *testing.T) {
+	disabled := policyRecord(3, "Disabled", "rejected", true)
+	isEnabled := false
+	disabled.Configuration.IsEnabled = &isEnabled
+
+	deleted := policyRecord(4, "Deleted", "rejected", true)
+	isDeleted := true
+	deleted.Configuration.IsDeleted = &isDeleted
+
+	records := []policy.PolicyEvaluationRecord{
+		policyRecord(1, "Comments", "approved", false),
+		policyRecord(2, "CI", "rejected", true),
+		disabled,
+		deleted,
+		policyRecord(5, "Other branch", "notApplicable", true),
+		policyRecord(6, "Reviewers", "queued", true),
+	}
+
+	var names []string
+	for _, eval := range policyEvaluationsFromRecords(records) {
+		names = append(names, eval.Name)
+	}
+
+	// Blocking policies come first, each group keeping the API order
+	want := []string{"CI", "Reviewers", "Comments"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("policyEvaluationsFromRecords() names = %v, want %v", names, want)
+	}
+}
+
+func TestPolicyWarnings(t *testing.T) {
+	policies := []PolicyEvaluation{
+		{Name: "CI", Status: PolicyStatusRejected, IsBlocking: true},
+		{Name: "Reviewers", Status: PolicyStatusApproved, IsBlocking: true},
+		{Name: "Work items", Status: PolicyStatusQueued, IsBlocking: true},
+		{Name: "Comments", Status: PolicyStatusRejected, IsBlocking: false},
+	}
+
+	want := []string{"Policy Rejected: CI", "Policy Queued: Work items"}
+	if got := PolicyWarnings(policies); !reflect.DeepEqual(got, want) {
+		t.Errorf("PolicyWarnings() = %v, want %v", got, want)
+	}
+
+	if got := PolicyWarnings(nil); len(got) != 0 {
+		t.Errorf("PolicyWarnings(nil) = %v, want none", got)
+	}
+}
@@ -35,7 +35,7 @@ func (c *PRRequirementsChecker) CheckPRRequirements(pr models.PullRequest) []str
 	warnings := []string{}
 
 	if pr.MergeStatus == models.MergeStatusConflicts {
-		warnings = append(warnings, "Has merge conflicts")
+		warnings = append(warnings, conflictsWarning(0))
 	}
 
 	if pr.MergeStatus == models.MergeStatusNotSet || pr.MergeStatus == models.MergeStatusNotStarted {
@@ -146,6 +146,81 @@ func (c *PRRequirementsChecker) GetWorkItemsCountsBatch(ctx context.Context, ite
 	return results
 }
 
+// GetPolicyWarningsBatch returns the blocking policy warnings for each PR, keyed by "repoID:prID".
+// PRs whose policies cannot be retrieved get no warnings.
+func (c *PRRequirementsChecker) GetPolicyWarningsBatch(ctx context.Context, prs []models.PullRequest, maxWorkers int) map[string][]string {
+	results := make(map[string][]string)
+	var mutex sync.Mutex
+
+	semaphore := make(chan struct{}, maxWorkers)
+	var wg sync.WaitGroup
+
+	for _, pr := range prs {
+		projectID := pr.Repository.Project.ID
+		if projectID == "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(repoID, projectID string, prID int) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			records, err := c.client.GetPullRequestPolicyEvaluations(ctx, projectID, prID)
+			if err != nil {
+				return
+			}
+
+			warnings := PolicyWarnings(policyEvaluationsFromRecords(records))
+
+			mutex.Lock()
+			results[fmt.Sprintf("%s:%d", repoID, prID)] = warnings
+			mutex.Unlock()
+		}(pr.Repository.ID, projectID, pr.ID)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// GetConflictCountsBatch returns the number of conflicting files of each PR with merge
+// conflicts, keyed by "repoID:prID". PRs whose conflicts cannot be retrieved are omitted.
+func (c *PRRequirementsChecker) GetConflictCountsBatch(ctx context.Context, prs []models.PullRequest, maxWorkers int) map[string]int {
+	results := make(map[string]int)
+	var mutex sync.Mutex
+
+	semaphore := make(chan struct{}, maxWorkers)
+	var wg sync.WaitGroup
+
+	for _, pr := range prs {
+		if !pr.HasMergeConflicts() {
+			continue
+		}
+
+		wg.Add(1)
+		go func(repoID string, prID int) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			conflicts, err := c.client.GetPullRequestConflicts(ctx, repoID, prID)
+			if err != nil {
+				return
+			}
+
+			mutex.Lock()
+			results[fmt.Sprintf("%s:%d", repoID, prID)] = len(conflicts)
+			mutex.Unlock()
+		}(pr.Repository.ID, pr.ID)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
 func (s *DevOpsService) CreatePullRequest(ctx context.Context, repositoryID, sourceBranch, targetBranch, title, description string, reviewers []string, workItemIDs []string, skipChangeCheck bool) (*models.PullRequest, error) {
 	if !skipChangeCheck {
 		hasChanges, err := s.client.BranchesHaveChanges(ctx, repositoryID, sourceBranch, targetBranch)
@@ -315,6 +390,20 @@ func (s *DevOpsService) GetPullRequest(ctx context.Context, pullrequestid int) (
 	return s.client.GetPullRequest(ctx, pullrequestid)
 }
 
+// RetargetPullRequest changes the target branch of a pull request
+func (s *DevOpsService) RetargetPullRequest(ctx context.Context, repositoryID string, pullRequestID int, targetBranch string) (*models.PullRequest, error) {
+	targetRefName := fmt.Sprintf("refs/heads/%s", targetBranch)
+	result, err := s.client.UpdatePullRequest(ctx, repositoryID, pullRequestID, &git.GitPullRequest{
+		TargetRefName: &targetRefName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retarget PR #%d to %s: %w", pullRequestID, targetBranch, err)
+	}
+
+	pr := models.PullRequestFromAzure(result)
+	return &pr, nil
+}
+
 func (s *DevOpsService) MergePullRequest(ctx context.Context, repositoryID string, pullRequestID int, mergeStrategy *client.GitPullRequestMergeStrategy, deleteSourceBranch bool, commitMessage string) (*git.GitPullRequest, error) {
 	completionOptions := &client.GitPullRequestCompletionOptions{
 		MergeStrategy:      mergeStrategy,
@@ -326,6 +415,27 @@ func (s *DevOpsService) MergePullRequest(ctx context.Context, repositoryID strin
 	return s.client.MergePullRequest(ctx, repositoryID, pullRequestID, completionOptions)
 }
 
+func (s *DevOpsService) SetPullRequestAutoComplete(ctx context.Context, repositoryID string, pullRequestID int, mergeStrategy *client.GitPullRequestMergeStrategy, deleteSourceBranch, transitionWorkItems bool, commitMessage string) (*git.GitPullRequest, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	completionOptions := &client.GitPullRequestCompletionOptions{
+		MergeStrategy:       mergeStrategy,
+		DeleteSourceBranch:  &deleteSourceBranch,
+		TransitionWorkItems: &transitionWorkItems,
+	}
+	if commitMessage != "" {
+		completionOptions.MergeCommitMessage = &commitMessage
+	}
+	return s.client.SetPullRequestAutoComplete(ctx, repositoryID, pullRequestID, user.ID, completionOptions)
+}
+
+func (s *DevOpsService) CancelPullRequestAutoComplete(ctx context.Context, repositoryID string, pullRequestID int) (*git.GitPullRequest, error) {
+	return s.client.CancelPullRequestAutoComplete(ctx, repositoryID, pullRequestID)
+}
+
 func (s *DevOpsService) AbandonPullRequest(ctx context.Context, repositoryID string, pullRequestID int, abortMessage string) (*git.GitPullRequest, error) {
 	return s.client.AbandonPullRequest(ctx, repositoryID, pullRequestID, abortMessage)
 }
@@ -399,14 +509,22 @@ func (s *DevOpsService) ListPullRequests(ctx context.Context, repositoryID, stat
 }
 
 func (s *DevOpsService) ListPullRequestsWithFilter(ctx context.Context, repositoryID string, prFilter *filter.PRFilter) ([]models.PullRequest, error) {
-	prs, err := s.ListPullRequests(ctx, repositoryID, prFilter.Status, prFilter.TargetBranch, prFilter.SourceBranch, prFilter.CreatorID)
+	// A status in the query takes precedence over the status flag
+	effective := *prFilter
+	if prFilter.Query != nil {
+		if queryStatus := prFilter.Query.Status(); queryStatus != "" {
+			effective.Status = queryStatus
+		}
+	}
+
+	prs, err := s.ListPullRequests(ctx, repositoryID, effective.Status, effective.TargetBranch, effective.SourceBranch, effective.CreatorID)
 	if err != nil {
 		return nil, err
 	}
 
 	filteredPRs := []models.PullRequest{}
 	for _, pr := range prs {
-		matches, err := prFilter.MatchesPR(pr)
+		matches, err := effective.MatchesPR(pr)
 		if err != nil {
 			return nil, err
 		}
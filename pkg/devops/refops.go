@@ -0,0 +1,182 @@
+package devops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"adoctl/pkg/models"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+)
+
+// RefOperationPollInterval is how often cherry-pick and revert operations are polled
+const RefOperationPollInterval = 2 * time.Second
+
+// RefOperationResult is the outcome of a cherry-pick or revert of a PR onto one branch
+type RefOperationResult struct {
+	OntoBranch      string
+	GeneratedBranch string
+	PullRequest     *models.PullRequest
+	Error           error
+}
+
+// CherryPickBranchName returns the branch generated when cherry-picking a PR onto a branch
+func CherryPickBranchName(pullRequestID int, ontoBranch string) string {
+	return fmt.Sprintf("cherry-pick/%d-onto-%s", pullRequestID, strings.ReplaceAll(models.NormalizeBranchName(ontoBranch), "/", "-"))
+}
+
+// RevertBranchName returns the branch generated when reverting a PR
+func RevertBranchName(pullRequestID int) string {
+	return fmt.Sprintf("revert/%d", pullRequestID)
+}
+
+// CherryPickPullRequest cherry-picks a PR onto each branch, waits for the generated
+// branches and opens a PR for each one with the original work items linked. Each
+// branch gets its own timeout from newCtx, as the server-side operations of several
+// branches can together outlast a single request timeout.
+func (s *DevOpsService) CherryPickPullRequest(newCtx func() (context.Context, context.CancelFunc), source *git.GitPullRequest, ontoBranches []string) []RefOperationResult {
+	repoID := source.Repository.Id.String()
+	prID := *source.PullRequestId
+
+	ctx, cancel := newCtx()
+	workItemIDs := s.getPullRequestWorkItemIDs(ctx, repoID, prID)
+	cancel()
+
+	results := make([]RefOperationResult, 0, len(ontoBranches))
+	for _, onto := range ontoBranches {
+		ctx, cancel := newCtx()
+		results = append(results, s.cherryPickOnto(ctx, source, models.NormalizeBranchName(onto), workItemIDs))
+		cancel()
+	}
+
+	return results
+}
+
+// cherryPickOnto cherry-picks a PR onto one branch and opens a PR for the result
+func (s *DevOpsService) cherryPickOnto(ctx context.Context, source *git.GitPullRequest, onto string, workItemIDs []string) RefOperationResult {
+	repoID := source.Repository.Id.String()
+	prID := *source.PullRequestId
+	result := RefOperationResult{
+		OntoBranch:      onto,
+		GeneratedBranch: CherryPickBranchName(prID, onto),
+	}
+
+	op, err := s.client.CreateCherryPick(ctx, repoID, prID, "refs/heads/"+onto, "refs/heads/"+result.GeneratedBranch)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	err = waitForRefOperation(ctx, func() (*git.GitAsyncOperationStatus, *git.GitAsyncRefOperationDetail, error) {
+		current, err := s.client.GetCherryPick(ctx, repoID, *op.CherryPickId)
+		if err != nil {
+			return nil, nil, err
+		}
+		return current.Status, current.DetailedStatus, nil
+	})
+	if err != nil {
+		result.Error = fmt.Errorf("cherry-pick onto %s failed: %w", onto, err)
+		return result
+	}
+
+	title := fmt.Sprintf("Cherry-pick PR #%d: %s", prID, models.DereferenceString(source.Title))
+	description := fmt.Sprintf("Cherry-pick of PR #%d onto %s.\n\n%s", prID, onto, models.DereferenceString(source.Description))
+
+	result.PullRequest, result.Error = s.CreatePullRequest(ctx, repoID, result.GeneratedBranch, onto, title, description, nil, workItemIDs, true)
+	return result
+}
+
+// RevertPullRequest reverts a completed PR onto its target branch, waits for the
+// generated branch and opens a PR with the original work items linked.
+func (s *DevOpsService) RevertPullRequest(ctx context.Context, source *git.GitPullRequest) RefOperationResult {
+	repoID := source.Repository.Id.String()
+	prID := *source.PullRequestId
+	onto := models.NormalizeBranchName(models.DereferenceString(source.TargetRefName))
+
+	result := RefOperationResult{
+		OntoBranch:      onto,
+		GeneratedBranch: RevertBranchName(prID),
+	}
+
+	op, err := s.client.CreateRevert(ctx, repoID, prID, "refs/heads/"+onto, "refs/heads/"+result.GeneratedBranch)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	err = waitForRefOperation(ctx, func() (*git.GitAsyncOperationStatus, *git.GitAsyncRefOperationDetail, error) {
+		current, err := s.client.GetRevert(ctx, repoID, *op.RevertId)
+		if err != nil {
+			return nil, nil, err
+		}
+		return current.Status, current.DetailedStatus, nil
+	})
+	if err != nil {
+		result.Error = fmt.Errorf("revert failed: %w", err)
+		return result
+	}
+
+	workItemIDs := s.getPullRequestWorkItemIDs(ctx, repoID, prID)
+	title := fmt.Sprintf("Revert PR #%d: %s", prID, models.DereferenceString(source.Title))
+	description := fmt.Sprintf("Reverts PR #%d.", prID)
+
+	result.PullRequest, result.Error = s.CreatePullRequest(ctx, repoID, result.GeneratedBranch, onto, title, description, nil, workItemIDs, true)
+	return result
+}
+
+// waitForRefOperation polls an asynchronous ref operation until it completes, fails or ctx expires.
+func waitForRefOperation(ctx context.Context, fetch func() (*git.GitAsyncOperationStatus, *git.GitAsyncRefOperationDetail, error)) error {
+	ticker := time.NewTicker(RefOperationPollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, detail, err := fetch()
+		if err != nil {
+			return err
+		}
+
+		if status != nil {
+			switch *status {
+			case git.GitAsyncOperationStatusValues.Completed:
+				return nil
+			case git.GitAsyncOperationStatusValues.Failed, git.GitAsyncOperationStatusValues.Abandoned:
+				return refOperationError(*status, detail)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func refOperationError(status git.GitAsyncOperationStatus, detail *git.GitAsyncRefOperationDetail) error {
+	if detail != nil {
+		if detail.Conflict != nil && *detail.Conflict {
+			return fmt.Errorf("operation %s due to merge conflicts", status)
+		}
+		if detail.FailureMessage != nil && *detail.FailureMessage != "" {
+			return fmt.Errorf("operation %s: %s", status, *detail.FailureMessage)
+		}
+	}
+	return fmt.Errorf("operation %s", status)
+}
+
+func (s *DevOpsService) getPullRequestWorkItemIDs(ctx context.Context, repoID string, prID int) []string {
+	refs, err := s.client.GetPullRequestWorkItems(ctx, repoID, prID)
+	if err != nil {
+		return nil
+	}
+
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if ref.Id != nil {
+			ids = append(ids, *ref.Id)
+		}
+	}
+	return ids
+}
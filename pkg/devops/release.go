@@ -0,0 +1,228 @@
+package devops
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"adoctl/pkg/logger"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/release"
+)
+
+// ResolveReleaseDefinition finds a release definition by ID or exact name
+func (s *DevOpsService) ResolveReleaseDefinition(ctx context.Context, nameOrID string) (*release.ReleaseDefinition, error) {
+	if id, err := strconv.Atoi(nameOrID); err == nil {
+		return s.client.GetReleaseDefinition(ctx, id)
+	}
+
+	definitions, err := s.client.FindReleaseDefinitions(ctx, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(definitions) {
+	case 0:
+		return nil, fmt.Errorf("release definition '%s' not found", nameOrID)
+	case 1:
+		return s.client.GetReleaseDefinition(ctx, *definitions[0].Id)
+	default:
+		paths := make([]string, 0, len(definitions))
+		for _, d := range definitions {
+			path := ""
+			if d.Path != nil {
+				path = *d.Path
+			}
+			paths = append(paths, fmt.Sprintf("%s (id %d)", strings.TrimSuffix(path, `\`)+`\`+*d.Name, *d.Id))
+		}
+		return nil, fmt.Errorf("release definition '%s' is ambiguous, use the ID: %s", nameOrID, strings.Join(paths, ", "))
+	}
+}
+
+// ResolveArtifactVersions maps artifact alias=version pairs onto the artifacts of
+// a release definition. The key "build" selects the primary artifact when no
+// artifact has that alias.
+func ResolveArtifactVersions(definition *release.ReleaseDefinition, versions map[string]string) ([]release.ArtifactMetadata, error) {
+	artifacts := []release.Artifact{}
+	if definition.Artifacts != nil {
+		artifacts = *definition.Artifacts
+	}
+
+	metadata := make([]release.ArtifactMetadata, 0, len(versions))
+	for key, version := range versions {
+		artifact := findArtifact(artifacts, key)
+		if artifact == nil {
+			aliases := make([]string, 0, len(artifacts))
+			for _, a := range artifacts {
+				if a.Alias != nil {
+					aliases = append(aliases, *a.Alias)
+				}
+			}
+			return nil, fmt.Errorf("release definition has no artifact '%s' (artifacts: %s)", key, strings.Join(aliases, ", "))
+		}
+
+		metadata = append(metadata, release.ArtifactMetadata{
+			Alias:             artifact.Alias,
+			InstanceReference: &release.BuildVersion{Id: &version, Name: &version},
+		})
+	}
+	return metadata, nil
+}
+
+func findArtifact(artifacts []release.Artifact, alias string) *release.Artifact {
+	for i := range artifacts {
+		if artifacts[i].Alias != nil && strings.EqualFold(*artifacts[i].Alias, alias) {
+			return &artifacts[i]
+		}
+	}
+
+	if !strings.EqualFold(alias, "build") {
+		return nil
+	}
+	for i := range artifacts {
+		if artifacts[i].IsPrimary != nil && *artifacts[i].IsPrimary {
+			return &artifacts[i]
+		}
+	}
+	if len(artifacts) == 1 {
+		return &artifacts[0]
+	}
+	return nil
+}
+
+// CreateRelease creates a release of a definition with the given artifact versions;
+// artifacts without a version use the definition's default
+func (s *DevOpsService) CreateRelease(ctx context.Context, definitionID int, artifacts []release.ArtifactMetadata, description string) (*release.Release, error) {
+	metadata := &release.ReleaseStartMetadata{
+		DefinitionId: &definitionID,
+	}
+	if len(artifacts) > 0 {
+		metadata.Artifacts = &artifacts
+	}
+	if description != "" {
+		metadata.Description = &description
+	}
+
+	return s.client.CreateRelease(ctx, metadata)
+}
+
+// GetRelease returns a release with its environments
+func (s *DevOpsService) GetRelease(ctx context.Context, releaseID int) (*release.Release, error) {
+	return s.client.GetRelease(ctx, releaseID)
+}
+
+// FindReleaseEnvironment returns the environment of a release with the given name
+func FindReleaseEnvironment(r *release.Release, name string) (*release.ReleaseEnvironment, error) {
+	names := []string{}
+	if r.Environments != nil {
+		for i, env := range *r.Environments {
+			if env.Name == nil {
+				continue
+			}
+			if strings.EqualFold(*env.Name, name) {
+				return &(*r.Environments)[i], nil
+			}
+			names = append(names, *env.Name)
+		}
+	}
+	return nil, fmt.Errorf("release has no environment '%s' (environments: %s)", name, strings.Join(names, ", "))
+}
+
+// DeployEnvironment starts or redeploys an environment of a release
+func (s *DevOpsService) DeployEnvironment(ctx context.Context, releaseID, environmentID int, comment string) (*release.ReleaseEnvironment, error) {
+	return s.client.DeployReleaseEnvironment(ctx, releaseID, environmentID, comment)
+}
+
+// IsEnvironmentCompleted reports whether a release environment status is final
+func IsEnvironmentCompleted(status release.EnvironmentStatus) bool {
+	switch status {
+	case release.EnvironmentStatusValues.Succeeded,
+		release.EnvironmentStatusValues.PartiallySucceeded,
+		release.EnvironmentStatusValues.Rejected,
+		release.EnvironmentStatusValues.Canceled:
+		return true
+	}
+	return false
+}
+
+// WaitForEnvironment polls a release environment every interval until its
+// deployment completes or waitCtx is done, in which case the error is
+// waitCtx.Err(). onUpdate is called with each intermediate state. Each request
+// gets its own timeout from newCtx.
+func (s *DevOpsService) WaitForEnvironment(waitCtx context.Context, newCtx func() (context.Context, context.CancelFunc), releaseID, environmentID int, interval time.Duration, onUpdate func(*release.ReleaseEnvironment)) (*release.ReleaseEnvironment, error) {
+	for {
+		ctx, cancel := newCtx()
+		r, err := s.client.GetRelease(ctx, releaseID)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+
+		var env *release.ReleaseEnvironment
+		if r.Environments != nil {
+			for i := range *r.Environments {
+				if e := &(*r.Environments)[i]; e.Id != nil && *e.Id == environmentID {
+					env = e
+					break
+				}
+			}
+		}
+		if env == nil {
+			return nil, fmt.Errorf("environment %d not found in release %d", environmentID, releaseID)
+		}
+
+		if env.Status != nil && IsEnvironmentCompleted(*env.Status) {
+			return env, nil
+		}
+		if onUpdate != nil {
+			onUpdate(env)
+		}
+
+		select {
+		case <-waitCtx.Done():
+			return nil, waitCtx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// SyncLatestDeployment fetches the latest deployment of a release environment and
+// saves it to the deployments cache
+func (s *DevOpsService) SyncLatestDeployment(ctx context.Context, r *release.Release, env *release.ReleaseEnvironment) (*release.Deployment, error) {
+	if r.ReleaseDefinition == nil || r.ReleaseDefinition.Id == nil || env.DefinitionEnvironmentId == nil {
+		return nil, fmt.Errorf("release %d has no definition environment", *r.Id)
+	}
+
+	deployments, err := s.client.GetDeployments(ctx, map[string]string{
+		"definitionId":            strconv.Itoa(*r.ReleaseDefinition.Id),
+		"definitionEnvironmentId": strconv.Itoa(*env.DefinitionEnvironmentId),
+		"queryOrder":              string(release.ReleaseQueryOrderValues.Descending),
+		"$top":                    "25",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch deployments: %w", err)
+	}
+
+	for i := range deployments {
+		d := &deployments[i]
+		if d.Release == nil || d.Release.Id == nil || *d.Release.Id != *r.Id {
+			continue
+		}
+
+		if s.cache != nil {
+			if err := s.SyncDeployment(*d); err != nil {
+				logger.Warn().Err(err).Int("release_id", *r.Id).Msg("Failed to cache deployment")
+			}
+		}
+		return d, nil
+	}
+
+	return nil, nil
+}
+
+// ReleaseWebURL returns the web URL of a release
+func (s *DevOpsService) ReleaseWebURL(releaseID int) string {
+	return fmt.Sprintf("https://dev.azure.com/%s/%s/_releaseProgress?releaseId=%d", s.client.GetOrganization(), s.client.GetProject(), releaseID)
+}
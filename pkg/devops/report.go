@@ -16,6 +16,15 @@ type prEntry struct {
 	repoName string
 	webURL   string
 	warnings []string
+	// autoCompleteBy is the display name of whoever armed auto-complete
+	autoCompleteBy string
+}
+
+func (e prEntry) autoCompleteSuffix() string {
+	if e.autoCompleteBy == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (auto-complete: %s)", e.autoCompleteBy)
 }
 
 type prGroupData struct {
@@ -48,6 +57,8 @@ func (s *DevOpsService) buildPRGroups(
 	}
 
 	workItemsCounts := map[string]int{}
+	policyWarnings := map[string][]string{}
+	conflictCounts := map[string]int{}
 	if showWarnings {
 		items := []struct {
 			RepoID string
@@ -62,6 +73,8 @@ func (s *DevOpsService) buildPRGroups(
 			}{repoID, prID})
 		}
 		workItemsCounts = checker.GetWorkItemsCountsBatch(ctx, items, config.GetParallelProcesses())
+		policyWarnings = checker.GetPolicyWarningsBatch(ctx, filteredPRs, config.GetParallelProcesses())
+		conflictCounts = checker.GetConflictCountsBatch(ctx, filteredPRs, config.GetParallelProcesses())
 	}
 
 	for _, pr := range filteredPRs {
@@ -87,11 +100,19 @@ func (s *DevOpsService) buildPRGroups(
 			cacheKey := fmt.Sprintf("%s:%d", repoID, prID)
 			workItemsCount := workItemsCounts[cacheKey]
 			mergeWarnings := checker.CheckPRRequirements(pr)
+			if count, ok := conflictCounts[cacheKey]; ok {
+				for i, w := range mergeWarnings {
+					if w == conflictsWarning(0) {
+						mergeWarnings[i] = conflictsWarning(count)
+					}
+				}
+			}
 
 			if workItemsCount == 0 {
 				warnings = append(warnings, "No work items linked")
 			}
 			warnings = append(warnings, mergeWarnings...)
+			warnings = append(warnings, policyWarnings[cacheKey]...)
 		}
 
 		entry := prEntry{
@@ -101,6 +122,9 @@ func (s *DevOpsService) buildPRGroups(
 			webURL:   webURL,
 			warnings: warnings,
 		}
+		if pr.HasAutoComplete() {
+			entry.autoCompleteBy = pr.AutoCompleteSetBy.DisplayName
+		}
 
 		grouped[groupKey] = append(grouped[groupKey], entry)
 	}
@@ -145,7 +169,7 @@ func (s *DevOpsService) GenerateMessageReport(ctx context.Context, prs []models.
 			if len(pr.warnings) > 0 {
 				warningStr = "\n    " + strings.Join(pr.warnings, " ")
 			}
-			prLine := fmt.Sprintf("%s: %s, [PR #%d](%s)%s", pr.repoName, pr.title, pr.id, pr.webURL, warningStr)
+			prLine := fmt.Sprintf("%s: %s, [PR #%d](%s)%s%s", pr.repoName, pr.title, pr.id, pr.webURL, pr.autoCompleteSuffix(), warningStr)
 			lines = append(lines, prLine)
 		}
 
@@ -173,7 +197,7 @@ func (s *DevOpsService) GeneratePlainTextReport(ctx context.Context, prs []model
 			if len(pr.warnings) > 0 {
 				warningStr = "\n    " + strings.Join(pr.warnings, " ")
 			}
-			prLine := fmt.Sprintf("%s: %s, PR #%d%s", pr.repoName, pr.title, pr.id, warningStr)
+			prLine := fmt.Sprintf("%s: %s, PR #%d%s%s", pr.repoName, pr.title, pr.id, pr.autoCompleteSuffix(), warningStr)
 			lines = append(lines, prLine)
 		}
 
@@ -201,7 +225,7 @@ func (s *DevOpsService) GenerateHTMLMessageReport(ctx context.Context, prs []mod
 			if len(pr.warnings) > 0 {
 				warningStr = "<br>&nbsp;&nbsp;&nbsp;&nbsp;" + strings.Join(pr.warnings, " ")
 			}
-			prLine := fmt.Sprintf(`%s: %s, <a href="%s">PR #%d</a>%s`, pr.repoName, pr.title, pr.webURL, pr.id, warningStr)
+			prLine := fmt.Sprintf(`%s: %s, <a href="%s">PR #%d</a>%s%s`, pr.repoName, pr.title, pr.webURL, pr.id, pr.autoCompleteSuffix(), warningStr)
 			lines = append(lines, prLine)
 		}
 
@@ -0,0 +1,265 @@
+package devops
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"adoctl/pkg/azure/client"
+	"adoctl/pkg/config"
+	"adoctl/pkg/models"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+)
+
+// DefaultNudgeTemplate is the comment posted on stale PRs by default.
+// See FormatNudgeMessage for the supported placeholders.
+const DefaultNudgeTemplate = "Hi {author}, this pull request has had no activity for {idle} days (last update {lastUpdate}). " +
+	"Is it still needed? Please update it, or abandon it if it is no longer relevant."
+
+// StalePullRequest is an active PR with the dates of its latest activity
+type StalePullRequest struct {
+	PR models.PullRequest
+	// LastUpdate is the latest of creation, last commit and any thread activity, including votes and system events
+	LastUpdate  time.Time
+	LastCommit  time.Time
+	LastComment time.Time
+}
+
+// IdleFor returns how long the PR has had no activity
+func (p StalePullRequest) IdleFor(now time.Time) time.Duration {
+	if p.LastUpdate.IsZero() {
+		return 0
+	}
+	return now.Sub(p.LastUpdate)
+}
+
+// StaleActionResult is the outcome of a cleanup action on one stale PR
+type StaleActionResult struct {
+	PR    models.PullRequest
+	Error error
+}
+
+// GetStalePullRequests returns active PRs created more than olderThan ago and, when
+// noActivity is set, without any activity in that period. Results are sorted by
+// last update, oldest first. A zero duration disables the corresponding check.
+func (s *DevOpsService) GetStalePullRequests(ctx context.Context, repositoryID string, olderThan, noActivity time.Duration) ([]StalePullRequest, error) {
+	prs, err := s.ListPullRequests(ctx, repositoryID, string(models.PRStatusActive), "", "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	candidates := staleCandidates(prs, now, olderThan)
+	s.enrichStalePullRequests(ctx, candidates)
+
+	return idlePullRequests(candidates, now, noActivity), nil
+}
+
+// staleCandidates keeps the PRs created more than olderThan before now, with their
+// creation as last update until their activity is known
+func staleCandidates(prs []models.PullRequest, now time.Time, olderThan time.Duration) []StalePullRequest {
+	candidates := []StalePullRequest{}
+	for _, pr := range prs {
+		if olderThan > 0 && (pr.CreationDate.IsZero() || now.Sub(pr.CreationDate) < olderThan) {
+			continue
+		}
+		candidates = append(candidates, StalePullRequest{PR: pr, LastUpdate: pr.CreationDate})
+	}
+	return candidates
+}
+
+// idlePullRequests keeps the candidates without activity for noActivity, sorted by
+// last update, oldest first
+func idlePullRequests(candidates []StalePullRequest, now time.Time, noActivity time.Duration) []StalePullRequest {
+	stale := []StalePullRequest{}
+	for _, candidate := range candidates {
+		if noActivity > 0 && candidate.IdleFor(now) < noActivity {
+			continue
+		}
+		stale = append(stale, candidate)
+	}
+
+	sort.Slice(stale, func(i, j int) bool {
+		return stale[i].LastUpdate.Before(stale[j].LastUpdate)
+	})
+
+	return stale
+}
+
+// enrichStalePullRequests fills the last commit, last comment and last update dates.
+func (s *DevOpsService) enrichStalePullRequests(ctx context.Context, prs []StalePullRequest) {
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, config.GetParallelProcesses())
+
+	for i := range prs {
+		wg.Add(1)
+		go func(stale *StalePullRequest) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			repoID := stale.PR.Repository.ID
+
+			if commits, err := s.client.GetPullRequestCommits(ctx, repoID, stale.PR.ID); err == nil {
+				for _, commit := range commits {
+					if commit.Committer != nil && commit.Committer.Date != nil && commit.Committer.Date.Time.After(stale.LastCommit) {
+						stale.LastCommit = commit.Committer.Date.Time
+					}
+				}
+			}
+
+			if threads, err := s.client.GetPullRequestThreads(ctx, repoID, stale.PR.ID); err == nil {
+				lastActivity, lastComment := threadActivity(threads)
+				stale.LastComment = lastComment
+				if lastActivity.After(stale.LastUpdate) {
+					stale.LastUpdate = lastActivity
+				}
+			}
+
+			if stale.LastCommit.After(stale.LastUpdate) {
+				stale.LastUpdate = stale.LastCommit
+			}
+		}(&prs[i])
+	}
+
+	wg.Wait()
+}
+
+// threadActivity returns the latest activity across all threads, and the latest
+// comment written by a person (system comments and deleted comments are ignored).
+func threadActivity(threads []git.GitPullRequestCommentThread) (time.Time, time.Time) {
+	var lastActivity, lastComment time.Time
+
+	for _, thread := range threads {
+		if thread.LastUpdatedDate != nil && thread.LastUpdatedDate.Time.After(lastActivity) {
+			lastActivity = thread.LastUpdatedDate.Time
+		}
+		if thread.Comments == nil {
+			continue
+		}
+
+		for _, comment := range *thread.Comments {
+			date := time.Time{}
+			if comment.LastUpdatedDate != nil {
+				date = comment.LastUpdatedDate.Time
+			} else if comment.PublishedDate != nil {
+				date = comment.PublishedDate.Time
+			}
+
+			if date.After(lastActivity) {
+				lastActivity = date
+			}
+
+			if comment.CommentType != nil && *comment.CommentType == git.CommentTypeValues.System {
+				continue
+			}
+			if comment.IsDeleted != nil && *comment.IsDeleted {
+				continue
+			}
+			if date.After(lastComment) {
+				lastComment = date
+			}
+		}
+	}
+
+	return lastActivity, lastComment
+}
+
+// FormatNudgeMessage fills a nudge template for a stale PR. Supported placeholders:
+// {author} (a mention of the PR author), {title}, {id}, {idle} (days without
+// activity) and {lastUpdate} (date of the last activity).
+func FormatNudgeMessage(template string, stale StalePullRequest, now time.Time) string {
+	author := stale.PR.CreatedBy.DisplayName
+	if stale.PR.CreatedBy.ID != "" {
+		author = fmt.Sprintf("@<%s>", stale.PR.CreatedBy.ID)
+	}
+
+	lastUpdate := "unknown"
+	if !stale.LastUpdate.IsZero() {
+		lastUpdate = stale.LastUpdate.Format("2006-01-02")
+	}
+
+	replacer := strings.NewReplacer(
+		"{author}", author,
+		"{title}", stale.PR.Title,
+		"{id}", fmt.Sprintf("%d", stale.PR.ID),
+		"{idle}", fmt.Sprintf("%d", int(stale.IdleFor(now).Hours()/24)),
+		"{lastUpdate}", lastUpdate,
+	)
+	return replacer.Replace(template)
+}
+
+// NudgeStalePullRequests posts a comment built from template on each stale PR
+func (s *DevOpsService) NudgeStalePullRequests(ctx context.Context, prs []StalePullRequest, template string) []StaleActionResult {
+	now := time.Now()
+	return s.forEachStalePullRequest(prs, func(stale StalePullRequest) error {
+		_, err := s.client.CreatePullRequestComment(ctx, stale.PR.Repository.ID, stale.PR.ID, FormatNudgeMessage(template, stale, now))
+		return err
+	})
+}
+
+// ConvertStalePullRequestsToDraft converts each stale PR to a draft
+func (s *DevOpsService) ConvertStalePullRequestsToDraft(ctx context.Context, prs []StalePullRequest) []StaleActionResult {
+	isDraft := true
+	return s.forEachStalePullRequest(prs, func(stale StalePullRequest) error {
+		_, err := s.client.UpdatePullRequest(ctx, stale.PR.Repository.ID, stale.PR.ID, &git.GitPullRequest{IsDraft: &isDraft})
+		if err != nil {
+			return fmt.Errorf("failed to convert to draft: %w", err)
+		}
+		return nil
+	})
+}
+
+// AbandonStalePullRequests posts comment on each stale PR, when set, and abandons them
+func (s *DevOpsService) AbandonStalePullRequests(ctx context.Context, prs []StalePullRequest, comment string) []StaleActionResult {
+	if comment != "" {
+		// The comment only explains the abandon; failing to post it should not block it
+		s.forEachStalePullRequest(prs, func(stale StalePullRequest) error {
+			_, err := s.client.CreatePullRequestComment(ctx, stale.PR.Repository.ID, stale.PR.ID, comment)
+			return err
+		})
+	}
+
+	requests := make([]client.AbandonRequest, 0, len(prs))
+	for _, stale := range prs {
+		requests = append(requests, client.AbandonRequest{
+			RepositoryID:  stale.PR.Repository.ID,
+			PullRequestID: stale.PR.ID,
+		})
+	}
+
+	bulkResults, _ := s.client.BulkAbandonPullRequests(ctx, requests, config.GetParallelProcesses())
+
+	results := make([]StaleActionResult, len(prs))
+	for i, result := range bulkResults {
+		results[i] = StaleActionResult{PR: prs[result.Index].PR, Error: result.Error}
+	}
+	return results
+}
+
+func (s *DevOpsService) forEachStalePullRequest(prs []StalePullRequest, action func(StalePullRequest) error) []StaleActionResult {
+	results := make([]StaleActionResult, len(prs))
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, config.GetParallelProcesses())
+
+	for i := range prs {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			results[index] = StaleActionResult{
+				PR:    prs[index].PR,
+				Error: action(prs[index]),
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	return results
+}
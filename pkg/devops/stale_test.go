@@ -0,0 +1,173 @@
+package devops
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"adoctl/pkg/models"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+)
+
+func TestStaleCandidates(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	prs := []models.PullRequest{
+		{ID: 1, CreationDate: now.Add(-40 * 24 * time.Hour)},
+		{ID: 2, CreationDate: now.Add(-5 * 24 * time.Hour)},
+		{ID: 3},
+	}
+
+	tests := []struct {
+		name      string
+		olderThan time.Duration
+		wantIDs   []int
+	}{
+		{"no age limit", 0, []int{1, 2, 3}},
+		{"older than 30 days", 30 * 24 * time.Hour, []int{1}},
+		{"older than a year", 365 * 24 * time.Hour, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []int
+			for _, candidate := range staleCandidates(prs, now, tt.olderThan) {
+				if !candidate.LastUpdate.Equal(candidate.PR.CreationDate) {
+					t.Errorf("PR %d LastUpdate = %v, want its creation date", candidate.PR.ID, candidate.LastUpdate)
+				}
+				ids = append(ids, candidate.PR.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("staleCandidates() IDs = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestIdlePullRequests(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	candidates := []StalePullRequest{
+		{PR: models.PullRequest{ID: 1}, LastUpdate: now.Add(-20 * 24 * time.Hour)},
+		{PR: models.PullRequest{ID: 2}, LastUpdate: now.Add(-2 * 24 * time.Hour)},
+		{PR: models.PullRequest{ID: 3}, LastUpdate: now.Add(-60 * 24 * time.Hour)},
+	}
+
+	tests := []struct {
+		name       string
+		noActivity time.Duration
+		wantIDs    []int
+	}{
+		{"no activity limit sorts oldest first", 0, []int{3, 1, 2}},
+		{"idle for 14 days", 14 * 24 * time.Hour, []int{3, 1}},
+		{"idle for 90 days", 90 * 24 * time.Hour, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []int
+			for _, stale := range idlePullRequests(candidates, now, tt.noActivity) {
+				ids = append(ids, stale.PR.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("idlePullRequests() IDs = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestThreadActivity(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 6, d, 0, 0, 0, 0, time.UTC) }
+	at := func(d int) *azuredevops.Time { return &azuredevops.Time{Time: day(d)} }
+	system := git.CommentTypeValues.System
+	deleted := true
+
+	tests := []struct {
+		name         string
+		threads      []git.GitPullRequestCommentThread
+		wantActivity time.Time
+		wantComment  time.Time
+	}{
+		{
+			name:         "no threads",
+			threads:      nil,
+			wantActivity: time.Time{},
+			wantComment:  time.Time{},
+		},
+		{
+			name: "system and deleted comments only count as activity",
+			threads: []git.GitPullRequestCommentThread{
+				{LastUpdatedDate: at(3), Comments: &[]git.Comment{
+					{PublishedDate: at(2)},
+					{PublishedDate: at(5), CommentType: &system},
+					{PublishedDate: at(6), IsDeleted: &deleted},
+				}},
+			},
+			wantActivity: day(6),
+			wantComment:  day(2),
+		},
+		{
+			name: "edited comment uses its last update",
+			threads: []git.GitPullRequestCommentThread{
+				{LastUpdatedDate: at(1), Comments: &[]git.Comment{
+					{PublishedDate: at(1), LastUpdatedDate: at(4)},
+				}},
+				{LastUpdatedDate: at(8)},
+			},
+			wantActivity: day(8),
+			wantComment:  day(4),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activity, comment := threadActivity(tt.threads)
+			if !activity.Equal(tt.wantActivity) {
+				t.Errorf("threadActivity() activity = %v, want %v", activity, tt.wantActivity)
+			}
+			if !comment.Equal(tt.wantComment) {
+				t.Errorf("threadActivity() comment = %v, want %v", comment, tt.wantComment)
+			}
+		})
+	}
+}
+
+func TestFormatNudgeMessage(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	stale := StalePullRequest{
+		PR: models.PullRequest{
+			ID:        42,
+			Title:     "Add retries",
+			CreatedBy: models.Identity{ID: "abc-123", DisplayName: "Sam"},
+		},
+		LastUpdate: now.Add(-10*24*time.Hour - time.Hour),
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		stale    StalePullRequest
+		want     string
+	}{
+		{
+			name:     "every placeholder",
+			template: "{author} #{id} {title}: {idle} days since {lastUpdate}",
+			stale:    stale,
+			want:     "@<abc-123> #42 Add retries: 10 days since 2024-06-20",
+		},
+		{
+			name:     "author without ID and unknown activity",
+			template: "{author} {idle} {lastUpdate}",
+			stale:    StalePullRequest{PR: models.PullRequest{CreatedBy: models.Identity{DisplayName: "Sam"}}},
+			want:     "Sam 0 unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatNudgeMessage(tt.template, tt.stale, now); got != tt.want {
+				t.Errorf("FormatNudgeMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
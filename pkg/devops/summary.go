@@ -28,6 +28,7 @@ type PRSummary struct {
 	ApprovedCount  int
 	RejectedCount  int
 	PendingCount   int
+	Policies       []PolicyEvaluation
 }
 
 type ApprovalResult struct {
@@ -75,7 +76,7 @@ func GetApprovalStatus(reviewers *[]git.IdentityRefWithVote) ApprovalResult {
 	return ApprovalResult{Status: "Pending", Total: total, Approved: approved, Rejected: rejected, Pending: pending}
 }
 
-func getPRStatus(pr *git.GitPullRequest, builds []cache.Build, deployments []DeploymentStatusInfo) string {
+func getPRStatus(pr *git.GitPullRequest, builds []cache.Build, deployments []DeploymentStatusInfo, policies []PolicyEvaluation) string {
 	prStatus := ""
 	if pr.Status != nil {
 		prStatus = string(*pr.Status)
@@ -89,29 +90,37 @@ func getPRStatus(pr *git.GitPullRequest, builds []cache.Build, deployments []Dep
 		return "Merged"
 	}
 
-	if pr.WorkItemRefs == nil || len(*pr.WorkItemRefs) == 0 {
-		return "Needs work items"
-	}
-
 	if pr.MergeStatus != nil && string(*pr.MergeStatus) == "conflicts" {
 		return "Needs conflict resolution"
 	}
 
-	approvalResult := GetApprovalStatus(pr.Reviewers)
-	if approvalResult.Status == "Rejected" || approvalResult.Status == "Pending" || approvalResult.Status == "Partial" || approvalResult.Status == "No reviewers" {
-		return "Needs approval"
-	}
-
-	if len(builds) > 0 {
-		build := builds[0]
-		if build.Status == "inProgress" {
-			return "CI in progress"
+	// Branch policies are the source of truth when available; the heuristics
+	// below only apply to branches without policies configured.
+	if len(policies) > 0 {
+		if status := getPolicyGateStatus(policies); status != "" {
+			return status
 		}
-		if build.Status == "completed" && build.Result == "failed" {
-			return "CI failed"
+	} else {
+		if pr.WorkItemRefs == nil || len(*pr.WorkItemRefs) == 0 {
+			return "Needs work items"
 		}
-		if build.Status == "notStarted" {
-			return "CI pending"
+
+		approvalResult := GetApprovalStatus(pr.Reviewers)
+		if approvalResult.Status == "Rejected" || approvalResult.Status == "Pending" || approvalResult.Status == "Partial" || approvalResult.Status == "No reviewers" {
+			return "Needs approval"
+		}
+
+		if len(builds) > 0 {
+			build := builds[0]
+			if build.Status == "inProgress" {
+				return "CI in progress"
+			}
+			if build.Status == "completed" && build.Result == "failed" {
+				return "CI failed"
+			}
+			if build.Status == "notStarted" {
+				return "CI pending"
+			}
 		}
 	}
 
@@ -125,14 +134,31 @@ func getPRStatus(pr *git.GitPullRequest, builds []cache.Build, deployments []Dep
 		}
 	}
 
-	if len(builds) == 0 {
+	if len(policies) == 0 && len(builds) == 0 {
 		return "CI pending"
 	}
 
 	return "Completed"
 }
 
-func BuildPRSummary(pr *git.GitPullRequest, builds []cache.Build, deployments []DeploymentStatusInfo) *PRSummary {
+// getPolicyGateStatus returns the PR status implied by its blocking policies,
+// or an empty string when every blocking policy is fulfilled.
+func getPolicyGateStatus(policies []PolicyEvaluation) string {
+	blocking := BlockingPolicies(policies)
+	if len(blocking) == 0 {
+		return ""
+	}
+
+	for _, p := range blocking {
+		if p.IsFailing() {
+			return "Policy failed"
+		}
+	}
+
+	return "Policy pending"
+}
+
+func BuildPRSummary(pr *git.GitPullRequest, builds []cache.Build, deployments []DeploymentStatusInfo, policies []PolicyEvaluation) *PRSummary {
 	author := ""
 	if pr.CreatedBy != nil && pr.CreatedBy.DisplayName != nil {
 		author = *pr.CreatedBy.DisplayName
@@ -153,7 +179,7 @@ func BuildPRSummary(pr *git.GitPullRequest, builds []cache.Build, deployments []
 		creationDate = pr.CreationDate.String()
 	}
 
-	prStatus := getPRStatus(pr, builds, deployments)
+	prStatus := getPRStatus(pr, builds, deployments, policies)
 	approvalResult := GetApprovalStatus(pr.Reviewers)
 
 	ciStatus := "◌"
@@ -235,6 +261,7 @@ func BuildPRSummary(pr *git.GitPullRequest, builds []cache.Build, deployments []
 		ApprovedCount:  approvalResult.Approved,
 		RejectedCount:  approvalResult.Rejected,
 		PendingCount:   approvalResult.Pending,
+		Policies:       policies,
 	}
 }
 
@@ -0,0 +1,44 @@
+package devops
+
+import "testing"
+
+func TestGetPolicyGateStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies []PolicyEvaluation
+		want     string
+	}{
+		{
+			name: "all blocking policies approved",
+			policies: []PolicyEvaluation{
+				{Status: PolicyStatusApproved, IsBlocking: true},
+				{Status: PolicyStatusRejected, IsBlocking: false},
+			},
+			want: "",
+		},
+		{
+			name: "blocking policy running",
+			policies: []PolicyEvaluation{
+				{Status: PolicyStatusApproved, IsBlocking: true},
+				{Status: PolicyStatusRunning, IsBlocking: true},
+			},
+			want: "Policy pending",
+		},
+		{
+			name: "a failure outweighs pending policies",
+			policies: []PolicyEvaluation{
+				{Status: PolicyStatusQueued, IsBlocking: true},
+				{Status: PolicyStatusBroken, IsBlocking: true},
+			},
+			want: "Policy failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getPolicyGateStatus(tt.policies); got != tt.want {
+				t.Errorf("getPolicyGateStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package devops
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/build"
+)
+
+// Timeline record types
+const (
+	RecordStage = "Stage"
+	RecordPhase = "Phase"
+	RecordJob   = "Job"
+	RecordTask  = "Task"
+)
+
+// TimelineIssue is an error or warning reported by a timeline record
+type TimelineIssue struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// TimelineNode is a stage, job or task of a build timeline
+type TimelineNode struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Name       string          `json:"name"`
+	State      string          `json:"state"`
+	Result     string          `json:"result,omitempty"`
+	StartTime  time.Time       `json:"startTime,omitempty"`
+	FinishTime time.Time       `json:"finishTime,omitempty"`
+	LogID      int             `json:"logId,omitempty"`
+	Order      int             `json:"order"`
+	Issues     []TimelineIssue `json:"issues,omitempty"`
+	Children   []*TimelineNode `json:"children,omitempty"`
+
+	parentID string
+}
+
+// Duration returns how long the record ran, up to now while it is in progress
+func (n *TimelineNode) Duration() time.Duration {
+	if n.StartTime.IsZero() {
+		return 0
+	}
+	if n.FinishTime.IsZero() {
+		return time.Since(n.StartTime)
+	}
+	return n.FinishTime.Sub(n.StartTime)
+}
+
+// IsFailed reports whether the record failed
+func (n *TimelineNode) IsFailed() bool {
+	return n.Result == string(build.TaskResultValues.Failed)
+}
+
+// BuildTimelineTree arranges timeline records into a stage → job → task tree
+// ordered as in the pipeline. Phases are folded into their stage, and records
+// such as checkpoints are left out.
+func BuildTimelineTree(records []build.TimelineRecord) []*TimelineNode {
+	nodes := make(map[string]*TimelineNode, len(records))
+	for _, record := range records {
+		if record.Id == nil || record.Type == nil {
+			continue
+		}
+		switch *record.Type {
+		case RecordStage, RecordPhase, RecordJob, RecordTask:
+		default:
+			continue
+		}
+		nodes[record.Id.String()] = timelineNodeFromRecord(record)
+	}
+
+	roots := []*TimelineNode{}
+	for _, node := range nodes {
+		if node.Type == RecordPhase {
+			continue
+		}
+
+		parent := nodes[node.parentID]
+		for parent != nil && parent.Type == RecordPhase {
+			parent = nodes[parent.parentID]
+		}
+
+		if parent == nil {
+			roots = append(roots, node)
+		} else {
+			parent.Children = append(parent.Children, node)
+		}
+	}
+
+	sortTimelineNodes(roots)
+	return roots
+}
+
+func timelineNodeFromRecord(record build.TimelineRecord) *TimelineNode {
+	node := &TimelineNode{
+		ID:   record.Id.String(),
+		Type: *record.Type,
+	}
+	if record.ParentId != nil {
+		node.parentID = record.ParentId.String()
+	}
+	if record.Name != nil {
+		node.Name = *record.Name
+	}
+	if record.State != nil {
+		node.State = string(*record.State)
+	}
+	if record.Result != nil {
+		node.Result = string(*record.Result)
+	}
+	if record.StartTime != nil {
+		node.StartTime = record.StartTime.Time
+	}
+	if record.FinishTime != nil {
+		node.FinishTime = record.FinishTime.Time
+	}
+	if record.Log != nil && record.Log.Id != nil {
+		node.LogID = *record.Log.Id
+	}
+	if record.Order != nil {
+		node.Order = *record.Order
+	}
+	if record.Issues != nil {
+		for _, issue := range *record.Issues {
+			item := TimelineIssue{}
+			if issue.Type != nil {
+				item.Type = string(*issue.Type)
+			}
+			if issue.Message != nil {
+				item.Message = *issue.Message
+			}
+			node.Issues = append(node.Issues, item)
+		}
+	}
+	return node
+}
+
+func sortTimelineNodes(nodes []*TimelineNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].Order != nodes[j].Order {
+			return nodes[i].Order < nodes[j].Order
+		}
+		return nodes[i].StartTime.Before(nodes[j].StartTime)
+	})
+	for _, node := range nodes {
+		sortTimelineNodes(node.Children)
+	}
+}
+
+// TimelineTasks returns the tasks of the tree in pipeline order, each with the
+// path of stage and job names leading to it
+func TimelineTasks(roots []*TimelineNode) []TimelineTask {
+	tasks := []TimelineTask{}
+	var walk func(nodes []*TimelineNode, path []string)
+	walk = func(nodes []*TimelineNode, path []string) {
+		for _, node := range nodes {
+			if node.Type == RecordTask {
+				tasks = append(tasks, TimelineTask{Node: node, Path: append([]string{}, path...)})
+				continue
+			}
+			walk(node.Children, append(path, node.Name))
+		}
+	}
+	walk(roots, nil)
+	return tasks
+}
+
+// TimelineTask is a task with the names of the stage and job it belongs to
+type TimelineTask struct {
+	Node *TimelineNode
+	Path []string
+}
+
+// Label returns the task name prefixed with its stage and job, e.g. Build/Linux/Test
+func (t TimelineTask) Label() string {
+	return strings.Join(append(append([]string{}, t.Path...), t.Node.Name), "/")
+}
+
+// GetBuildTimeline fetches the timeline of a build as a stage → job → task tree
+func (s *DevOpsService) GetBuildTimeline(ctx context.Context, buildID int) ([]*TimelineNode, error) {
+	timeline, err := s.client.GetBuildTimeline(ctx, buildID)
+	if err != nil {
+		return nil, err
+	}
+	if timeline == nil || timeline.Records == nil {
+		return []*TimelineNode{}, nil
+	}
+	return BuildTimelineTree(*timeline.Records), nil
+}
+
+// GetBuild fetches a build from the API
+func (s *DevOpsService) GetBuild(ctx context.Context, buildID int) (*build.Build, error) {
+	return s.client.GetBuildByID(ctx, buildID)
+}
+
+// GetBuildLogLines returns the lines of a build log from startLine (1-based) on
+func (s *DevOpsService) GetBuildLogLines(ctx context.Context, buildID, logID int, startLine uint64) ([]string, error) {
+	return s.client.GetBuildLogLines(ctx, buildID, logID, startLine)
+}
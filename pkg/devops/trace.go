@@ -0,0 +1,377 @@
+package devops
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"adoctl/pkg/cache"
+	"adoctl/pkg/logger"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+)
+
+// Trace follows a change from a work item, pull request or commit to the
+// environments it reached. Only one of WorkItem, PullRequests and Commit is set:
+// the root of the chain.
+type Trace struct {
+	Subject      string             `json:"subject"`
+	WorkItem     *TraceWorkItem     `json:"workItem,omitempty"`
+	PullRequests []TracePullRequest `json:"pullRequests,omitempty"`
+	Commit       *TraceCommit       `json:"commit,omitempty"`
+}
+
+// TraceWorkItem is a work item with the pull requests linked to it
+type TraceWorkItem struct {
+	ID           int                `json:"id"`
+	Type         string             `json:"type"`
+	Title        string             `json:"title"`
+	State        string             `json:"state"`
+	CreatedAt    *time.Time         `json:"createdAt,omitempty"`
+	PullRequests []TracePullRequest `json:"pullRequests"`
+}
+
+// TracePullRequest is a pull request with the commit it merged, if completed
+type TracePullRequest struct {
+	ID           int        `json:"id"`
+	Title        string     `json:"title"`
+	Status       string     `json:"status"`
+	Repository   string     `json:"repository"`
+	SourceBranch string     `json:"sourceBranch"`
+	TargetBranch string     `json:"targetBranch"`
+	CreatedAt    *time.Time `json:"createdAt,omitempty"`
+	// ClosedAt is when the PR was completed or abandoned
+	ClosedAt    *time.Time   `json:"closedAt,omitempty"`
+	URL         string       `json:"url"`
+	MergeCommit *TraceCommit `json:"mergeCommit,omitempty"`
+}
+
+// TraceCommit is a commit with the builds that ran on it
+type TraceCommit struct {
+	Commit       string       `json:"commit"`
+	RepositoryID string       `json:"repositoryId,omitempty"`
+	Builds       []TraceBuild `json:"builds"`
+}
+
+// TraceBuild is a build with the releases created from it
+type TraceBuild struct {
+	ID          int            `json:"id"`
+	Definition  string         `json:"definition"`
+	Branch      string         `json:"branch"`
+	Status      string         `json:"status"`
+	Result      string         `json:"result,omitempty"`
+	StartedAt   time.Time      `json:"startedAt"`
+	CompletedAt *time.Time     `json:"completedAt,omitempty"`
+	URL         string         `json:"url"`
+	Releases    []TraceRelease `json:"releases"`
+}
+
+// TraceRelease is a release with the latest deployment of each of its environments
+type TraceRelease struct {
+	ID           int                `json:"id"`
+	Name         string             `json:"name"`
+	Definition   string             `json:"definition"`
+	URL          string             `json:"url"`
+	Environments []TraceEnvironment `json:"environments"`
+}
+
+// TraceEnvironment is the latest deployment of a release to an environment
+type TraceEnvironment struct {
+	Environment  string     `json:"environment"`
+	DeploymentID int        `json:"deploymentId"`
+	Status       string     `json:"status"`
+	StartedAt    time.Time  `json:"startedAt"`
+	CompletedAt  *time.Time `json:"completedAt,omitempty"`
+	// environmentID orders environments as they appear in the release definition
+	environmentID int
+}
+
+// TraceWorkItem traces a work item through its linked pull requests
+func (s *DevOpsService) TraceWorkItem(ctx context.Context, workItemID int) (*Trace, error) {
+	if s.cache == nil {
+		return nil, fmt.Errorf("cache not initialized")
+	}
+
+	workItem, err := s.client.GetWorkItem(ctx, workItemID)
+	if err != nil {
+		return nil, err
+	}
+	relations, err := s.client.GetWorkItemRelations(ctx, workItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &TraceWorkItem{ID: workItemID, PullRequests: []TracePullRequest{}}
+	if fields, ok := workItem["fields"].(map[string]any); ok {
+		item.Type, _ = fields["System.WorkItemType"].(string)
+		item.Title, _ = fields["System.Title"].(string)
+		item.State, _ = fields["System.State"].(string)
+		if created, ok := fields["System.CreatedDate"].(string); ok {
+			if t, err := time.Parse(time.RFC3339, created); err == nil {
+				item.CreatedAt = &t
+			}
+		}
+	}
+
+	prIDs := []int{}
+	for _, relation := range relations {
+		if rel, _ := relation["rel"].(string); rel != "ArtifactLink" {
+			continue
+		}
+		artifactURL, _ := relation["url"].(string)
+		if prID, ok := ParsePullRequestArtifactURL(artifactURL); ok {
+			prIDs = append(prIDs, prID)
+		}
+	}
+	sort.Ints(prIDs)
+
+	for _, prID := range prIDs {
+		pr, err := s.client.GetPullRequest(ctx, prID)
+		if err != nil {
+			logger.Warn().Err(err).Int("pr_id", prID).Msg("Failed to get linked pull request")
+			continue
+		}
+		item.PullRequests = append(item.PullRequests, s.tracePullRequest(pr))
+	}
+
+	return &Trace{Subject: fmt.Sprintf("work item %d", workItemID), WorkItem: item}, nil
+}
+
+// TracePullRequest traces a pull request through its merge commit
+func (s *DevOpsService) TracePullRequest(ctx context.Context, pullRequestID int) (*Trace, error) {
+	if s.cache == nil {
+		return nil, fmt.Errorf("cache not initialized")
+	}
+
+	pr, err := s.client.GetPullRequest(ctx, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Trace{
+		Subject:      fmt.Sprintf("pull request %d", pullRequestID),
+		PullRequests: []TracePullRequest{s.tracePullRequest(pr)},
+	}, nil
+}
+
+// TraceCommit traces a commit through its builds. A short SHA is expanded from the
+// builds cache. When repositoryID is empty, the repositories of the commit's builds
+// are searched for the pull requests that merged it.
+func (s *DevOpsService) TraceCommit(ctx context.Context, commit, repositoryID string) (*Trace, error) {
+	if s.cache == nil {
+		return nil, fmt.Errorf("cache not initialized")
+	}
+
+	commit = strings.ToLower(commit)
+	builds, err := s.GetBuildsForCommit(commit, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to search builds in cache: %w", err)
+	}
+
+	if len(commit) < 40 {
+		if len(builds) == 0 {
+			return nil, fmt.Errorf("no cached builds for commit %s, use the full SHA", commit)
+		}
+		commit = builds[0].SourceVersion
+	}
+
+	repositoryIDs := []string{}
+	if repositoryID != "" {
+		repositoryIDs = append(repositoryIDs, repositoryID)
+	} else {
+		for _, b := range builds {
+			if b.RepositoryID != "" && !slices.Contains(repositoryIDs, b.RepositoryID) {
+				repositoryIDs = append(repositoryIDs, b.RepositoryID)
+			}
+		}
+	}
+
+	trace := &Trace{Subject: "commit " + ShortCommit(commit)}
+	for _, repoID := range repositoryIDs {
+		prs, err := s.client.GetPullRequestsByCommit(ctx, repoID, commit)
+		if err != nil {
+			logger.Warn().Err(err).Str("repository_id", repoID).Msg("Failed to find pull requests for commit")
+			continue
+		}
+		for i := range prs {
+			trace.PullRequests = append(trace.PullRequests, s.tracePullRequest(&prs[i]))
+		}
+	}
+
+	if len(trace.PullRequests) == 0 {
+		trace.Commit = s.traceCommit(commit, repositoryID)
+	}
+	return trace, nil
+}
+
+func (s *DevOpsService) tracePullRequest(pr *git.GitPullRequest) TracePullRequest {
+	node := TracePullRequest{}
+	if pr.PullRequestId != nil {
+		node.ID = *pr.PullRequestId
+	}
+	if pr.Title != nil {
+		node.Title = *pr.Title
+	}
+	if pr.Status != nil {
+		node.Status = string(*pr.Status)
+	}
+	if pr.SourceRefName != nil {
+		node.SourceBranch = strings.TrimPrefix(*pr.SourceRefName, "refs/heads/")
+	}
+	if pr.TargetRefName != nil {
+		node.TargetBranch = strings.TrimPrefix(*pr.TargetRefName, "refs/heads/")
+	}
+	if pr.CreationDate != nil {
+		node.CreatedAt = &pr.CreationDate.Time
+	}
+	if pr.ClosedDate != nil && !pr.ClosedDate.Time.IsZero() {
+		node.ClosedAt = &pr.ClosedDate.Time
+	}
+
+	repositoryID := ""
+	if pr.Repository != nil {
+		if pr.Repository.Name != nil {
+			node.Repository = *pr.Repository.Name
+		}
+		if pr.Repository.Id != nil {
+			repositoryID = pr.Repository.Id.String()
+		}
+	}
+	node.URL = fmt.Sprintf("https://dev.azure.com/%s/%s/_git/%s/pullrequest/%d", s.client.GetOrganization(), s.client.GetProject(), node.Repository, node.ID)
+
+	// Until a PR completes its last merge commit is a preview merge that never reaches
+	// the target branch
+	if node.Status == string(git.PullRequestStatusValues.Completed) && pr.LastMergeCommit != nil && pr.LastMergeCommit.CommitId != nil {
+		node.MergeCommit = s.traceCommit(*pr.LastMergeCommit.CommitId, repositoryID)
+	}
+
+	return node
+}
+
+// traceCommit collects the cached builds of a commit, oldest first, with their releases
+func (s *DevOpsService) traceCommit(commit, repositoryID string) *TraceCommit {
+	node := &TraceCommit{Commit: commit, RepositoryID: repositoryID, Builds: []TraceBuild{}}
+
+	builds, err := s.GetBuildsForCommit(commit, "", "")
+	if err != nil {
+		logger.Warn().Err(err).Str("commit", commit).Msg("Failed to search builds in cache")
+		return node
+	}
+
+	for i := len(builds) - 1; i >= 0; i-- {
+		b := builds[i]
+		if repositoryID != "" && b.RepositoryID != "" && b.RepositoryID != repositoryID {
+			continue
+		}
+		node.Builds = append(node.Builds, s.traceBuild(b))
+	}
+
+	return node
+}
+
+func (s *DevOpsService) traceBuild(b cache.Build) TraceBuild {
+	node := TraceBuild{
+		ID:         b.BuildID,
+		Definition: b.Repository,
+		Branch:     strings.TrimPrefix(b.Branch, "refs/heads/"),
+		Status:     b.Status,
+		Result:     b.Result,
+		StartedAt:  b.StartTime,
+		URL:        s.BuildWebURL(b.BuildID),
+		Releases:   []TraceRelease{},
+	}
+	if b.EndTime.Valid {
+		node.CompletedAt = &b.EndTime.Time
+	}
+
+	deployments, err := s.SearchDeploymentsCached(map[string]any{"build_id": b.BuildID})
+	if err != nil {
+		logger.Warn().Err(err).Int("build_id", b.BuildID).Msg("Failed to search deployments in cache")
+		return node
+	}
+	node.Releases = GroupTraceReleases(deployments)
+	for i := range node.Releases {
+		node.Releases[i].URL = s.ReleaseWebURL(node.Releases[i].ID)
+	}
+
+	return node
+}
+
+// GroupTraceReleases groups deployments by release, oldest release first, keeping
+// the latest deployment of each environment in release definition order
+func GroupTraceReleases(deployments []cache.Deployment) []TraceRelease {
+	releases := []TraceRelease{}
+	index := map[int]int{}
+	latest := map[[2]int]int{}
+
+	for _, d := range deployments {
+		i, ok := index[d.ReleaseID]
+		if !ok {
+			i = len(releases)
+			index[d.ReleaseID] = i
+			releases = append(releases, TraceRelease{
+				ID:           d.ReleaseID,
+				Name:         d.ReleaseName,
+				Definition:   d.DefinitionName,
+				Environments: []TraceEnvironment{},
+			})
+		}
+
+		env := TraceEnvironment{
+			Environment:   d.Environment,
+			DeploymentID:  d.DeploymentID,
+			Status:        d.Status,
+			StartedAt:     d.StartTime,
+			environmentID: d.EnvironmentID,
+		}
+		if d.EndTime.Valid {
+			completed := d.EndTime.Time
+			env.CompletedAt = &completed
+		}
+
+		// Redeployments of an environment replace the earlier attempts
+		key := [2]int{d.ReleaseID, d.EnvironmentID}
+		if j, ok := latest[key]; ok {
+			if releases[i].Environments[j].DeploymentID < env.DeploymentID {
+				releases[i].Environments[j] = env
+			}
+			continue
+		}
+		latest[key] = len(releases[i].Environments)
+		releases[i].Environments = append(releases[i].Environments, env)
+	}
+
+	sort.Slice(releases, func(i, j int) bool { return releases[i].ID < releases[j].ID })
+	for _, r := range releases {
+		sort.SliceStable(r.Environments, func(i, j int) bool {
+			return r.Environments[i].environmentID < r.Environments[j].environmentID
+		})
+	}
+
+	return releases
+}
+
+// ParsePullRequestArtifactURL extracts the pull request ID from a work item
+// artifact link such as vstfs:///Git/PullRequestId/{project}%2F{repository}%2F{id}
+func ParsePullRequestArtifactURL(artifactURL string) (int, bool) {
+	const prefix = "vstfs:///Git/PullRequestId/"
+	if !strings.HasPrefix(strings.ToLower(artifactURL), strings.ToLower(prefix)) {
+		return 0, false
+	}
+
+	path, err := url.PathUnescape(artifactURL[len(prefix):])
+	if err != nil {
+		return 0, false
+	}
+	parts := strings.Split(path, "/")
+	id, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
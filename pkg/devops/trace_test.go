@@ -0,0 +1,100 @@
+package devops
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"adoctl/pkg/cache"
+)
+
+func TestGroupTraceReleases(t *testing.T) {
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(10 * time.Minute)
+
+	deployment := func(releaseID, deploymentID, environmentID int, environment, status string) cache.Deployment {
+		return cache.Deployment{
+			ReleaseID:      releaseID,
+			ReleaseName:    fmt.Sprintf("Release-%d", releaseID),
+			DefinitionName: "web",
+			DeploymentID:   deploymentID,
+			EnvironmentID:  environmentID,
+			Environment:    environment,
+			Status:         status,
+			StartTime:      start,
+			EndTime:        sql.NullTime{Time: end, Valid: true},
+		}
+	}
+
+	deployments := []cache.Deployment{
+		deployment(2, 21, 1, "dev", "succeeded"),
+		// prod is listed before test, but comes after it in the definition
+		deployment(1, 13, 3, "prod", "failed"),
+		deployment(1, 11, 1, "dev", "succeeded"),
+		deployment(1, 12, 2, "test", "succeeded"),
+		// A redeployment of prod replaces the failed attempt, whatever the order
+		deployment(1, 15, 3, "prod", "succeeded"),
+		deployment(1, 14, 3, "prod", "canceled"),
+	}
+
+	type env struct {
+		Environment  string
+		DeploymentID int
+		Status       string
+	}
+	got := map[int][]env{}
+	var order []int
+	for _, r := range GroupTraceReleases(deployments) {
+		order = append(order, r.ID)
+		for _, e := range r.Environments {
+			if e.CompletedAt == nil || !e.CompletedAt.Equal(end) {
+				t.Errorf("release %d %s CompletedAt = %v, want %v", r.ID, e.Environment, e.CompletedAt, end)
+			}
+			got[r.ID] = append(got[r.ID], env{e.Environment, e.DeploymentID, e.Status})
+		}
+	}
+
+	if want := []int{1, 2}; !reflect.DeepEqual(order, want) {
+		t.Errorf("GroupTraceReleases() release order = %v, want %v", order, want)
+	}
+	want := map[int][]env{
+		1: {{"dev", 11, "succeeded"}, {"test", 12, "succeeded"}, {"prod", 15, "succeeded"}},
+		2: {{"dev", 21, "succeeded"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupTraceReleases() environments = %v, want %v", got, want)
+	}
+
+	if releases := GroupTraceReleases(nil); len(releases) != 0 {
+		t.Errorf("GroupTraceReleases(nil) = %v, want none", releases)
+	}
+}
+
+func TestParsePullRequestArtifactURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		wantID int
+		wantOK bool
+	}{
+		{"escaped project and repository", "vstfs:///Git/PullRequestId/3f1c%2Fa9b2%2F1234", 1234, true},
+		{"unescaped", "vstfs:///Git/PullRequestId/project/repo/56", 56, true},
+		{"prefix case differs", "VSTFS:///git/pullrequestid/p%2Fr%2F7", 7, true},
+		{"commit link", "vstfs:///Git/Commit/p%2Fr%2Fabc123", 0, false},
+		{"not a number", "vstfs:///Git/PullRequestId/p%2Fr%2Fabc", 0, false},
+		{"zero", "vstfs:///Git/PullRequestId/p%2Fr%2F0", 0, false},
+		{"bad escape", "vstfs:///Git/PullRequestId/p%2Fr%ZZ", 0, false},
+		{"empty", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ok := ParsePullRequestArtifactURL(tt.url)
+			if id != tt.wantID || ok != tt.wantOK {
+				t.Errorf("ParsePullRequestArtifactURL(%q) = %d, %v, want %d, %v", tt.url, id, ok, tt.wantID, tt.wantOK)
+			}
+		})
+	}
+}
@@ -4,8 +4,33 @@ import (
 	"context"
 	"fmt"
 	"strings"
+
+	"adoctl/pkg/models"
 )
 
+// GetCurrentUser returns the identity the configured token authenticates as.
+func (s *DevOpsService) GetCurrentUser(ctx context.Context) (*models.Identity, error) {
+	user, err := s.client.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve current user: %w", err)
+	}
+
+	identity := &models.Identity{
+		ID: user.Id.String(),
+	}
+	if user.ProviderDisplayName != nil {
+		identity.DisplayName = *user.ProviderDisplayName
+	}
+	if user.CustomDisplayName != nil && *user.CustomDisplayName != "" {
+		identity.DisplayName = *user.CustomDisplayName
+	}
+	if user.SubjectDescriptor != nil {
+		identity.Descriptor = *user.SubjectDescriptor
+	}
+
+	return identity, nil
+}
+
 func (s *DevOpsService) GetCurrentUserID() (string, error) {
 	prs, err := s.ListPullRequests(context.Background(), "", "all", "", "", "")
 	if err != nil {
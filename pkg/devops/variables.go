@@ -0,0 +1,318 @@
+package devops
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"adoctl/pkg/models"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
+)
+
+// Variable is a variable of a variable group or pipeline definition. Azure DevOps
+// never returns the value of a secret variable, so Value is empty when Secret is set.
+type Variable struct {
+	Name     string `json:"name"`
+	Value    string `json:"value,omitempty"`
+	Secret   bool   `json:"secret"`
+	ReadOnly bool   `json:"readOnly,omitempty"`
+	// AllowOverride is set on pipeline variables that can be set at queue time
+	AllowOverride bool `json:"allowOverride,omitempty"`
+}
+
+// VariableGroup is a library variable group with its variables sorted by name
+type VariableGroup struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Type is Vsts for plain groups and AzureKeyVault for groups linked to a key vault
+	Type       string     `json:"type"`
+	ModifiedBy string     `json:"modifiedBy,omitempty"`
+	ModifiedOn time.Time  `json:"modifiedOn,omitempty"`
+	Variables  []Variable `json:"variables"`
+}
+
+// VariableChange is a variable to set. Secret and AllowOverride are nil to keep
+// the current setting of an existing variable; new variables default to false.
+type VariableChange struct {
+	Value  string
+	Secret *bool
+	// AllowOverride only applies to pipeline variables
+	AllowOverride *bool
+}
+
+// VariableDiff compares a variable between two groups
+type VariableDiff struct {
+	Name string `json:"name"`
+	// Status is added, removed, changed, same or secret (both secret, not comparable)
+	Status string    `json:"status"`
+	Left   *Variable `json:"left,omitempty"`
+	Right  *Variable `json:"right,omitempty"`
+}
+
+const keyVaultGroupType = "AzureKeyVault"
+
+// ListVariableGroups returns the variable groups of the project
+func (s *DevOpsService) ListVariableGroups(ctx context.Context) ([]VariableGroup, error) {
+	groups, err := s.client.GetVariableGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]VariableGroup, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, toVariableGroup(g))
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return strings.ToLower(result[i].Name) < strings.ToLower(result[j].Name)
+	})
+	return result, nil
+}
+
+// GetVariableGroup finds a variable group by ID or case-insensitive name
+func (s *DevOpsService) GetVariableGroup(ctx context.Context, nameOrID string) (*VariableGroup, error) {
+	if id, err := strconv.Atoi(nameOrID); err == nil {
+		group, err := s.client.GetVariableGroup(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		result := toVariableGroup(*group)
+		return &result, nil
+	}
+
+	groups, err := s.ListVariableGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range groups {
+		if strings.EqualFold(g.Name, nameOrID) {
+			return &g, nil
+		}
+	}
+	return nil, fmt.Errorf("variable group '%s' not found", nameOrID)
+}
+
+// UpdateGroupVariables sets and removes variables of a variable group, keeping
+// its other variables, including secret values, unchanged. A variable that is
+// set keeps its name and attributes, such as read-only, unless the change sets them.
+func (s *DevOpsService) UpdateGroupVariables(ctx context.Context, groupID int, set map[string]VariableChange, unset []string) (*VariableGroup, error) {
+	group, err := s.client.GetVariableGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Type != nil && *group.Type == keyVaultGroupType {
+		return nil, fmt.Errorf("variable group '%s' is linked to a key vault; change its secrets in the key vault", models.DereferenceString(group.Name))
+	}
+
+	variables := map[string]interface{}{}
+	if group.Variables != nil {
+		variables = *group.Variables
+	}
+	for _, name := range unset {
+		key, ok := findVariableKey(variables, name)
+		if !ok {
+			return nil, fmt.Errorf("variable '%s' not found in group '%s'", name, models.DereferenceString(group.Name))
+		}
+		delete(variables, key)
+	}
+	for name, change := range set {
+		entry := map[string]interface{}{}
+		if key, ok := findVariableKey(variables, name); ok {
+			if fields, ok := variables[key].(map[string]interface{}); ok {
+				entry = fields
+			}
+			name = key
+		}
+		entry["value"] = change.Value
+		if change.Secret != nil {
+			entry["isSecret"] = *change.Secret
+		}
+		variables[name] = entry
+	}
+
+	params := &taskagent.VariableGroupParameters{
+		Name:                           group.Name,
+		Description:                    group.Description,
+		Type:                           group.Type,
+		ProviderData:                   group.ProviderData,
+		VariableGroupProjectReferences: group.VariableGroupProjectReferences,
+		Variables:                      &variables,
+	}
+
+	updated, err := s.client.UpdateVariableGroup(ctx, groupID, params)
+	if err != nil {
+		return nil, err
+	}
+	result := toVariableGroup(*updated)
+	return &result, nil
+}
+
+// ListPipelineVariables returns the variables defined on a pipeline definition,
+// sorted by name. Variables declared in the YAML file are not included.
+func (s *DevOpsService) ListPipelineVariables(ctx context.Context, definitionID int) ([]Variable, error) {
+	definition, err := s.client.GetDefinition(ctx, definitionID)
+	if err != nil {
+		return nil, err
+	}
+	return toPipelineVariables(definition.Variables), nil
+}
+
+// SetPipelineVariable sets a variable on a pipeline definition, keeping the other
+// variables, including secret values, unchanged. An existing variable keeps its
+// name and attributes unless the change sets them.
+func (s *DevOpsService) SetPipelineVariable(ctx context.Context, definitionID int, name string, change VariableChange) error {
+	definition, err := s.client.GetDefinition(ctx, definitionID)
+	if err != nil {
+		return err
+	}
+
+	variables := map[string]build.BuildDefinitionVariable{}
+	if definition.Variables != nil {
+		variables = *definition.Variables
+	}
+	variable := build.BuildDefinitionVariable{}
+	for key, existing := range variables {
+		if strings.EqualFold(key, name) {
+			variable, name = existing, key
+			break
+		}
+	}
+	value := change.Value
+	variable.Value = &value
+	if change.Secret != nil {
+		variable.IsSecret = change.Secret
+	}
+	if change.AllowOverride != nil {
+		variable.AllowOverride = change.AllowOverride
+	}
+	variables[name] = variable
+	definition.Variables = &variables
+
+	_, err = s.client.UpdateDefinition(ctx, definition)
+	return err
+}
+
+// DiffVariableGroups compares the variables of two groups by name. Variable
+// names are case-insensitive in Azure DevOps, so they are matched that way.
+func DiffVariableGroups(left, right *VariableGroup) []VariableDiff {
+	byName := map[string]*VariableDiff{}
+	names := []string{}
+	entry := func(name string) *VariableDiff {
+		key := strings.ToLower(name)
+		if d, ok := byName[key]; ok {
+			return d
+		}
+		d := &VariableDiff{Name: name}
+		byName[key] = d
+		names = append(names, key)
+		return d
+	}
+
+	for i := range left.Variables {
+		entry(left.Variables[i].Name).Left = &left.Variables[i]
+	}
+	for i := range right.Variables {
+		entry(right.Variables[i].Name).Right = &right.Variables[i]
+	}
+
+	sort.Strings(names)
+	diffs := make([]VariableDiff, 0, len(names))
+	for _, key := range names {
+		d := byName[key]
+		switch {
+		case d.Right == nil:
+			d.Status = "removed"
+		case d.Left == nil:
+			d.Status = "added"
+		case d.Left.Secret && d.Right.Secret:
+			d.Status = "secret"
+		case d.Left.Secret != d.Right.Secret || d.Left.Value != d.Right.Value:
+			d.Status = "changed"
+		default:
+			d.Status = "same"
+		}
+		diffs = append(diffs, *d)
+	}
+	return diffs
+}
+
+func toVariableGroup(g taskagent.VariableGroup) VariableGroup {
+	group := VariableGroup{
+		Name:        models.DereferenceString(g.Name),
+		Description: models.DereferenceString(g.Description),
+		Type:        models.DereferenceString(g.Type),
+		Variables:   []Variable{},
+	}
+	if g.Id != nil {
+		group.ID = *g.Id
+	}
+	if g.ModifiedBy != nil {
+		group.ModifiedBy = models.DereferenceString(g.ModifiedBy.DisplayName)
+	}
+	if g.ModifiedOn != nil {
+		group.ModifiedOn = g.ModifiedOn.Time
+	}
+	if g.Variables != nil {
+		for name, raw := range *g.Variables {
+			group.Variables = append(group.Variables, parseGroupVariable(name, raw))
+		}
+	}
+	sort.Slice(group.Variables, func(i, j int) bool {
+		return strings.ToLower(group.Variables[i].Name) < strings.ToLower(group.Variables[j].Name)
+	})
+	return group
+}
+
+// parseGroupVariable reads a group variable, which the API returns untyped
+func parseGroupVariable(name string, raw interface{}) Variable {
+	variable := Variable{Name: name}
+	fields, ok := raw.(map[string]interface{})
+	if !ok {
+		return variable
+	}
+	variable.Value, _ = fields["value"].(string)
+	variable.Secret, _ = fields["isSecret"].(bool)
+	variable.ReadOnly, _ = fields["isReadOnly"].(bool)
+	if variable.Secret {
+		variable.Value = ""
+	}
+	return variable
+}
+
+func toPipelineVariables(raw *map[string]build.BuildDefinitionVariable) []Variable {
+	variables := []Variable{}
+	if raw == nil {
+		return variables
+	}
+	for name, v := range *raw {
+		variable := Variable{Name: name}
+		if v.IsSecret != nil {
+			variable.Secret = *v.IsSecret
+		}
+		if v.AllowOverride != nil {
+			variable.AllowOverride = *v.AllowOverride
+		}
+		if v.Value != nil && !variable.Secret {
+			variable.Value = *v.Value
+		}
+		variables = append(variables, variable)
+	}
+	sort.Slice(variables, func(i, j int) bool {
+		return strings.ToLower(variables[i].Name) < strings.ToLower(variables[j].Name)
+	})
+	return variables
+}
+
+func findVariableKey(variables map[string]interface{}, name string) (string, bool) {
+	for key := range variables {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,130 @@
+package devops
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGroupVariable(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  interface{}
+		want Variable
+	}{
+		{
+			name: "plain value",
+			raw:  map[string]interface{}{"value": "debug"},
+			want: Variable{Name: "v", Value: "debug"},
+		},
+		{
+			name: "secret value is dropped",
+			raw:  map[string]interface{}{"value": "leaked", "isSecret": true},
+			want: Variable{Name: "v", Secret: true},
+		},
+		{
+			name: "secret without value",
+			raw:  map[string]interface{}{"value": nil, "isSecret": true},
+			want: Variable{Name: "v", Secret: true},
+		},
+		{
+			name: "read-only",
+			raw:  map[string]interface{}{"value": "x", "isReadOnly": true},
+			want: Variable{Name: "v", Value: "x", ReadOnly: true},
+		},
+		{
+			name: "unexpected shape",
+			raw:  "x",
+			want: Variable{Name: "v"},
+		},
+		{
+			name: "nil",
+			raw:  nil,
+			want: Variable{Name: "v"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseGroupVariable("v", tt.raw)
+			if got != tt.want {
+				t.Errorf("parseGroupVariable() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffVariableGroups(t *testing.T) {
+	plain := func(name, value string) Variable { return Variable{Name: name, Value: value} }
+	secret := func(name string) Variable { return Variable{Name: name, Secret: true} }
+
+	tests := []struct {
+		name  string
+		left  []Variable
+		right []Variable
+		want  map[string]string
+		order []string
+	}{
+		{
+			name:  "empty groups",
+			want:  map[string]string{},
+			order: []string{},
+		},
+		{
+			name:  "added, removed, changed and same",
+			left:  []Variable{plain("A", "1"), plain("B", "2"), plain("C", "3")},
+			right: []Variable{plain("B", "2"), plain("C", "4"), plain("D", "5")},
+			want:  map[string]string{"A": "removed", "B": "same", "C": "changed", "D": "added"},
+			order: []string{"A", "B", "C", "D"},
+		},
+		{
+			name:  "names match case-insensitively",
+			left:  []Variable{plain("Log_Level", "debug")},
+			right: []Variable{plain("LOG_LEVEL", "debug")},
+			want:  map[string]string{"Log_Level": "same"},
+			order: []string{"Log_Level"},
+		},
+		{
+			name:  "secret in both is not compared",
+			left:  []Variable{secret("TOKEN")},
+			right: []Variable{secret("TOKEN")},
+			want:  map[string]string{"TOKEN": "secret"},
+			order: []string{"TOKEN"},
+		},
+		{
+			name:  "secret on one side only is a change",
+			left:  []Variable{secret("TOKEN")},
+			right: []Variable{plain("TOKEN", "")},
+			want:  map[string]string{"TOKEN": "changed"},
+			order: []string{"TOKEN"},
+		},
+		{
+			name:  "sorted by name",
+			left:  []Variable{plain("b", "1"), plain("A", "1")},
+			right: []Variable{plain("c", "1")},
+			want:  map[string]string{"A": "removed", "b": "removed", "c": "added"},
+			order: []string{"A", "b", "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs := DiffVariableGroups(&VariableGroup{Variables: tt.left}, &VariableGroup{Variables: tt.right})
+
+			got := map[string]string{}
+			order := []string{}
+			for _, d := range diffs {
+				got[d.Name] = d.Status
+				order = append(order, d.Name)
+				if (d.Left == nil) != (d.Status == "added") || (d.Right == nil) != (d.Status == "removed") {
+					t.Errorf("%s: status %s with left %v and right %v", d.Name, d.Status, d.Left, d.Right)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffVariableGroups() statuses = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(order, tt.order) {
+				t.Errorf("DiffVariableGroups() order = %v, want %v", order, tt.order)
+			}
+		})
+	}
+}
@@ -24,6 +24,8 @@ const (
 	ExitCodeCancellation   ExitCode = 8
 	ExitCodeTimeout        ExitCode = 9
 	ExitCodeNotImplemented ExitCode = 10
+	ExitCodeBuildFailed    ExitCode = 11
+	ExitCodeBuildPartial   ExitCode = 12
 )
 
 // Standardized error messages for consistent user-facing errors
@@ -429,6 +431,55 @@ func CancelledError(operation string) *Error {
 	}
 }
 
+// BuildResultError maps the result of a completed build to an error with a
+// matching exit code, or nil when the build succeeded
+func BuildResultError(buildID int, result string) *Error {
+	switch result {
+	case "succeeded":
+		return nil
+	case "partiallySucceeded":
+		return &Error{
+			Code:    ExitCodeBuildPartial,
+			Message: fmt.Sprintf("Build %d partially succeeded", buildID),
+		}
+	case "canceled":
+		return &Error{
+			Code:    ExitCodeCancellation,
+			Message: fmt.Sprintf("Build %d was canceled", buildID),
+		}
+	default:
+		return &Error{
+			Code:       ExitCodeBuildFailed,
+			Message:    fmt.Sprintf("Build %d finished with result %s", buildID, result),
+			Suggestion: fmt.Sprintf("Inspect the build logs with: adoctl build logs %d", buildID),
+		}
+	}
+}
+
+// DeploymentResultError maps the final status of a release environment to an
+// error with the same exit codes as builds, or nil when the deployment succeeded
+func DeploymentResultError(releaseName, environment, status string) *Error {
+	switch status {
+	case "succeeded":
+		return nil
+	case "partiallySucceeded":
+		return &Error{
+			Code:    ExitCodeBuildPartial,
+			Message: fmt.Sprintf("Deployment of %s to %s partially succeeded", releaseName, environment),
+		}
+	case "canceled":
+		return &Error{
+			Code:    ExitCodeCancellation,
+			Message: fmt.Sprintf("Deployment of %s to %s was canceled", releaseName, environment),
+		}
+	default:
+		return &Error{
+			Code:    ExitCodeBuildFailed,
+			Message: fmt.Sprintf("Deployment of %s to %s finished with status %s", releaseName, environment, status),
+		}
+	}
+}
+
 // CommandError wraps errors from command handlers with consistent formatting.
 // It preserves the original error chain for inspection while providing
 // a user-friendly message.
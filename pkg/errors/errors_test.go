@@ -243,6 +243,61 @@ func TestHelperFunctions(t *testing.T) {
 	}
 }
 
+func TestBuildResultError(t *testing.T) {
+	tests := []struct {
+		result string
+		want   ExitCode
+	}{
+		{"succeeded", ExitCodeSuccess},
+		{"partiallySucceeded", ExitCodeBuildPartial},
+		{"canceled", ExitCodeCancellation},
+		{"failed", ExitCodeBuildFailed},
+		{"none", ExitCodeBuildFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.result, func(t *testing.T) {
+			err := BuildResultError(42, tt.result)
+			if tt.want == ExitCodeSuccess {
+				if err != nil {
+					t.Errorf("BuildResultError(%q) = %v, want nil", tt.result, err)
+				}
+				return
+			}
+			if err == nil || err.Code != tt.want {
+				t.Errorf("BuildResultError(%q) = %v, want code %d", tt.result, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeploymentResultError(t *testing.T) {
+	tests := []struct {
+		status string
+		want   ExitCode
+	}{
+		{"succeeded", ExitCodeSuccess},
+		{"partiallySucceeded", ExitCodeBuildPartial},
+		{"canceled", ExitCodeCancellation},
+		{"rejected", ExitCodeBuildFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			err := DeploymentResultError("Release-1", "prod", tt.status)
+			if tt.want == ExitCodeSuccess {
+				if err != nil {
+					t.Errorf("DeploymentResultError(%q) = %v, want nil", tt.status, err)
+				}
+				return
+			}
+			if err == nil || err.Code != tt.want {
+				t.Errorf("DeploymentResultError(%q) = %v, want code %d", tt.status, err, tt.want)
+			}
+		})
+	}
+}
+
 func TestUserError(t *testing.T) {
 	err := UserError(ExitCodeValidation, "invalid input")
 
@@ -167,6 +167,8 @@ type PRFilter struct {
 	TargetBranch string
 	Status       string
 	CreatorID    string
+	// Query is an optional query expression that must also match
+	Query *Query
 }
 
 func (f *PRFilter) MatchesPR(pr models.PullRequest) (bool, error) {
@@ -228,5 +230,9 @@ func (f *PRFilter) MatchesPR(pr models.PullRequest) (bool, error) {
 		}
 	}
 
+	if f.Query != nil && !f.Query.Match(pr) {
+		return false, nil
+	}
+
 	return true, nil
 }
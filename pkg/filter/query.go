@@ -0,0 +1,505 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"adoctl/pkg/models"
+)
+
+// QueryFields lists the fields supported in PR query expressions
+var QueryFields = []string{"status", "author", "reviewer", "repo", "title", "source", "target", "age", "draft", "conflicts", "saved"}
+
+// Query is a compiled PR query expression such as
+//
+//	status:active author:me target:main title~"login" repo:/^api-/ age>7d draft:false
+//
+// Terms are separated by spaces and must all match. Each term is field, operator
+// and value, optionally prefixed with "-" to negate it:
+//
+//   - ":" matches exactly (substring for title); comma-separated values match any of them
+//   - "~" matches fuzzily
+//   - ":/regex/" matches a regular expression
+//   - ">", ">=", "<", "<=" compare ages such as 7d, 12h or 2w
+//
+// A bare word matches the title, and saved:<name> expands a saved query.
+type Query struct {
+	Expr string
+	// CurrentUserID is the identity "me" resolves to in author and reviewer terms
+	CurrentUserID string
+
+	terms []queryTerm
+}
+
+type queryTerm struct {
+	field  string
+	op     string
+	value  string
+	negate bool
+	match  func(pr models.PullRequest, q *Query, now time.Time) bool
+}
+
+// ParseQuery compiles a query expression. saved maps saved query names to their
+// expressions; an expression that is just a saved query name expands to it.
+func ParseQuery(expr string, saved map[string]string) (*Query, error) {
+	q := &Query{Expr: expr}
+	if err := q.parse(expr, saved, map[string]bool{}); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// Match returns true if the PR satisfies every term of the query
+func (q *Query) Match(pr models.PullRequest) bool {
+	now := time.Now()
+	for _, term := range q.terms {
+		if term.match(pr, q, now) == term.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// ReferencesCurrentUser returns true if the query uses "me" and needs CurrentUserID
+func (q *Query) ReferencesCurrentUser() bool {
+	for _, term := range q.terms {
+		if term.field != "author" && term.field != "reviewer" {
+			continue
+		}
+		for _, v := range splitValues(term.value) {
+			if strings.EqualFold(v, "me") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Status returns the PR status to request from the server: the status of a single
+// positive status:<value> term, "all" when the query constrains status in any other
+// way, or "" when the query has no status term.
+func (q *Query) Status() string {
+	status := ""
+	for _, term := range q.terms {
+		if term.field != "status" {
+			continue
+		}
+		values := splitValues(term.value)
+		if status != "" || term.negate || term.op != ":" || len(values) != 1 || isRegexValue(term.value) {
+			return string(models.PRStatusAll)
+		}
+		status = strings.ToLower(values[0])
+	}
+	return status
+}
+
+func (q *Query) parse(expr string, saved map[string]string, seen map[string]bool) error {
+	trimmed := strings.TrimSpace(expr)
+	if savedExpr, ok := saved[trimmed]; ok && trimmed != "" {
+		return q.expandSaved(trimmed, savedExpr, saved, seen)
+	}
+
+	tokens, err := tokenizeQuery(expr)
+	if err != nil {
+		return err
+	}
+
+	for _, tok := range tokens {
+		if tok.field == "saved" {
+			savedExpr, ok := saved[tok.value]
+			if !ok {
+				return fmt.Errorf("saved query '%s' not found", tok.value)
+			}
+			if tok.negate {
+				return fmt.Errorf("saved query '%s' cannot be negated", tok.value)
+			}
+			if err := q.expandSaved(tok.value, savedExpr, saved, seen); err != nil {
+				return err
+			}
+			continue
+		}
+
+		term, err := compileTerm(tok)
+		if err != nil {
+			return err
+		}
+		q.terms = append(q.terms, term)
+	}
+
+	return nil
+}
+
+func (q *Query) expandSaved(name, expr string, saved map[string]string, seen map[string]bool) error {
+	if seen[name] {
+		return fmt.Errorf("saved query '%s' references itself", name)
+	}
+	seen[name] = true
+	defer delete(seen, name)
+
+	if err := q.parse(expr, saved, seen); err != nil {
+		return fmt.Errorf("saved query '%s': %w", name, err)
+	}
+	return nil
+}
+
+// tokenizeQuery splits an expression into terms, honouring quoted and /regex/ values
+func tokenizeQuery(expr string) ([]queryTerm, error) {
+	terms := []queryTerm{}
+	runes := []rune(expr)
+	i := 0
+
+	for i < len(runes) {
+		if runes[i] == ' ' || runes[i] == '\t' || runes[i] == '\n' {
+			i++
+			continue
+		}
+
+		start := i
+		term := queryTerm{}
+		if runes[i] == '-' {
+			term.negate = true
+			i++
+		}
+
+		fieldStart := i
+		for i < len(runes) && isFieldRune(runes[i]) {
+			i++
+		}
+		field := strings.ToLower(string(runes[fieldStart:i]))
+
+		op := ""
+		if field != "" && i < len(runes) {
+			switch {
+			case strings.HasPrefix(string(runes[i:]), ">="), strings.HasPrefix(string(runes[i:]), "<="):
+				op = string(runes[i : i+2])
+			case strings.ContainsRune(":~><", runes[i]):
+				op = string(runes[i])
+			}
+		}
+
+		if op == "" {
+			// Bare word or quoted phrase: match against the title
+			i = start
+			if term.negate {
+				i++
+			}
+			value, next, err := readQueryValue(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			if value == "" {
+				return nil, fmt.Errorf("invalid query term at position %d", start+1)
+			}
+			i = next
+			term.field = "title"
+			term.op = ":"
+			term.value = value
+			terms = append(terms, term)
+			continue
+		}
+
+		i += len([]rune(op))
+		value, next, err := readQueryValue(runes, i)
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			return nil, fmt.Errorf("missing value for '%s%s'", field, op)
+		}
+		i = next
+
+		term.field = field
+		term.op = op
+		term.value = value
+		terms = append(terms, term)
+	}
+
+	return terms, nil
+}
+
+// readQueryValue reads a plain, "quoted" or /regex/ value starting at i.
+// Regex values keep their slashes so terms can tell them apart.
+func readQueryValue(runes []rune, i int) (string, int, error) {
+	if i >= len(runes) {
+		return "", i, nil
+	}
+
+	if runes[i] == '"' || runes[i] == '/' {
+		delim := runes[i]
+		var b strings.Builder
+		if delim == '/' {
+			b.WriteRune('/')
+		}
+		for j := i + 1; j < len(runes); j++ {
+			switch {
+			case runes[j] == '\\' && j+1 < len(runes) && runes[j+1] == delim:
+				b.WriteRune(delim)
+				j++
+			case runes[j] == delim:
+				if delim == '/' {
+					b.WriteRune('/')
+				}
+				return b.String(), j + 1, nil
+			default:
+				b.WriteRune(runes[j])
+			}
+		}
+		return "", i, fmt.Errorf("unterminated %c in query", delim)
+	}
+
+	j := i
+	for j < len(runes) && runes[j] != ' ' && runes[j] != '\t' && runes[j] != '\n' {
+		j++
+	}
+	return string(runes[i:j]), j, nil
+}
+
+func isFieldRune(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func compileTerm(term queryTerm) (queryTerm, error) {
+	var err error
+	switch term.field {
+	case "status":
+		term.match, err = compileStringTerm(term, func(pr models.PullRequest) []string {
+			return []string{string(pr.Status)}
+		}, false)
+		if err == nil && term.op == ":" && strings.EqualFold(term.value, string(models.PRStatusAll)) {
+			term.match = func(models.PullRequest, *Query, time.Time) bool { return true }
+		}
+	case "repo":
+		term.match, err = compileStringTerm(term, func(pr models.PullRequest) []string {
+			return []string{pr.Repository.Name}
+		}, false)
+	case "title":
+		term.match, err = compileStringTerm(term, func(pr models.PullRequest) []string {
+			return []string{pr.Title}
+		}, true)
+	case "source":
+		term.match, err = compileStringTerm(term, func(pr models.PullRequest) []string {
+			return []string{pr.GetSourceBranchName()}
+		}, false)
+	case "target":
+		term.match, err = compileStringTerm(term, func(pr models.PullRequest) []string {
+			return []string{pr.GetTargetBranchName()}
+		}, false)
+	case "author":
+		term.match, err = compileIdentityTerm(term, func(pr models.PullRequest) []models.Identity {
+			return []models.Identity{pr.CreatedBy}
+		})
+	case "reviewer":
+		term.match, err = compileIdentityTerm(term, func(pr models.PullRequest) []models.Identity {
+			identities := make([]models.Identity, 0, len(pr.Reviewers))
+			for _, r := range pr.Reviewers {
+				identities = append(identities, r.Identity)
+			}
+			return identities
+		})
+	case "age":
+		term.match, err = compileAgeTerm(term)
+	case "draft":
+		term.match, err = compileBoolTerm(term, func(pr models.PullRequest) bool { return pr.IsDraft })
+	case "conflicts":
+		term.match, err = compileBoolTerm(term, func(pr models.PullRequest) bool { return pr.HasMergeConflicts() })
+	default:
+		return term, fmt.Errorf("unknown query field '%s' (supported: %s)", term.field, strings.Join(QueryFields, ", "))
+	}
+
+	if err != nil {
+		return term, fmt.Errorf("invalid query term '%s%s%s': %w", term.field, term.op, term.value, err)
+	}
+	return term, nil
+}
+
+// compileStringTerm matches any of the values returned by get. With substring set,
+// ":" matches substrings instead of whole values.
+func compileStringTerm(term queryTerm, get func(pr models.PullRequest) []string, substring bool) (func(models.PullRequest, *Query, time.Time) bool, error) {
+	matches, err := compileValueMatcher(term, substring)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(pr models.PullRequest, _ *Query, _ time.Time) bool {
+		for _, s := range get(pr) {
+			if matches(s) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// compileIdentityTerm matches identities by ID, unique name or display name.
+// "me" matches the current user, and a leading "@" may be used for teams, which
+// also match their short name without the "[Project]\" prefix.
+func compileIdentityTerm(term queryTerm, get func(pr models.PullRequest) []models.Identity) (func(models.PullRequest, *Query, time.Time) bool, error) {
+	if term.op == ":" && !isRegexValue(term.value) {
+		values := splitValues(term.value)
+		return func(pr models.PullRequest, q *Query, _ time.Time) bool {
+			for _, identity := range get(pr) {
+				for _, v := range values {
+					if identityMatches(identity, v, q.CurrentUserID) {
+						return true
+					}
+				}
+			}
+			return false
+		}, nil
+	}
+
+	matches, err := compileValueMatcher(term, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(pr models.PullRequest, _ *Query, _ time.Time) bool {
+		for _, identity := range get(pr) {
+			if matches(identity.DisplayName) || matches(identity.UniqueName) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+func identityMatches(identity models.Identity, value, currentUserID string) bool {
+	if strings.EqualFold(value, "me") {
+		return currentUserID != "" && strings.EqualFold(identity.ID, currentUserID)
+	}
+
+	value = strings.TrimPrefix(value, "@")
+	if strings.EqualFold(identity.ID, value) || strings.EqualFold(identity.UniqueName, value) || strings.EqualFold(identity.DisplayName, value) {
+		return true
+	}
+
+	// Team display names look like "[Project]\Team"
+	if idx := strings.LastIndex(identity.DisplayName, `\`); idx >= 0 {
+		return strings.EqualFold(identity.DisplayName[idx+1:], value)
+	}
+	return false
+}
+
+// compileValueMatcher builds a string matcher for ":" (exact, any of comma-separated
+// values), ":/regex/" and "~" (fuzzy) operators.
+func compileValueMatcher(term queryTerm, substring bool) (func(string) bool, error) {
+	switch term.op {
+	case ":":
+		if isRegexValue(term.value) {
+			re, err := regexp.Compile(term.value[1 : len(term.value)-1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid regex: %w", err)
+			}
+			return re.MatchString, nil
+		}
+		if substring {
+			value := strings.ToLower(term.value)
+			return func(s string) bool {
+				return strings.Contains(strings.ToLower(s), value)
+			}, nil
+		}
+		values := splitValues(term.value)
+		return func(s string) bool {
+			for _, v := range values {
+				if strings.EqualFold(s, v) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case "~":
+		return func(s string) bool {
+			return FuzzyMatch(term.value, s)
+		}, nil
+	default:
+		return nil, fmt.Errorf("operator '%s' is not supported for %s", term.op, term.field)
+	}
+}
+
+func compileAgeTerm(term queryTerm) (func(models.PullRequest, *Query, time.Time) bool, error) {
+	age, err := ParseAge(term.value)
+	if err != nil {
+		return nil, err
+	}
+
+	var compare func(d time.Duration) bool
+	switch term.op {
+	case ">":
+		compare = func(d time.Duration) bool { return d > age }
+	case ">=":
+		compare = func(d time.Duration) bool { return d >= age }
+	case "<":
+		compare = func(d time.Duration) bool { return d < age }
+	case "<=":
+		compare = func(d time.Duration) bool { return d <= age }
+	default:
+		return nil, fmt.Errorf("operator '%s' is not supported for age, use >, >=, < or <=", term.op)
+	}
+
+	return func(pr models.PullRequest, _ *Query, now time.Time) bool {
+		if pr.CreationDate.IsZero() {
+			return false
+		}
+		return compare(now.Sub(pr.CreationDate))
+	}, nil
+}
+
+func compileBoolTerm(term queryTerm, get func(pr models.PullRequest) bool) (func(models.PullRequest, *Query, time.Time) bool, error) {
+	if term.op != ":" {
+		return nil, fmt.Errorf("operator '%s' is not supported for %s", term.op, term.field)
+	}
+
+	want, err := strconv.ParseBool(term.value)
+	if err != nil {
+		return nil, fmt.Errorf("expected true or false")
+	}
+
+	return func(pr models.PullRequest, _ *Query, _ time.Time) bool {
+		return get(pr) == want
+	}, nil
+}
+
+// ParseAge parses an age such as 30m, 12h, 7d or 2w
+func ParseAge(s string) (time.Duration, error) {
+	units := map[byte]time.Duration{
+		'd': 24 * time.Hour,
+		'w': 7 * 24 * time.Hour,
+	}
+
+	if len(s) > 1 {
+		if unit, ok := units[s[len(s)-1]]; ok {
+			n, err := strconv.Atoi(s[:len(s)-1])
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid age '%s'", s)
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age '%s', expected a value like 30m, 12h, 7d or 2w", s)
+	}
+	return d, nil
+}
+
+func isRegexValue(value string) bool {
+	return len(value) >= 2 && strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/")
+}
+
+func splitValues(value string) []string {
+	if isRegexValue(value) {
+		return []string{value}
+	}
+	parts := strings.Split(value, ",")
+	values := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			values = append(values, p)
+		}
+	}
+	return values
+}
@@ -0,0 +1,251 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"adoctl/pkg/models"
+)
+
+func TestParseQuery_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		expr      string
+		saved     map[string]string
+		errString string
+	}{
+		{
+			name:      "unknown field",
+			expr:      "colour:red",
+			errString: "unknown query field",
+		},
+		{
+			name:      "missing value",
+			expr:      "status:",
+			errString: "missing value",
+		},
+		{
+			name:      "invalid regex",
+			expr:      "title:/[invalid(/",
+			errString: "invalid regex",
+		},
+		{
+			name:      "unterminated quote",
+			expr:      `title~"login`,
+			errString: "unterminated",
+		},
+		{
+			name:      "invalid age",
+			expr:      "age>soon",
+			errString: "invalid age",
+		},
+		{
+			name:      "unsupported age operator",
+			expr:      "age:7d",
+			errString: "not supported for age",
+		},
+		{
+			name:      "invalid boolean",
+			expr:      "draft:maybe",
+			errString: "expected true or false",
+		},
+		{
+			name:      "comparison on string field",
+			expr:      "title>abc",
+			errString: "not supported for title",
+		},
+		{
+			name:      "unknown saved query",
+			expr:      "saved:missing",
+			errString: "saved query 'missing' not found",
+		},
+		{
+			name:      "recursive saved query",
+			expr:      "saved:loop",
+			saved:     map[string]string{"loop": "status:active saved:loop"},
+			errString: "references itself",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseQuery(tt.expr, tt.saved)
+			if err == nil {
+				t.Fatalf("ParseQuery(%q) expected error, got nil", tt.expr)
+			}
+			if !strings.Contains(err.Error(), tt.errString) {
+				t.Errorf("ParseQuery(%q) error = %v, want containing %q", tt.expr, err, tt.errString)
+			}
+		})
+	}
+}
+
+func TestQuery_Match(t *testing.T) {
+	const me = "user-1"
+
+	pr := models.PullRequest{
+		ID:           42,
+		Title:        "Fix login redirect",
+		Status:       models.PRStatusActive,
+		SourceBranch: "refs/heads/feature/login",
+		TargetBranch: "refs/heads/main",
+		MergeStatus:  models.MergeStatusConflicts,
+		Repository:   models.Repository{Name: "api-gateway"},
+		CreatedBy:    models.Identity{ID: me, DisplayName: "Jane Doe", UniqueName: "jane@example.com"},
+		CreationDate: time.Now().Add(-10 * 24 * time.Hour),
+		Reviewers: []models.Reviewer{
+			{Identity: models.Identity{ID: "team-1", DisplayName: `[Project]\team-x`}, IsContainer: true},
+			{Identity: models.Identity{ID: "user-2", DisplayName: "John Smith", UniqueName: "john@example.com"}, Vote: 10},
+		},
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		want bool
+	}{
+		{name: "empty query", expr: "", want: true},
+		{name: "full example", expr: `status:active author:me target:main title~"login" repo:/^api-/ age>7d draft:false conflicts:true reviewer:@team-x`, want: true},
+		{name: "status mismatch", expr: "status:completed", want: false},
+		{name: "status any of", expr: "status:completed,active", want: true},
+		{name: "status all", expr: "status:all", want: true},
+		{name: "author by email", expr: "author:jane@example.com", want: true},
+		{name: "author by other name", expr: "author:john@example.com", want: false},
+		{name: "author fuzzy", expr: "author~jdoe", want: true},
+		{name: "negated author", expr: "-author:me", want: false},
+		{name: "reviewer by display name", expr: `reviewer:"John Smith"`, want: true},
+		{name: "reviewer not present", expr: "reviewer:@team-y", want: false},
+		{name: "reviewer me", expr: "reviewer:me", want: false},
+		{name: "title substring", expr: "title:redirect", want: true},
+		{name: "title regex", expr: "title:/^Fix .* redirect$/", want: true},
+		{name: "bare word matches title", expr: "login", want: true},
+		{name: "quoted phrase matches title", expr: `"login redirect"`, want: true},
+		{name: "negated bare word", expr: "-login", want: false},
+		{name: "source exact", expr: "source:feature/login", want: true},
+		{name: "target exact mismatch", expr: "target:develop", want: false},
+		{name: "repo exact", expr: "repo:api-gateway", want: true},
+		{name: "repo regex mismatch", expr: "repo:/^web-/", want: false},
+		{name: "age less than", expr: "age<7d", want: false},
+		{name: "age in weeks", expr: "age>=1w", want: true},
+		{name: "draft mismatch", expr: "draft:true", want: false},
+		{name: "no conflicts", expr: "conflicts:false", want: false},
+		{name: "terms are anded", expr: "status:active target:develop", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseQuery(tt.expr, nil)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q) unexpected error: %v", tt.expr, err)
+			}
+			q.CurrentUserID = me
+
+			if got := q.Match(pr); got != tt.want {
+				t.Errorf("ParseQuery(%q).Match() = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery_SavedQueries(t *testing.T) {
+	saved := map[string]string{
+		"mine":       "author:me status:active",
+		"mine-stale": "saved:mine age>7d",
+	}
+
+	pr := models.PullRequest{
+		Status:       models.PRStatusActive,
+		CreatedBy:    models.Identity{ID: "user-1"},
+		CreationDate: time.Now().Add(-10 * 24 * time.Hour),
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		want bool
+	}{
+		{name: "query name alone", expr: "mine", want: true},
+		{name: "nested saved query", expr: "mine-stale", want: true},
+		{name: "saved term combined with others", expr: "saved:mine draft:true", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseQuery(tt.expr, saved)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q) unexpected error: %v", tt.expr, err)
+			}
+			q.CurrentUserID = "user-1"
+
+			if got := q.Match(pr); got != tt.want {
+				t.Errorf("ParseQuery(%q).Match() = %v, want %v", tt.expr, got, tt.want)
+			}
+			if !q.ReferencesCurrentUser() {
+				t.Errorf("ParseQuery(%q).ReferencesCurrentUser() = false, want true", tt.expr)
+			}
+		})
+	}
+}
+
+func TestQuery_Status(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{expr: "title:login", want: ""},
+		{expr: "status:active", want: "active"},
+		{expr: "status:Completed", want: "completed"},
+		{expr: "status:active,completed", want: "all"},
+		{expr: "-status:active", want: "all"},
+		{expr: "status:/act/", want: "all"},
+		{expr: "status:active status:completed", want: "all"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			q, err := ParseQuery(tt.expr, nil)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q) unexpected error: %v", tt.expr, err)
+			}
+			if got := q.Status(); got != tt.want {
+				t.Errorf("ParseQuery(%q).Status() = %q, want %q", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "30m", want: 30 * time.Minute},
+		{input: "12h", want: 12 * time.Hour},
+		{input: "7d", want: 7 * 24 * time.Hour},
+		{input: "2w", want: 14 * 24 * time.Hour},
+		{input: "1h30m", want: 90 * time.Minute},
+		{input: "xd", wantErr: true},
+		{input: "-1d", wantErr: true},
+		{input: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseAge(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseAge(%q) expected error, got nil", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAge(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseAge(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
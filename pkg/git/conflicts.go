@@ -0,0 +1,92 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// LocalConflict is a file left with conflict markers by a local merge
+type LocalConflict struct {
+	Path string `json:"path" yaml:"path"`
+	// Hunks are the conflicting regions, each including its <<<<<<< and >>>>>>> markers
+	Hunks []string `json:"hunks" yaml:"hunks"`
+}
+
+// ReproduceMerge merges sourceRef into targetRef in a temporary worktree and returns
+// the conflicting files with their conflict markers. The worktree is always removed
+// and the current checkout is never touched.
+func ReproduceMerge(sourceRef, targetRef string) ([]LocalConflict, error) {
+	dir, err := os.MkdirTemp("", "adoctl-merge-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	// git worktree add expects the path not to exist yet
+	worktree := filepath.Join(dir, "worktree")
+	defer os.RemoveAll(dir)
+
+	if err := runGit("worktree", "add", "--detach", worktree, targetRef); err != nil {
+		return nil, fmt.Errorf("failed to create worktree: %w", err)
+	}
+	defer func() {
+		_ = runGit("worktree", "remove", "--force", worktree)
+	}()
+
+	merge := exec.Command("git", "-C", worktree, "merge", "--no-commit", "--no-ff", sourceRef)
+	mergeOutput, mergeErr := merge.CombinedOutput()
+	if mergeErr == nil {
+		return nil, nil
+	}
+
+	// A failed merge is a conflict when it left unmerged paths; git's messages are
+	// localized, so they are only shown for other failures
+	diff := exec.Command("git", "-C", worktree, "diff", "--name-only", "--diff-filter=U")
+	output, err := diff.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conflicting files: %w", err)
+	}
+	if strings.TrimSpace(string(output)) == "" {
+		return nil, fmt.Errorf("failed to merge %s into %s: %s", sourceRef, targetRef, strings.TrimSpace(string(mergeOutput)))
+	}
+
+	conflicts := []LocalConflict{}
+	for _, path := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if path == "" {
+			continue
+		}
+
+		conflict := LocalConflict{Path: path}
+		// Deleted or renamed files have no content with markers
+		if data, readErr := os.ReadFile(filepath.Join(worktree, path)); readErr == nil {
+			conflict.Hunks = ParseConflictHunks(string(data))
+		}
+		conflicts = append(conflicts, conflict)
+	}
+
+	return conflicts, nil
+}
+
+// ParseConflictHunks extracts the regions between <<<<<<< and >>>>>>> markers
+func ParseConflictHunks(content string) []string {
+	hunks := []string{}
+	current := []string{}
+	inHunk := false
+
+	for _, line := range strings.Split(content, "\n") {
+		switch {
+		case strings.HasPrefix(line, "<<<<<<<"):
+			inHunk = true
+			current = []string{line}
+		case inHunk && strings.HasPrefix(line, ">>>>>>>"):
+			current = append(current, line)
+			hunks = append(hunks, strings.Join(current, "\n"))
+			inHunk = false
+		case inHunk:
+			current = append(current, line)
+		}
+	}
+
+	return hunks
+}
@@ -0,0 +1,47 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseConflictHunks(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "no markers",
+			content: "package main\n\nfunc main() {}\n",
+			want:    []string{},
+		},
+		{
+			name:    "single hunk",
+			content: "a\n<<<<<<< HEAD\nb\n=======\nc\n>>>>>>> feature\nd\n",
+			want:    []string{"<<<<<<< HEAD\nb\n=======\nc\n>>>>>>> feature"},
+		},
+		{
+			name:    "two hunks",
+			content: "<<<<<<< HEAD\n1\n=======\n2\n>>>>>>> f\nx\n<<<<<<< HEAD\n3\n=======\n>>>>>>> f\n",
+			want: []string{
+				"<<<<<<< HEAD\n1\n=======\n2\n>>>>>>> f",
+				"<<<<<<< HEAD\n3\n=======\n>>>>>>> f",
+			},
+		},
+		{
+			name:    "unterminated hunk is ignored",
+			content: "<<<<<<< HEAD\n1\n=======\n",
+			want:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseConflictHunks(tt.content)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseConflictHunks() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package git
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// StackFile is the file inside the .git directory where PR stacks are recorded
+const StackFile = "adoctl-stacks.json"
+
+// Stack is a chain of dependent branches, each with a PR targeting the branch below it
+type Stack struct {
+	Name string `json:"name"`
+	// Base is the branch the bottom of the stack targets, e.g. main
+	Base string `json:"base"`
+	// Branches are ordered from the bottom of the stack to the top
+	Branches []StackBranch `json:"branches"`
+}
+
+// StackBranch is one branch of a stack
+type StackBranch struct {
+	Name          string `json:"name"`
+	PullRequestID int    `json:"pullRequestId,omitempty"`
+	// ParentCommit is the parent branch commit this branch was last based on.
+	// It is the upstream used to rebase the branch once its parent changes.
+	ParentCommit string `json:"parentCommit,omitempty"`
+}
+
+// Stacks is the set of stacks recorded for a repository
+type Stacks struct {
+	Stacks []Stack `json:"stacks"`
+}
+
+// Parent returns the branch the i-th branch of the stack targets
+func (s *Stack) Parent(i int) string {
+	if i <= 0 {
+		return s.Base
+	}
+	return s.Branches[i-1].Name
+}
+
+// IndexOf returns the position of branch in the stack, or -1
+func (s *Stack) IndexOf(branch string) int {
+	for i, b := range s.Branches {
+		if b.Name == branch {
+			return i
+		}
+	}
+	return -1
+}
+
+// RemoveBranch removes a branch from the stack. Its child, if any, now targets
+// the removed branch's parent.
+func (s *Stack) RemoveBranch(branch string) bool {
+	i := s.IndexOf(branch)
+	if i < 0 {
+		return false
+	}
+	s.Branches = append(s.Branches[:i], s.Branches[i+1:]...)
+	return true
+}
+
+// Get returns the stack with the given name, or nil
+func (st *Stacks) Get(name string) *Stack {
+	for i := range st.Stacks {
+		if st.Stacks[i].Name == name {
+			return &st.Stacks[i]
+		}
+	}
+	return nil
+}
+
+// FindByBranch returns the stack containing branch, or nil
+func (st *Stacks) FindByBranch(branch string) *Stack {
+	for i := range st.Stacks {
+		if st.Stacks[i].IndexOf(branch) >= 0 {
+			return &st.Stacks[i]
+		}
+	}
+	return nil
+}
+
+// Add records a new stack
+func (st *Stacks) Add(stack Stack) error {
+	if stack.Name == "" {
+		return fmt.Errorf("stack name is required")
+	}
+	if st.Get(stack.Name) != nil {
+		return fmt.Errorf("stack '%s' already exists", stack.Name)
+	}
+	for _, b := range stack.Branches {
+		if other := st.FindByBranch(b.Name); other != nil {
+			return fmt.Errorf("branch '%s' is already in stack '%s'", b.Name, other.Name)
+		}
+	}
+
+	st.Stacks = append(st.Stacks, stack)
+	return nil
+}
+
+// Remove deletes a stack
+func (st *Stacks) Remove(name string) error {
+	for i := range st.Stacks {
+		if st.Stacks[i].Name == name {
+			st.Stacks = append(st.Stacks[:i], st.Stacks[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("stack '%s' not found", name)
+}
+
+// LoadStacks reads the stacks recorded at path. A missing file yields no stacks.
+func LoadStacks(path string) (*Stacks, error) {
+	stacks := &Stacks{}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return stacks, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stacks: %w", err)
+	}
+
+	if err := json.Unmarshal(data, stacks); err != nil {
+		return nil, fmt.Errorf("failed to parse stacks file %s: %w", path, err)
+	}
+
+	return stacks, nil
+}
+
+// Save writes the stacks to path
+func (st *Stacks) Save(path string) error {
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode stacks: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write stacks: %w", err)
+	}
+	return nil
+}
+
+// StacksPath returns the path of the stacks file of the current repository
+func StacksPath() (string, error) {
+	gitDir, err := GetGitDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(gitDir, StackFile), nil
+}
+
+// GetCommitHash resolves a ref to its commit hash
+func GetCommitHash(ref string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--verify", ref+"^{commit}")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetBranchSubject returns the subject of the latest commit on a branch
+func GetBranchSubject(branch string) (string, error) {
+	cmd := exec.Command("git", "log", "-1", "--format=%s", branch)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get last commit of %s: %w", branch, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Fetch fetches from a remote (default: origin)
+func Fetch(remote string) error {
+	if remote == "" {
+		remote = "origin"
+	}
+	return runGit("fetch", remote)
+}
+
+// Checkout switches to a branch
+func Checkout(branch string) error {
+	return runGit("checkout", branch)
+}
+
+// Push pushes a branch to a remote (default: origin) and sets it as upstream.
+// With force, the push uses --force-with-lease.
+func Push(remote, branch string, force bool) error {
+	if remote == "" {
+		remote = "origin"
+	}
+	args := []string{"push", "--set-upstream"}
+	if force {
+		args = append(args, "--force-with-lease")
+	}
+	return runGit(append(args, remote, branch)...)
+}
+
+// RebaseOnto replays the commits of branch after upstream onto newBase.
+// If the rebase fails, it is aborted and the branch is left unchanged.
+func RebaseOnto(newBase, upstream, branch string) error {
+	if err := runGit("rebase", "--onto", newBase, upstream, branch); err != nil {
+		_ = runGit("rebase", "--abort")
+		return fmt.Errorf("rebase of %s onto %s failed and was aborted: %w", branch, newBase, err)
+	}
+	return nil
+}
+
+// runGit runs a git command, including its stderr in the returned error
+func runGit(args ...string) error {
+	cmd := exec.Command("git", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package git
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func newTestStack() Stack {
+	return Stack{
+		Name: "feature",
+		Base: "main",
+		Branches: []StackBranch{
+			{Name: "feature/a", PullRequestID: 1},
+			{Name: "feature/b", PullRequestID: 2},
+			{Name: "feature/c", PullRequestID: 3},
+		},
+	}
+}
+
+func TestStack_Parent(t *testing.T) {
+	stack := newTestStack()
+
+	tests := []struct {
+		index int
+		want  string
+	}{
+		{index: 0, want: "main"},
+		{index: 1, want: "feature/a"},
+		{index: 2, want: "feature/b"},
+	}
+
+	for _, tt := range tests {
+		if got := stack.Parent(tt.index); got != tt.want {
+			t.Errorf("Parent(%d) = %q, want %q", tt.index, got, tt.want)
+		}
+	}
+}
+
+func TestStack_RemoveBranch(t *testing.T) {
+	tests := []struct {
+		name       string
+		branch     string
+		wantOK     bool
+		wantParent map[string]string
+	}{
+		{
+			name:       "remove bottom branch retargets child to base",
+			branch:     "feature/a",
+			wantOK:     true,
+			wantParent: map[string]string{"feature/b": "main", "feature/c": "feature/b"},
+		},
+		{
+			name:       "remove middle branch retargets child to grandparent",
+			branch:     "feature/b",
+			wantOK:     true,
+			wantParent: map[string]string{"feature/a": "main", "feature/c": "feature/a"},
+		},
+		{
+			name:       "unknown branch",
+			branch:     "feature/x",
+			wantOK:     false,
+			wantParent: map[string]string{"feature/a": "main", "feature/b": "feature/a", "feature/c": "feature/b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := newTestStack()
+			if got := stack.RemoveBranch(tt.branch); got != tt.wantOK {
+				t.Fatalf("RemoveBranch(%q) = %v, want %v", tt.branch, got, tt.wantOK)
+			}
+			if len(stack.Branches) != len(tt.wantParent) {
+				t.Fatalf("expected %d branches, got %d", len(tt.wantParent), len(stack.Branches))
+			}
+			for branch, parent := range tt.wantParent {
+				i := stack.IndexOf(branch)
+				if i < 0 {
+					t.Fatalf("branch %q missing from stack", branch)
+				}
+				if got := stack.Parent(i); got != parent {
+					t.Errorf("parent of %q = %q, want %q", branch, got, parent)
+				}
+			}
+		})
+	}
+}
+
+func TestStacks_Add(t *testing.T) {
+	stacks := &Stacks{}
+	if err := stacks.Add(newTestStack()); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		stack Stack
+	}{
+		{name: "duplicate name", stack: Stack{Name: "feature", Base: "main"}},
+		{name: "empty name", stack: Stack{Base: "main"}},
+		{name: "branch already stacked", stack: Stack{Name: "other", Base: "main", Branches: []StackBranch{{Name: "feature/b"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := stacks.Add(tt.stack); err == nil {
+				t.Error("Add() expected error, got nil")
+			}
+		})
+	}
+
+	if got := stacks.FindByBranch("feature/c"); got == nil || got.Name != "feature" {
+		t.Errorf("FindByBranch() = %v, want stack 'feature'", got)
+	}
+	if got := stacks.FindByBranch("main"); got != nil {
+		t.Errorf("FindByBranch(base) = %v, want nil", got)
+	}
+}
+
+func TestStacks_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), StackFile)
+
+	empty, err := LoadStacks(path)
+	if err != nil {
+		t.Fatalf("LoadStacks() on missing file failed: %v", err)
+	}
+	if len(empty.Stacks) != 0 {
+		t.Errorf("expected no stacks, got %d", len(empty.Stacks))
+	}
+
+	stacks := &Stacks{}
+	if err := stacks.Add(newTestStack()); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	if err := stacks.Save(path); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	loaded, err := LoadStacks(path)
+	if err != nil {
+		t.Fatalf("LoadStacks() failed: %v", err)
+	}
+	stack := loaded.Get("feature")
+	if stack == nil {
+		t.Fatal("Get() returned nil for saved stack")
+	}
+	if len(stack.Branches) != 3 || stack.Branches[2].PullRequestID != 3 {
+		t.Errorf("loaded stack = %+v, want 3 branches with PR #3 on top", stack)
+	}
+
+	if err := loaded.Remove("feature"); err != nil {
+		t.Fatalf("Remove() failed: %v", err)
+	}
+	if err := loaded.Remove("feature"); err == nil {
+		t.Error("Remove() expected error for missing stack")
+	}
+}
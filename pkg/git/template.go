@@ -0,0 +1,164 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// prTemplateDirs are the folders Azure DevOps searches for PR templates, in priority order
+var prTemplateDirs = []string{".azuredevops", ".vsts", "docs", ""}
+
+// prTemplateExtensions are the supported PR template file extensions, in priority order
+var prTemplateExtensions = []string{".md", ".txt"}
+
+// FindPRTemplate returns the path of the PR description template that applies to
+// targetBranch, following the Azure DevOps lookup rules:
+//
+//   - a branch-specific template in pull_request_template/branches/, named after
+//     the target branch or its first path segment (e.g. release.md for release/1.0)
+//   - the default pull_request_template.md or .txt
+//
+// Each is searched in .azuredevops, .vsts, docs and the repository root.
+// It returns an empty path if no template exists.
+func FindPRTemplate(repoRoot, targetBranch string) string {
+	targetBranch = strings.TrimPrefix(targetBranch, "refs/heads/")
+
+	branchNames := []string{}
+	if targetBranch != "" {
+		branchNames = append(branchNames, targetBranch)
+		if first := strings.SplitN(targetBranch, "/", 2)[0]; first != targetBranch {
+			branchNames = append(branchNames, first)
+		}
+	}
+
+	for _, dir := range prTemplateDirs {
+		for _, branch := range branchNames {
+			for _, ext := range prTemplateExtensions {
+				path := filepath.Join(repoRoot, dir, "pull_request_template", "branches", branch+ext)
+				if isFile(path) {
+					return path
+				}
+			}
+		}
+	}
+
+	for _, dir := range prTemplateDirs {
+		for _, ext := range prTemplateExtensions {
+			path := filepath.Join(repoRoot, dir, "pull_request_template"+ext)
+			if isFile(path) {
+				return path
+			}
+		}
+	}
+
+	return ""
+}
+
+// LoadPRTemplate reads the PR description template for targetBranch from the
+// current repository. It returns an empty string if no template exists.
+func LoadPRTemplate(targetBranch string) (string, error) {
+	root, err := GetRepositoryRoot()
+	if err != nil {
+		return "", err
+	}
+
+	path := FindPRTemplate(root, targetBranch)
+	if path == "" {
+		return "", nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read PR template %s: %w", path, err)
+	}
+
+	return string(data), nil
+}
+
+// GetMergeBase returns the best common ancestor of two refs
+func GetMergeBase(refA, refB string) (string, error) {
+	cmd := exec.Command("git", "merge-base", refA, refB)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get merge base of %s and %s: %w", refA, refB, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetCommitsSince returns the commits reachable from head but not from base, newest first
+func GetCommitsSince(base, head string) ([]Commit, error) {
+	cmd := exec.Command("git", "log", fmt.Sprintf("%s..%s", base, head), "--no-merges", "--format=%H%x00%s%x00%b%x00%x01")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits since %s: %w", base, err)
+	}
+
+	return parseCommits(string(output)), nil
+}
+
+// GenerateDescription fills a PR description from commits and linked work items.
+// Commit subjects go under the first heading mentioning "change" and work items under
+// the first heading mentioning "work item"; sections the template lacks are appended.
+// Commits are expected newest first, as returned by git log.
+func GenerateDescription(template string, commits []Commit, workItems []string) string {
+	changes := []string{}
+	for i := len(commits) - 1; i >= 0; i-- {
+		changes = append(changes, "- "+commits[i].Subject)
+	}
+
+	items := []string{}
+	for _, wi := range workItems {
+		items = append(items, "- "+wi)
+	}
+
+	if strings.TrimSpace(template) == "" {
+		template = "## Summary\n\n"
+		if len(commits) > 0 {
+			template += commits[len(commits)-1].Subject + "\n"
+		}
+	}
+
+	lines := strings.Split(strings.TrimRight(template, "\n"), "\n")
+	result := make([]string, 0, len(lines)+len(changes)+len(items))
+	changesPlaced := len(changes) == 0
+	itemsPlaced := len(items) == 0
+
+	for _, line := range lines {
+		result = append(result, line)
+
+		heading := strings.ToLower(strings.TrimSpace(line))
+		if !strings.HasPrefix(heading, "#") {
+			continue
+		}
+
+		switch {
+		case !changesPlaced && strings.Contains(heading, "change"):
+			result = append(result, "")
+			result = append(result, changes...)
+			changesPlaced = true
+		case !itemsPlaced && strings.Contains(heading, "work item"):
+			result = append(result, "")
+			result = append(result, items...)
+			itemsPlaced = true
+		}
+	}
+
+	if !changesPlaced {
+		result = append(result, "", "## Changes", "")
+		result = append(result, changes...)
+	}
+	if !itemsPlaced {
+		result = append(result, "", "## Work Items", "")
+		result = append(result, items...)
+	}
+
+	return strings.Join(result, "\n") + "\n"
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,136 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTemplate(t *testing.T, root, rel string) string {
+	t.Helper()
+	path := filepath.Join(root, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(rel), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFindPRTemplate(t *testing.T) {
+	tests := []struct {
+		name         string
+		files        []string
+		targetBranch string
+		want         string
+	}{
+		{
+			name:         "no templates",
+			files:        nil,
+			targetBranch: "main",
+			want:         "",
+		},
+		{
+			name:         "default template in .azuredevops",
+			files:        []string{".azuredevops/pull_request_template.md"},
+			targetBranch: "main",
+			want:         ".azuredevops/pull_request_template.md",
+		},
+		{
+			name:         "default template in root",
+			files:        []string{"pull_request_template.txt"},
+			targetBranch: "main",
+			want:         "pull_request_template.txt",
+		},
+		{
+			name:         ".azuredevops takes priority over docs",
+			files:        []string{"docs/pull_request_template.md", ".azuredevops/pull_request_template.md"},
+			targetBranch: "main",
+			want:         ".azuredevops/pull_request_template.md",
+		},
+		{
+			name:         "branch template takes priority over default",
+			files:        []string{".azuredevops/pull_request_template.md", "docs/pull_request_template/branches/main.md"},
+			targetBranch: "main",
+			want:         "docs/pull_request_template/branches/main.md",
+		},
+		{
+			name:         "branch template matched by first path segment",
+			files:        []string{".azuredevops/pull_request_template/branches/release.md"},
+			targetBranch: "refs/heads/release/1.0",
+			want:         ".azuredevops/pull_request_template/branches/release.md",
+		},
+		{
+			name:         "branch template for other branch is ignored",
+			files:        []string{".azuredevops/pull_request_template/branches/develop.md", ".vsts/pull_request_template.md"},
+			targetBranch: "main",
+			want:         ".vsts/pull_request_template.md",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for _, f := range tt.files {
+				writeTemplate(t, root, f)
+			}
+
+			got := FindPRTemplate(root, tt.targetBranch)
+			want := ""
+			if tt.want != "" {
+				want = filepath.Join(root, tt.want)
+			}
+			if got != want {
+				t.Errorf("FindPRTemplate() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestGenerateDescription(t *testing.T) {
+	commits := []Commit{
+		{Hash: "b", Subject: "Add tests"},
+		{Hash: "a", Subject: "Add login endpoint"},
+	}
+	workItems := []string{"#123 Login page"}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{
+			name:     "no template",
+			template: "",
+			want:     "## Summary\n\nAdd login endpoint\n\n## Changes\n\n- Add login endpoint\n- Add tests\n\n## Work Items\n\n- #123 Login page\n",
+		},
+		{
+			name:     "template with matching sections",
+			template: "## Description\n\n## Changes made\n\n## Related work items\n",
+			want:     "## Description\n\n## Changes made\n\n- Add login endpoint\n- Add tests\n\n## Related work items\n\n- #123 Login page\n",
+		},
+		{
+			name:     "template without matching sections",
+			template: "## Checklist\n- [ ] Tests added",
+			want:     "## Checklist\n- [ ] Tests added\n\n## Changes\n\n- Add login endpoint\n- Add tests\n\n## Work Items\n\n- #123 Login page\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GenerateDescription(tt.template, commits, workItems)
+			if got != tt.want {
+				t.Errorf("GenerateDescription() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("no work items", func(t *testing.T) {
+		got := GenerateDescription("", commits, nil)
+		if strings.Contains(got, "Work Items") {
+			t.Errorf("GenerateDescription() should not add a work items section, got\n%s", got)
+		}
+	})
+}
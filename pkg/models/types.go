@@ -2,6 +2,7 @@ package models
 
 import (
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
@@ -67,6 +68,18 @@ type PullRequest struct {
 	Repository   Repository
 	CreatedBy    Identity
 	IsDraft      bool
+	CreationDate time.Time
+	Reviewers    []Reviewer
+	// AutoCompleteSetBy is the identity that armed auto-complete, if any
+	AutoCompleteSetBy Identity
+}
+
+// Reviewer represents a pull request reviewer and their vote
+type Reviewer struct {
+	Identity
+	Vote        int
+	IsRequired  bool
+	IsContainer bool
 }
 
 // PullRequestFromAzure converts an Azure DevOps GitPullRequest to our domain model
@@ -78,11 +91,13 @@ func PullRequestFromAzure(pr *git.GitPullRequest) PullRequest {
 	result := PullRequest{
 		Repository:   RepositoryFromAzure(pr.Repository),
 		CreatedBy:    IdentityFromAzure(pr.CreatedBy),
-		SourceBranch: dereferenceString(pr.SourceRefName),
-		TargetBranch: dereferenceString(pr.TargetRefName),
-		Title:        dereferenceString(pr.Title),
-		Description:  dereferenceString(pr.Description),
-		URL:          dereferenceString(pr.Url),
+		SourceBranch: DereferenceString(pr.SourceRefName),
+		TargetBranch: DereferenceString(pr.TargetRefName),
+		Title:        DereferenceString(pr.Title),
+		Description:  DereferenceString(pr.Description),
+		URL:          DereferenceString(pr.Url),
+
+		AutoCompleteSetBy: IdentityFromAzure(pr.AutoCompleteSetBy),
 	}
 
 	if pr.PullRequestId != nil {
@@ -101,6 +116,42 @@ func PullRequestFromAzure(pr *git.GitPullRequest) PullRequest {
 		result.IsDraft = *pr.IsDraft
 	}
 
+	if pr.CreationDate != nil {
+		result.CreationDate = pr.CreationDate.Time
+	}
+
+	if pr.Reviewers != nil {
+		for _, r := range *pr.Reviewers {
+			result.Reviewers = append(result.Reviewers, ReviewerFromAzure(r))
+		}
+	}
+
+	return result
+}
+
+// ReviewerFromAzure converts an Azure DevOps IdentityRefWithVote to our domain model
+func ReviewerFromAzure(ref git.IdentityRefWithVote) Reviewer {
+	result := Reviewer{
+		Identity: Identity{
+			ID:          DereferenceString(ref.Id),
+			DisplayName: DereferenceString(ref.DisplayName),
+			UniqueName:  DereferenceString(ref.UniqueName),
+			URL:         DereferenceString(ref.Url),
+			ImageURL:    DereferenceString(ref.ImageUrl),
+			Descriptor:  DereferenceString(ref.Descriptor),
+		},
+	}
+
+	if ref.Vote != nil {
+		result.Vote = *ref.Vote
+	}
+	if ref.IsRequired != nil {
+		result.IsRequired = *ref.IsRequired
+	}
+	if ref.IsContainer != nil {
+		result.IsContainer = *ref.IsContainer
+	}
+
 	return result
 }
 
@@ -112,15 +163,15 @@ func RepositoryFromAzure(repo *git.GitRepository) Repository {
 
 	result := Repository{
 		ID:        dereferenceGUID(repo.Id),
-		Name:      dereferenceString(repo.Name),
-		URL:       dereferenceString(repo.Url),
-		RemoteURL: dereferenceString(repo.RemoteUrl),
+		Name:      DereferenceString(repo.Name),
+		URL:       DereferenceString(repo.Url),
+		RemoteURL: DereferenceString(repo.RemoteUrl),
 	}
 
 	if repo.Project != nil {
 		result.Project = Project{
 			ID:   dereferenceGUID(repo.Project.Id),
-			Name: dereferenceString(repo.Project.Name),
+			Name: DereferenceString(repo.Project.Name),
 		}
 	}
 
@@ -134,23 +185,23 @@ func IdentityFromAzure(ref *webapi.IdentityRef) Identity {
 	}
 
 	return Identity{
-		ID:          dereferenceString(ref.Id),
-		DisplayName: dereferenceString(ref.DisplayName),
-		UniqueName:  dereferenceString(ref.UniqueName),
-		URL:         dereferenceString(ref.Url),
-		ImageURL:    dereferenceString(ref.ImageUrl),
-		Descriptor:  dereferenceString(ref.Descriptor),
+		ID:          DereferenceString(ref.Id),
+		DisplayName: DereferenceString(ref.DisplayName),
+		UniqueName:  DereferenceString(ref.UniqueName),
+		URL:         DereferenceString(ref.Url),
+		ImageURL:    DereferenceString(ref.ImageUrl),
+		Descriptor:  DereferenceString(ref.Descriptor),
 	}
 }
 
 // GetSourceBranchName returns the source branch name without refs/heads/ prefix
 func (pr *PullRequest) GetSourceBranchName() string {
-	return normalizeBranchName(pr.SourceBranch)
+	return NormalizeBranchName(pr.SourceBranch)
 }
 
 // GetTargetBranchName returns the target branch name without refs/heads/ prefix
 func (pr *PullRequest) GetTargetBranchName() string {
-	return normalizeBranchName(pr.TargetBranch)
+	return NormalizeBranchName(pr.TargetBranch)
 }
 
 // HasMergeConflicts returns true if the PR has merge conflicts
@@ -158,13 +209,18 @@ func (pr *PullRequest) HasMergeConflicts() bool {
 	return pr.MergeStatus == MergeStatusConflicts
 }
 
+// HasAutoComplete returns true if auto-complete is armed on the PR
+func (pr *PullRequest) HasAutoComplete() bool {
+	return pr.AutoCompleteSetBy.ID != ""
+}
+
 // IsMergeable returns true if the PR can be merged without conflicts
 func (pr *PullRequest) IsMergeable() bool {
 	return pr.MergeStatus == MergeStatusSucceeded
 }
 
-// normalizeBranchName removes refs/heads/ or refs/tags/ prefix from branch names
-func normalizeBranchName(refName string) string {
+// NormalizeBranchName removes refs/heads/ or refs/tags/ prefix from branch names
+func NormalizeBranchName(refName string) string {
 	const refsHeads = "refs/heads/"
 	const refsTags = "refs/tags/"
 
@@ -179,8 +235,8 @@ func normalizeBranchName(refName string) string {
 	return refName
 }
 
-// dereferenceString safely dereferences a string pointer
-func dereferenceString(s *string) string {
+// DereferenceString safely dereferences a string pointer
+func DereferenceString(s *string) string {
 	if s == nil {
 		return ""
 	}